@@ -23,6 +23,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"github.com/chronicleprotocol/oracle-suite/pkg/config"
 	"github.com/chronicleprotocol/oracle-suite/pkg/supervisor"
 )
 
@@ -51,6 +52,9 @@ func NewRunCmd(c supervisor.Config, f *ConfigFlags, l *LoggerFlags) *cobra.Comma
 			if err := f.Load(c); err != nil {
 				return err
 			}
+			if l, ok := c.(config.HasLoader); ok {
+				l.SetLoader(f.Load)
+			}
 			s, err := c.Services(l.Logger(), cc.Root().Use, cc.Root().Version)
 			if err != nil {
 				return err
@@ -0,0 +1,238 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/config"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/supervisor"
+	utilHCL "github.com/chronicleprotocol/oracle-suite/pkg/util/hcl"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/hcl/ext/secrets"
+)
+
+// NewConfigCmd returns a command group with subcommands that operate on
+// the configuration without running the application.
+func NewConfigCmd(c supervisor.Config, f *ConfigFlags) *cobra.Command {
+	cc := &cobra.Command{
+		Use:   "config",
+		Args:  cobra.NoArgs,
+		Short: "Configuration related commands",
+	}
+	cc.AddCommand(
+		newConfigValidateCmd(c, f),
+		newConfigDiffCmd(c),
+		newConfigEncryptSecretsCmd(),
+	)
+	return cc
+}
+
+func newConfigValidateCmd(c supervisor.Config, f *ConfigFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Args:  cobra.NoArgs,
+		Short: "Validate the configuration by building all services without starting them",
+		RunE: func(cc *cobra.Command, _ []string) error {
+			err := f.Load(c)
+			if err == nil {
+				_, err = c.Services(null.New(), cc.Root().Use, cc.Root().Version)
+			}
+			if err != nil {
+				printDiagnostics(cc.ErrOrStderr(), err)
+				return fmt.Errorf("configuration is invalid")
+			}
+			fmt.Fprintln(cc.OutOrStdout(), "Configuration is valid")
+			return nil
+		},
+	}
+}
+
+func newConfigDiffCmd(c supervisor.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff OLD NEW",
+		Args:  cobra.ExactArgs(2),
+		Short: "List models, feeds, contracts and other settings that differ between two configurations",
+		Long: "List models, feeds, contracts and other settings that differ between two configurations.\n\n" +
+			"OLD and NEW are comma-separated lists of config files.",
+		RunE: func(cc *cobra.Command, args []string) error {
+			typ := reflect.TypeOf(c).Elem()
+			oldCfg := reflect.New(typ).Interface()
+			newCfg := reflect.New(typ).Interface()
+			redact := secretPlaceholders()
+			if err := config.LoadFilesRedacted(oldCfg, strings.Split(args[0], ","), redact); err != nil {
+				printDiagnostics(cc.ErrOrStderr(), err)
+				return fmt.Errorf("unable to load the old configuration")
+			}
+			if err := config.LoadFilesRedacted(newCfg, strings.Split(args[1], ","), redact); err != nil {
+				printDiagnostics(cc.ErrOrStderr(), err)
+				return fmt.Errorf("unable to load the new configuration")
+			}
+			printDiff(cc.OutOrStdout(), config.Diff(oldCfg, newCfg))
+			return nil
+		},
+	}
+}
+
+func newConfigEncryptSecretsCmd() *cobra.Command {
+	var passphraseFile string
+	cc := &cobra.Command{
+		Use:   "encrypt-secrets",
+		Args:  cobra.NoArgs,
+		Short: "Encrypt a JSON object with secrets read from stdin for use with the encrypted_file secret provider",
+		RunE: func(cc *cobra.Command, _ []string) error {
+			passphrase, err := os.ReadFile(passphraseFile)
+			if err != nil {
+				return fmt.Errorf("unable to read passphrase file: %w", err)
+			}
+			var values map[string]string
+			if err := json.NewDecoder(cc.InOrStdin()).Decode(&values); err != nil {
+				return fmt.Errorf("unable to decode secrets: %w", err)
+			}
+			encrypted, err := secrets.EncryptSecrets(values, []byte(strings.TrimSuffix(string(passphrase), "\n")))
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cc.OutOrStdout(), string(encrypted))
+			return nil
+		},
+	}
+	cc.Flags().StringVar(&passphraseFile, "passphrase-file", "", "file containing the passphrase used to encrypt secrets")
+	_ = cc.MarkFlagRequired("passphrase-file")
+	return cc
+}
+
+// secretPlaceholders returns a redact function that replaces every distinct
+// secret with a distinct placeholder, so changed secrets are still listed
+// by the diff command without revealing their values.
+func secretPlaceholders() func(string) string {
+	ids := map[string]int{}
+	return func(s string) string {
+		id, ok := ids[s]
+		if !ok {
+			id = len(ids) + 1
+			ids[s] = id
+		}
+		return fmt.Sprintf("%s#%d]", strings.TrimSuffix(utilHCL.Redacted, "]"), id)
+	}
+}
+
+// printDiagnostics prints HCL diagnostics one per line, together with their
+// source ranges. Other errors are printed as they are.
+func printDiagnostics(w io.Writer, err error) {
+	var diags hcl.Diagnostics
+	switch e := err.(type) {
+	case hcl.Diagnostics:
+		diags = e
+	case *hcl.Diagnostic:
+		diags = hcl.Diagnostics{e}
+	default:
+		fmt.Fprintln(w, err)
+		return
+	}
+	for _, d := range diags {
+		fmt.Fprintln(w, d.Error())
+	}
+}
+
+// Categories of changes printed by the diff command:
+const (
+	diffModels    = "Models"
+	diffFeeds     = "Feeds"
+	diffContracts = "Contracts"
+	diffOther     = "Other"
+)
+
+// printDiff prints changes grouped by category. Changes to models and feeds
+// are reduced to names of models and feed addresses, other changes are
+// printed with their old and new values.
+func printDiff(w io.Writer, changes []config.Change) {
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "No changes")
+		return
+	}
+	groups := map[string]map[string]config.ChangeKind{}
+	for _, ch := range changes {
+		category, key := diffCategory(ch)
+		if groups[category] == nil {
+			groups[category] = map[string]config.ChangeKind{}
+		}
+		if kind, ok := groups[category][key]; ok && kind != ch.Kind {
+			// Some values of the model were added and some removed.
+			groups[category][key] = config.Modified
+			continue
+		}
+		groups[category][key] = ch.Kind
+	}
+	for _, category := range []string{diffModels, diffFeeds, diffContracts, diffOther} {
+		if len(groups[category]) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "%s:\n", category)
+		keys := make([]string, 0, len(groups[category]))
+		for k := range groups[category] {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(w, "  %s %s\n", groups[category][k], k)
+		}
+	}
+}
+
+// diffCategory returns the category of the change and the key under which
+// it is printed.
+func diffCategory(ch config.Change) (string, string) {
+	for _, p := range ch.Path {
+		name, key, ok := strings.Cut(p, "[")
+		if !ok {
+			continue
+		}
+		key = strings.Trim(strings.TrimSuffix(key, "]"), `"`)
+		switch name {
+		case "data_model", "data_models":
+			return diffModels, key
+		case "feeds":
+			return diffFeeds, key
+		}
+	}
+	path := ch.String()
+	value := ""
+	switch ch.Kind {
+	case config.Added:
+		value = ch.New
+	case config.Removed:
+		value = ch.Old
+	default:
+		value = fmt.Sprintf("%s -> %s", ch.Old, ch.New)
+	}
+	if value != "" {
+		path = fmt.Sprintf("%s: %s", path, value)
+	}
+	if strings.Contains(ch.String(), "contract") {
+		return diffContracts, path
+	}
+	return diffOther, path
+}
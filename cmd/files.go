@@ -24,6 +24,7 @@ import (
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/config"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/globals"
+	utilHCL "github.com/chronicleprotocol/oracle-suite/pkg/util/hcl"
 )
 
 func ConfigFlagsForConfig(d config.HasDefaults) ConfigFlags {
@@ -43,14 +44,8 @@ type ConfigFlags struct {
 
 // Load loads the config files into the given config struct.
 func (ff *ConfigFlags) Load(c any) error {
-	if len(ff.paths) == 0 {
-		if err := config.LoadEmbeds(c, ff.embeds); err != nil {
-			return err
-		}
-	} else {
-		if err := config.LoadFiles(c, ff.paths); err != nil {
-			return err
-		}
+	if err := ff.load(c); err != nil {
+		return err
 	}
 	switch {
 	case globals.ShowEnvVarsUsedInConfig:
@@ -69,6 +64,22 @@ func (ff *ConfigFlags) Load(c any) error {
 	return nil
 }
 
+// load loads the config files into the given config struct. When the config
+// is going to be rendered as JSON, secrets are redacted while decoding.
+func (ff *ConfigFlags) load(c any) error {
+	if globals.RenderConfigJSON {
+		redact := func(string) string { return utilHCL.Redacted }
+		if len(ff.paths) == 0 {
+			return config.LoadEmbedsRedacted(c, ff.embeds, redact)
+		}
+		return config.LoadFilesRedacted(c, ff.paths, redact)
+	}
+	if len(ff.paths) == 0 {
+		return config.LoadEmbeds(c, ff.embeds)
+	}
+	return config.LoadFiles(c, ff.paths)
+}
+
 // FlagSet binds CLI args [--config or -c] for config files as a pflag.FlagSet.
 func (ff *ConfigFlags) FlagSet() *pflag.FlagSet {
 	fs := pflag.NewFlagSet("config", pflag.PanicOnError)
@@ -0,0 +1,241 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/sliceutil"
+)
+
+// API paths:
+const (
+	apiModelsPath = "/models"
+	apiDataPath   = "/data"
+	apiStreamPath = "/stream"
+)
+
+// apiHandler is an HTTP API over the datapoint.Provider.
+//
+// Endpoints:
+//   - GET /models - all models
+//   - GET /models/{model} - a single model
+//   - GET /data?models=A,B - data points for given models, or all models if
+//     the models parameter is omitted
+//   - GET /data/{model} - a data point for a single model
+//   - GET /stream/{model} - server-sent events with data point updates
+//
+// Model names may contain slashes, e.g. /data/BTC/USD. The response format
+// can be selected using the format query parameter, which accepts the same
+// values as the --format flag. The default format is JSON.
+type apiHandler struct {
+	provider datapoint.Provider
+	interval time.Duration
+	mux      *http.ServeMux
+}
+
+func newAPIHandler(provider datapoint.Provider, interval time.Duration) *apiHandler {
+	h := &apiHandler{
+		provider: provider,
+		interval: interval,
+		mux:      http.NewServeMux(),
+	}
+	h.mux.HandleFunc(apiModelsPath, h.handleModels)
+	h.mux.HandleFunc(apiModelsPath+"/", h.handleModels)
+	h.mux.HandleFunc(apiDataPath, h.handleData)
+	h.mux.HandleFunc(apiDataPath+"/", h.handleData)
+	h.mux.HandleFunc(apiStreamPath+"/", h.handleStream)
+	return h
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *apiHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	h.mux.ServeHTTP(rw, r)
+}
+
+func (h *apiHandler) handleModels(rw http.ResponseWriter, r *http.Request) {
+	format, ok := requestFormat(rw, r)
+	if !ok {
+		return
+	}
+	models, ok := h.requestModels(rw, r, apiModelsPath)
+	if !ok {
+		return
+	}
+	res, err := h.provider.Models(r.Context(), models...)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	marshaled, err := marshalModels(res, format)
+	writeResponse(rw, format, marshaled, err)
+}
+
+func (h *apiHandler) handleData(rw http.ResponseWriter, r *http.Request) {
+	format, ok := requestFormat(rw, r)
+	if !ok {
+		return
+	}
+	models, ok := h.requestModels(rw, r, apiDataPath)
+	if !ok {
+		return
+	}
+	res, err := h.provider.DataPoints(r.Context(), models...)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	marshaled, err := marshalDataPoints(res, format)
+	writeResponse(rw, format, marshaled, err)
+}
+
+// handleStream sends a data point for the model every time it changes.
+// The data point is checked for changes in the interval given to
+// newAPIHandler.
+func (h *apiHandler) handleStream(rw http.ResponseWriter, r *http.Request) {
+	format, ok := requestFormat(rw, r)
+	if !ok {
+		return
+	}
+	models, ok := h.requestModels(rw, r, apiStreamPath)
+	if !ok {
+		return
+	}
+	flusher, ok := rw.(http.Flusher)
+	if !ok {
+		http.Error(rw, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	rw.Header().Set("Connection", "keep-alive")
+	rw.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	var last []byte
+	for {
+		last = h.streamEvent(r.Context(), rw, models[0], format, last)
+		flusher.Flush()
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// streamEvent writes an event with the data point for the model if it
+// differs from the last sent one. It returns the marshaled data point.
+func (h *apiHandler) streamEvent(ctx context.Context, rw http.ResponseWriter, model, format string, last []byte) []byte {
+	point, err := h.provider.DataPoint(ctx, model)
+	if err != nil {
+		writeEvent(rw, "error", []byte(err.Error()))
+		return last
+	}
+	marshaled, err := marshalDataPoints(map[string]datapoint.Point{model: point}, format)
+	if err != nil {
+		writeEvent(rw, "error", []byte(err.Error()))
+		return last
+	}
+	if bytes.Equal(marshaled, last) {
+		return last
+	}
+	writeEvent(rw, "data", marshaled)
+	return marshaled
+}
+
+// requestModels returns the list of models requested either in the path,
+// after the given prefix, or in the models query parameter. If the stream
+// prefix is used, exactly one model must be given in the path.
+func (h *apiHandler) requestModels(rw http.ResponseWriter, r *http.Request, prefix string) ([]string, bool) {
+	var models []string
+	if name := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, prefix), "/"); name != "" {
+		models = []string{name}
+	} else if q := r.URL.Query().Get("models"); q != "" {
+		models = strings.Split(q, ",")
+	}
+	if prefix == apiStreamPath && len(models) != 1 {
+		http.Error(rw, "model must be given in the path", http.StatusBadRequest)
+		return nil, false
+	}
+	names := h.provider.ModelNames(r.Context())
+	if len(models) == 0 {
+		return names, true
+	}
+	for _, m := range models {
+		if !sliceutil.Contains(names, m) {
+			http.Error(rw, fmt.Sprintf("model %s not found", m), http.StatusNotFound)
+			return nil, false
+		}
+	}
+	return models, true
+}
+
+// requestFormat returns the output format requested in the format query
+// parameter.
+func requestFormat(rw http.ResponseWriter, r *http.Request) (string, bool) {
+	q := r.URL.Query().Get("format")
+	if q == "" {
+		return formatJSON, true
+	}
+	var format formatTypeValue
+	if err := format.Set(q); err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return "", false
+	}
+	return format.String(), true
+}
+
+func writeResponse(rw http.ResponseWriter, format string, body []byte, err error) {
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if format == formatJSON {
+		rw.Header().Set("Content-Type", "application/json")
+	} else {
+		rw.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	_, _ = rw.Write(body)
+}
+
+// writeEvent writes a server-sent event. Multiline data is split into
+// multiple data fields.
+func writeEvent(rw http.ResponseWriter, event string, data []byte) {
+	var buf bytes.Buffer
+	buf.WriteString("event: ")
+	buf.WriteString(event)
+	buf.WriteString("\n")
+	for _, line := range bytes.Split(bytes.TrimRight(data, "\n"), []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(line)
+		buf.WriteString("\n")
+	}
+	buf.WriteString("\n")
+	_, _ = rw.Write(buf.Bytes())
+}
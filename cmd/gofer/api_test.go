@@ -0,0 +1,148 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/mocks"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+func testPoint(v float64) datapoint.Point {
+	return datapoint.Point{
+		Value: value.StaticValue{Value: bn.DecFloatPoint(v)},
+		Time:  time.Unix(1700000000, 0),
+	}
+}
+
+func testModel(name string) datapoint.Model {
+	return datapoint.Model{Meta: map[string]any{"type": "origin", "name": name}}
+}
+
+func TestAPIHandler(t *testing.T) {
+	provider := &mocks.Provider{}
+	provider.On("ModelNames", mock.Anything).Return([]string{"BTC/USD", "ETH/USD"})
+	provider.On("Models", mock.Anything, []string{"BTC/USD", "ETH/USD"}).Return(map[string]datapoint.Model{
+		"BTC/USD": testModel("BTC/USD"),
+		"ETH/USD": testModel("ETH/USD"),
+	}, nil)
+	provider.On("Models", mock.Anything, []string{"BTC/USD"}).Return(map[string]datapoint.Model{
+		"BTC/USD": testModel("BTC/USD"),
+	}, nil)
+	provider.On("DataPoints", mock.Anything, []string{"BTC/USD", "ETH/USD"}).Return(map[string]datapoint.Point{
+		"BTC/USD": testPoint(30000),
+		"ETH/USD": testPoint(2000),
+	}, nil)
+	provider.On("DataPoints", mock.Anything, []string{"ETH/USD"}).Return(map[string]datapoint.Point{
+		"ETH/USD": testPoint(2000),
+	}, nil)
+
+	srv := httptest.NewServer(newAPIHandler(provider, time.Second))
+	defer srv.Close()
+
+	tests := []struct {
+		path        string
+		status      int
+		contentType string
+		keys        []string
+		contains    string
+	}{
+		{path: "/models", status: http.StatusOK, contentType: "application/json", keys: []string{"BTC/USD", "ETH/USD"}},
+		{path: "/models/BTC/USD", status: http.StatusOK, contentType: "application/json", keys: []string{"BTC/USD"}},
+		{path: "/models/XXX/USD", status: http.StatusNotFound},
+		{path: "/data", status: http.StatusOK, contentType: "application/json", keys: []string{"BTC/USD", "ETH/USD"}},
+		{path: "/data?models=BTC/USD,ETH/USD", status: http.StatusOK, contentType: "application/json", keys: []string{"BTC/USD", "ETH/USD"}},
+		{path: "/data/ETH/USD", status: http.StatusOK, contentType: "application/json", keys: []string{"ETH/USD"}},
+		{path: "/data/ETH/USD?format=plain", status: http.StatusOK, contentType: "text/plain; charset=utf-8", contains: "ETH/USD: 2000"},
+		{path: "/data/ETH/USD?format=xml", status: http.StatusBadRequest},
+		{path: "/data?models=ETH/USD,XXX/USD", status: http.StatusNotFound},
+		{path: "/stream", status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			res, err := http.Get(srv.URL + tt.path)
+			require.NoError(t, err)
+			defer res.Body.Close()
+			assert.Equal(t, tt.status, res.StatusCode)
+			if tt.status != http.StatusOK {
+				return
+			}
+			assert.Equal(t, tt.contentType, res.Header.Get("Content-Type"))
+			if len(tt.keys) > 0 {
+				var body map[string]json.RawMessage
+				require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+				var keys []string
+				for k := range body {
+					keys = append(keys, k)
+				}
+				assert.ElementsMatch(t, tt.keys, keys)
+			}
+			if tt.contains != "" {
+				var sb strings.Builder
+				_, _ = bufio.NewReader(res.Body).WriteTo(&sb)
+				assert.Contains(t, sb.String(), tt.contains)
+			}
+		})
+	}
+}
+
+func TestAPIHandler_Stream(t *testing.T) {
+	provider := &mocks.Provider{}
+	provider.On("ModelNames", mock.Anything).Return([]string{"BTC/USD"})
+	provider.On("DataPoint", mock.Anything, "BTC/USD").Return(testPoint(1), nil).Twice()
+	provider.On("DataPoint", mock.Anything, "BTC/USD").Return(testPoint(2), nil)
+
+	srv := httptest.NewServer(newAPIHandler(provider, 10*time.Millisecond))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/stream/BTC/USD", nil)
+	require.NoError(t, err)
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	// Unchanged data points must not be sent again, so only two events
+	// are expected.
+	var events []string
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() && len(events) < 4 {
+		if line := scanner.Text(); line != "" {
+			events = append(events, line)
+		}
+	}
+	require.Len(t, events, 4)
+	assert.Equal(t, "event: data", events[0])
+	assert.Contains(t, events[1], `"value":"1"`)
+	assert.Equal(t, "event: data", events[2])
+	assert.Contains(t, events[3], `"value":"2"`)
+}
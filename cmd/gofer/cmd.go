@@ -18,9 +18,17 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/spf13/pflag"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/cassette"
+	gofer "github.com/chronicleprotocol/oracle-suite/pkg/config/gofernext"
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/origin"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/supervisor"
 )
 
 const (
@@ -64,3 +72,66 @@ func getModelsNames(ctx context.Context, provider datapoint.Provider, args []str
 	}
 	return args
 }
+
+// captureOrigins configures the config to record calls to origins in the
+// file at the given path. Captures are appended to the file, so it can be
+// used across multiple runs. The returned function closes the file.
+func captureOrigins(c supervisor.Config, path string, logger log.Logger) (func() error, error) {
+	gc, ok := c.(*gofer.Config)
+	if !ok {
+		return nil, fmt.Errorf("config is not gofer.Config")
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	gc.SetOriginWrapper(origin.NewRecorder(f, logger).Wrap)
+	return f.Close, nil
+}
+
+// cassetteFlags is used to record or replay HTTP and RPC responses.
+type cassetteFlags struct {
+	record string
+	replay string
+}
+
+// FlagSet binds CLI args [--record or --replay] as a pflag.FlagSet.
+func (cf *cassetteFlags) FlagSet() *pflag.FlagSet {
+	fs := pflag.NewFlagSet("cassette", pflag.PanicOnError)
+	fs.StringVar(
+		&cf.record,
+		"record",
+		"",
+		"record HTTP and RPC responses as fixtures in the given directory",
+	)
+	fs.StringVar(
+		&cf.replay,
+		"replay",
+		"",
+		"replay HTTP and RPC responses from fixtures in the given directory instead of making requests",
+	)
+	return fs
+}
+
+// configure sets the cassette on the config if any of the flags is used.
+func (cf *cassetteFlags) configure(c *gofer.Config) error {
+	var (
+		cas *cassette.Cassette
+		err error
+	)
+	switch {
+	case cf.record != "" && cf.replay != "":
+		return fmt.Errorf("--record and --replay flags cannot be used together")
+	case cf.record != "":
+		cas, err = cassette.New(cf.record, cassette.Record)
+	case cf.replay != "":
+		cas, err = cassette.New(cf.replay, cassette.Replay)
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	c.SetCassette(cas)
+	return nil
+}
@@ -0,0 +1,372 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/chronicleprotocol/oracle-suite/cmd"
+	"github.com/chronicleprotocol/oracle-suite/pkg/config"
+	gofer "github.com/chronicleprotocol/oracle-suite/pkg/config/gofernext"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/graph"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/origin"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/sliceutil"
+)
+
+func NewBacktestCmd(l *cmd.LoggerFlags) *cobra.Command {
+	var (
+		format     formatTypeValue
+		models     []string
+		step       time.Duration
+		spread     float64
+		expiration time.Duration
+	)
+	cc := &cobra.Command{
+		Use:   "backtest CAPTURES CONFIG...",
+		Args:  cobra.MinimumNArgs(2),
+		Short: "Replay recorded origin captures through one or more model configurations",
+		Long: "Replay recorded origin captures through one or more model configurations.\n\n" +
+			"CAPTURES is a file created using the --capture flag of the data or serve commands.\n" +
+			"Each CONFIG is a comma-separated list of config files.\n\n" +
+			"Captures are replayed using a simulated clock that advances by the step\n" +
+			"interval from the first to the last capture. At every step, all data models\n" +
+			"are evaluated using the most recent captures. The report contains the price\n" +
+			"series of every model, the deviation of every configuration from the first one\n" +
+			"and the number of relay updates that would occur under the given spread and\n" +
+			"expiration settings.",
+		RunE: func(cc *cobra.Command, args []string) error {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			captures, err := origin.ReadCaptures(f)
+			f.Close()
+			if err != nil {
+				return err
+			}
+			if len(captures) == 0 {
+				return fmt.Errorf("no captures found in %s", args[0])
+			}
+			if step <= 0 {
+				return fmt.Errorf("step must be positive")
+			}
+			var clock time.Time
+			providers := make([]graph.Provider, len(args)-1)
+			for i, paths := range args[1:] {
+				var c gofer.Config
+				if err := config.LoadFiles(&c, strings.Split(paths, ",")); err != nil {
+					return err
+				}
+				c.SetOriginWrapper(func(name string, _ origin.Origin) origin.Origin {
+					return origin.NewReplay(name, captures, func() time.Time { return clock })
+				})
+				services, err := c.Services(l.Logger(), cc.Root().Use, cc.Root().Version)
+				if err != nil {
+					return err
+				}
+				s, ok := services.(*gofer.Services)
+				if !ok {
+					return fmt.Errorf("services are not gofer.Services")
+				}
+				p, ok := s.DataProvider.(*graph.ReloadableProvider)
+				if !ok {
+					return fmt.Errorf("data provider is not graph.ReloadableProvider")
+				}
+				providers[i] = p.Provider()
+			}
+			ctx, ctxCancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer ctxCancel()
+			if len(models) == 0 {
+				for _, p := range providers {
+					for _, model := range p.ModelNames(ctx) {
+						if !sliceutil.Contains(models, model) {
+							models = append(models, model)
+						}
+					}
+				}
+				sort.Strings(models)
+			}
+			r := newBacktestReport(args[1:], models)
+			from, to := captures[0].Time, captures[len(captures)-1].Time
+			for clock = from; ; clock = clock.Add(step) {
+				if clock.After(to) {
+					// Make sure the last capture is always evaluated.
+					if !clock.Add(-step).Before(to) {
+						break
+					}
+					clock = to
+				}
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				points := make([]map[string]datapoint.Point, len(providers))
+				for i, p := range providers {
+					p.Refresh(ctx)
+					points[i] = make(map[string]datapoint.Point)
+					for _, model := range models {
+						point, err := p.DataPoint(ctx, model)
+						if err != nil {
+							point = datapoint.Point{Error: err}
+						}
+						points[i][model] = point
+					}
+				}
+				r.add(clock, points)
+			}
+			r.summarize(spread, expiration)
+			marshaled, err := r.marshal(format.String())
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(marshaled))
+			return nil
+		},
+	}
+	cc.Flags().VarP(
+		&format,
+		"format",
+		"o",
+		"output format",
+	)
+	cc.Flags().StringSliceVar(
+		&models,
+		"models",
+		nil,
+		"models to evaluate, all models by default",
+	)
+	cc.Flags().DurationVar(
+		&step,
+		"step",
+		time.Minute,
+		"interval by which the simulated clock advances",
+	)
+	cc.Flags().Float64Var(
+		&spread,
+		"spread",
+		1,
+		"minimum price change in percent that triggers a relay update",
+	)
+	cc.Flags().DurationVar(
+		&expiration,
+		"expiration",
+		time.Hour,
+		"maximum age of a relayed price after which a relay update occurs",
+	)
+	return cc
+}
+
+// backtestReport is a result of the backtest command.
+type backtestReport struct {
+	Configs []string                        `json:"configs"`
+	Models  map[string]*backtestModelReport `json:"models"`
+}
+
+type backtestModelReport struct {
+	// Series contains values of the model for every step of the simulated
+	// clock.
+	Series []backtestSample `json:"series"`
+
+	// Deviations contains deviations of every configuration from the first
+	// one. The first element is always zero.
+	Deviations []backtestDeviation `json:"deviations"`
+
+	// Updates contains the number of relay updates for every configuration.
+	Updates []int `json:"updates"`
+}
+
+type backtestSample struct {
+	Time   time.Time       `json:"time"`
+	Values []backtestValue `json:"values"`
+}
+
+type backtestValue struct {
+	Price *float64 `json:"price,omitempty"`
+	Error string   `json:"error,omitempty"`
+}
+
+type backtestDeviation struct {
+	// Max and Mean are relative deviations in percent.
+	Max  float64 `json:"max"`
+	Mean float64 `json:"mean"`
+
+	// Samples is the number of steps at which both configurations returned
+	// a valid value.
+	Samples int `json:"samples"`
+}
+
+func newBacktestReport(configs []string, models []string) *backtestReport {
+	r := &backtestReport{
+		Configs: configs,
+		Models:  make(map[string]*backtestModelReport, len(models)),
+	}
+	for _, model := range models {
+		r.Models[model] = &backtestModelReport{}
+	}
+	return r
+}
+
+// add adds a sample for every model. The points slice contains data points
+// returned by every configuration, keyed by the model name.
+func (r *backtestReport) add(t time.Time, points []map[string]datapoint.Point) {
+	for model, m := range r.Models {
+		s := backtestSample{Time: t, Values: make([]backtestValue, len(points))}
+		for i := range points {
+			s.Values[i] = backtestPointValue(points[i][model])
+		}
+		m.Series = append(m.Series, s)
+	}
+}
+
+// summarize calculates deviations and the number of relay updates.
+func (r *backtestReport) summarize(spread float64, expiration time.Duration) {
+	for _, m := range r.Models {
+		m.Deviations = make([]backtestDeviation, len(r.Configs))
+		m.Updates = make([]int, len(r.Configs))
+		for i := range r.Configs {
+			m.Deviations[i] = backtestDeviationFrom(m.Series, 0, i)
+			m.Updates[i] = backtestRelayUpdates(m.Series, i, spread, expiration)
+		}
+	}
+}
+
+func backtestPointValue(p datapoint.Point) backtestValue {
+	if err := p.Validate(); err != nil {
+		return backtestValue{Error: err.Error()}
+	}
+	n, ok := p.Value.(value.NumericValue)
+	if !ok || n.Number() == nil {
+		return backtestValue{Error: fmt.Sprintf("non-numeric value: %T", p.Value)}
+	}
+	price, _ := n.Number().BigFloat().Float64()
+	return backtestValue{Price: &price}
+}
+
+// backtestDeviationFrom calculates the relative deviation of values of the
+// i-th configuration from values of the base configuration.
+func backtestDeviationFrom(series []backtestSample, base, i int) backtestDeviation {
+	var d backtestDeviation
+	for _, s := range series {
+		b, v := s.Values[base].Price, s.Values[i].Price
+		if b == nil || v == nil || *b == 0 {
+			continue
+		}
+		dev := math.Abs(*v-*b) / math.Abs(*b) * 100
+		d.Max = math.Max(d.Max, dev)
+		d.Mean += dev
+		d.Samples++
+	}
+	if d.Samples > 0 {
+		d.Mean /= float64(d.Samples)
+	}
+	return d
+}
+
+// backtestRelayUpdates calculates how many times a relay would update
+// an oracle with values of the i-th configuration. An update occurs if
+// the value differs from the last relayed value by at least the spread
+// given in percent, or if the last relayed value is older than the
+// expiration.
+func backtestRelayUpdates(series []backtestSample, i int, spread float64, expiration time.Duration) int {
+	var (
+		updates  int
+		lastVal  float64
+		lastTime time.Time
+	)
+	for _, s := range series {
+		v := s.Values[i].Price
+		if v == nil {
+			continue
+		}
+		isExpired := lastTime.IsZero() || s.Time.Sub(lastTime) >= expiration
+		isStale := lastVal == 0 || math.Abs(*v-lastVal)/math.Abs(lastVal)*100 >= spread
+		if isExpired || isStale {
+			updates++
+			lastVal = *v
+			lastTime = s.Time
+		}
+	}
+	return updates
+}
+
+func (r *backtestReport) marshal(format string) ([]byte, error) {
+	switch format {
+	case formatPlain:
+		return r.marshalPlain()
+	case formatJSON:
+		return json.Marshal(r)
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+func (r *backtestReport) marshalPlain() ([]byte, error) {
+	var buf bytes.Buffer
+	for i, c := range r.Configs {
+		fmt.Fprintf(&buf, "Config %d: %s\n", i+1, c)
+	}
+	models := make([]string, 0, len(r.Models))
+	for model := range r.Models {
+		models = append(models, model)
+	}
+	sort.Strings(models)
+	for _, model := range models {
+		m := r.Models[model]
+		fmt.Fprintf(&buf, "\nModel %s:\n", model)
+		w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+		fmt.Fprint(w, "TIME")
+		for i := range r.Configs {
+			fmt.Fprintf(w, "\tCONFIG %d", i+1)
+		}
+		fmt.Fprintln(w)
+		for _, s := range m.Series {
+			fmt.Fprint(w, s.Time.UTC().Format(time.RFC3339))
+			for _, v := range s.Values {
+				if v.Price == nil {
+					fmt.Fprint(w, "\t-")
+				} else {
+					fmt.Fprintf(w, "\t%g", *v.Price)
+				}
+			}
+			fmt.Fprintln(w)
+		}
+		if err := w.Flush(); err != nil {
+			return nil, err
+		}
+		for i := range r.Configs {
+			fmt.Fprintf(&buf, "Config %d: %d relay updates", i+1, m.Updates[i])
+			if i > 0 {
+				d := m.Deviations[i]
+				fmt.Fprintf(&buf, ", deviation from config 1: max %.4f%%, mean %.4f%% (%d samples)", d.Max, d.Mean, d.Samples)
+			}
+			fmt.Fprintln(&buf)
+		}
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
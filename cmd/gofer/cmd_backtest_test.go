@@ -0,0 +1,51 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBacktestReport(t *testing.T) {
+	t0 := time.Unix(0, 0)
+	price := func(p float64) backtestValue { return backtestValue{Price: &p} }
+	series := []backtestSample{
+		{Time: t0, Values: []backtestValue{price(100), price(100)}},
+		{Time: t0.Add(time.Minute), Values: []backtestValue{price(100.5), price(101)}},
+		{Time: t0.Add(2 * time.Minute), Values: []backtestValue{price(101), {Error: "error"}}},
+		{Time: t0.Add(3 * time.Minute), Values: []backtestValue{price(101), price(101)}},
+		{Time: t0.Add(10 * time.Minute), Values: []backtestValue{price(101), price(101)}},
+	}
+
+	t.Run("relay updates", func(t *testing.T) {
+		// Updates at 0 (initial) and 2 (spread reached).
+		assert.Equal(t, 2, backtestRelayUpdates(series, 0, 1, time.Hour))
+		// Updates at 0, 2 and 10 (expired).
+		assert.Equal(t, 3, backtestRelayUpdates(series, 0, 1, 5*time.Minute))
+		// Updates at 0 and 1 (spread reached).
+		assert.Equal(t, 2, backtestRelayUpdates(series, 1, 1, time.Hour))
+	})
+	t.Run("deviation", func(t *testing.T) {
+		d := backtestDeviationFrom(series, 0, 1)
+		assert.Equal(t, 4, d.Samples)
+		assert.InDelta(t, 0.4975, d.Max, 1e-4)
+		assert.InDelta(t, 0.4975/4, d.Mean, 1e-4)
+		assert.Zero(t, backtestDeviationFrom(series, 0, 0).Max)
+	})
+}
@@ -35,7 +35,10 @@ import (
 )
 
 func NewDataCmd(c supervisor.Config, f *cmd.ConfigFlags, l *cmd.LoggerFlags) *cobra.Command {
-	var format formatTypeValue
+	var (
+		format  formatTypeValue
+		capture string
+	)
 	cc := &cobra.Command{
 		Use:     "data [MODEL...]",
 		Aliases: []string{"price", "prices"},
@@ -45,6 +48,13 @@ func NewDataCmd(c supervisor.Config, f *cmd.ConfigFlags, l *cmd.LoggerFlags) *co
 			if err := f.Load(c); err != nil {
 				return err
 			}
+			if capture != "" {
+				closeCapture, err := captureOrigins(c, capture, l.Logger())
+				if err != nil {
+					return err
+				}
+				defer closeCapture()
+			}
 			services, err := c.Services(l.Logger(), cc.Root().Use, cc.Root().Version)
 			if err != nil {
 				return err
@@ -82,6 +92,12 @@ func NewDataCmd(c supervisor.Config, f *cmd.ConfigFlags, l *cmd.LoggerFlags) *co
 		false,
 		"disable output coloring",
 	)
+	cc.Flags().StringVar(
+		&capture,
+		"capture",
+		"",
+		"append captures of calls to origins to the given file, for use with the backtest command",
+	)
 	return cc
 }
 
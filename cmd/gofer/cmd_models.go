@@ -116,7 +116,11 @@ func marshalModelsTrace(models map[string]datapoint.Model) ([]byte, error) {
 		if err != nil {
 			return nil, err
 		}
-		buf.WriteString(fmt.Sprintf("Model for %s:\n", name))
+		if hash := models[name].Hash; !hash.IsZero() {
+			buf.WriteString(fmt.Sprintf("Model for %s (hash: %s):\n", name, hash.String()))
+		} else {
+			buf.WriteString(fmt.Sprintf("Model for %s:\n", name))
+		}
 		buf.Write(bts)
 	}
 	return buf.Bytes(), nil
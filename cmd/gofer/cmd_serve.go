@@ -0,0 +1,153 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/chronicleprotocol/oracle-suite/cmd"
+	"github.com/chronicleprotocol/oracle-suite/pkg/config"
+	gofer "github.com/chronicleprotocol/oracle-suite/pkg/config/gofernext"
+	"github.com/chronicleprotocol/oracle-suite/pkg/httpserver"
+	"github.com/chronicleprotocol/oracle-suite/pkg/httpserver/middleware"
+	"github.com/chronicleprotocol/oracle-suite/pkg/supervisor"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/sliceutil"
+)
+
+const serveReadTimeout = 10 * time.Second
+
+func NewServeCmd(c supervisor.Config, f *cmd.ConfigFlags, l *cmd.LoggerFlags) *cobra.Command {
+	var (
+		listenAddr  string
+		interval    time.Duration
+		corsOrigins []string
+		capture     string
+	)
+	cc := &cobra.Command{
+		Use:   "serve",
+		Args:  cobra.NoArgs,
+		Short: "Serve models and data points over the HTTP API",
+		Long: "Serve models and data points over the HTTP API.\n\n" +
+			"Endpoints:\n" +
+			"  GET /models              list all models\n" +
+			"  GET /models/{model}      return a single model\n" +
+			"  GET /data?models=A,B     return data points for given models, or all models\n" +
+			"  GET /data/{model}        return a data point for a single model\n" +
+			"  GET /stream/{model}      stream data point updates as server-sent events\n" +
+			"  GET /health              health check\n\n" +
+			"The format query parameter selects the output format: json (default), trace or plain.",
+		RunE: func(cc *cobra.Command, _ []string) (err error) {
+			if interval <= 0 {
+				return fmt.Errorf("stream.interval must be positive")
+			}
+			if err := f.Load(c); err != nil {
+				return err
+			}
+			if l, ok := c.(config.HasLoader); ok {
+				l.SetLoader(f.Load)
+			}
+			if capture != "" {
+				closeCapture, err := captureOrigins(c, capture, l.Logger())
+				if err != nil {
+					return err
+				}
+				defer closeCapture()
+			}
+			services, err := c.Services(l.Logger(), cc.Root().Use, cc.Root().Version)
+			if err != nil {
+				return err
+			}
+			s, ok := services.(*gofer.Services)
+			if !ok {
+				return fmt.Errorf("services are not gofer.Services")
+			}
+			logger := s.Logger
+			srv := httpserver.New(&http.Server{
+				Addr:        listenAddr,
+				Handler:     newAPIHandler(s.DataProvider, interval),
+				ReadTimeout: serveReadTimeout,
+			})
+			if len(corsOrigins) > 0 {
+				srv.Use(&middleware.CORS{
+					Origin:  corsOrigin(corsOrigins),
+					Headers: func(*http.Request) string { return "Content-Type" },
+					Methods: func(*http.Request) string { return http.MethodGet },
+				})
+			}
+			srv.Use(&middleware.HealthCheck{Path: "/health"})
+			srv.Use(&middleware.Logger{Log: logger})
+			srv.Use(&middleware.Recover{Recover: func(err any) {
+				logger.WithField("error", err).Error("Panic in the HTTP API handler")
+			}})
+			sup := supervisor.New(logger)
+			sup.Watch(services, srv)
+			ctx, ctxCancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer ctxCancel()
+			if err = sup.Start(ctx); err != nil {
+				return err
+			}
+			logger.WithField("addr", srv.Addr().String()).Info("Listening for HTTP API requests")
+			return <-sup.Wait()
+		},
+	}
+	cc.Flags().StringVar(
+		&listenAddr,
+		"listen",
+		"127.0.0.1:8080",
+		"address on which the HTTP API listens",
+	)
+	cc.Flags().DurationVar(
+		&interval,
+		"stream.interval",
+		time.Second,
+		"how often data points are checked for updates in streams",
+	)
+	cc.Flags().StringSliceVar(
+		&corsOrigins,
+		"cors.origins",
+		nil,
+		"origins allowed to make cross-origin requests, use * to allow all origins",
+	)
+	cc.Flags().StringVar(
+		&capture,
+		"capture",
+		"",
+		"append captures of calls to origins to the given file, for use with the backtest command",
+	)
+	return cc
+}
+
+// corsOrigin returns a function that returns the value of the
+// Access-Control-Allow-Origin header for the given request.
+func corsOrigin(origins []string) func(*http.Request) string {
+	return func(r *http.Request) string {
+		if sliceutil.Contains(origins, "*") {
+			return "*"
+		}
+		origin := r.Header.Get("Origin")
+		if sliceutil.Contains(origins, origin) {
+			return origin
+		}
+		return ""
+	}
+}
@@ -0,0 +1,56 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/config"
+	gofer "github.com/chronicleprotocol/oracle-suite/pkg/config/gofernext"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+)
+
+// TestCassetteReplay evaluates data models using responses recorded with
+// the --record flag, so it does not require network access.
+func TestCassetteReplay(t *testing.T) {
+	var c gofer.Config
+	require.NoError(t, config.LoadFiles(&c, []string{"testdata/cassette/config.hcl"}))
+
+	cf := cassetteFlags{replay: "testdata/cassette/fixtures"}
+	require.NoError(t, cf.configure(&c))
+
+	services, err := c.Services(null.New(), "gofer", "test")
+	require.NoError(t, err)
+	s := services.(*gofer.Services)
+
+	points, err := s.DataProvider.DataPoints(context.Background(), "ETH/USD", "X/USD")
+	require.NoError(t, err)
+	for model, price := range map[string]string{"ETH/USD": "1251", "X/USD": "12.51"} {
+		require.NoError(t, points[model].Validate(), model)
+		assert.Equal(t, price, points[model].Value.(value.Tick).Price.String(), model)
+	}
+}
+
+func TestCassetteFlags(t *testing.T) {
+	var c gofer.Config
+	cf := cassetteFlags{record: t.TempDir(), replay: t.TempDir()}
+	assert.Error(t, cf.configure(&c))
+}
@@ -18,6 +18,8 @@ package main
 import (
 	"os"
 
+	"github.com/spf13/cobra"
+
 	suite "github.com/chronicleprotocol/oracle-suite"
 	"github.com/chronicleprotocol/oracle-suite/cmd"
 	gofer "github.com/chronicleprotocol/oracle-suite/pkg/config/gofernext"
@@ -28,12 +30,19 @@ func main() {
 	cf := cmd.ConfigFlagsForConfig(config)
 
 	var lf cmd.LoggerFlags
-	c := cmd.NewRootCommand("gofer", suite.Version, &cf, &lf)
+	var cas cassetteFlags
+	c := cmd.NewRootCommand("gofer", suite.Version, &cf, &lf, &cas)
+	c.PersistentPreRunE = func(*cobra.Command, []string) error {
+		return cas.configure(&config)
+	}
 
 	c.AddCommand(
 		cmd.NewRunCmd(&config, &cf, &lf),
+		cmd.NewConfigCmd(&config, &cf),
 		NewModelsCmd(&config, &cf, &lf),
 		NewDataCmd(&config, &cf, &lf),
+		NewServeCmd(&config, &cf, &lf),
+		NewBacktestCmd(&lf),
 	)
 
 	if err := c.Execute(); err != nil {
@@ -0,0 +1,16 @@
+gofer {
+  origin "ex" {
+    type = "tick_generic_jq"
+    url  = "http://127.0.0.1:18999/?p=$${ucbase}$${ucquote}"
+    jq   = "{price: .price, time: now|round, volume: 1}"
+  }
+  data_model "ETH/USD" {
+    origin "ex" { query = "ETH/USD" }
+  }
+  data_model "X/USD" {
+    indirect {
+      origin "ex" { query = "X/ETH" }
+      reference { data_model = "ETH/USD" }
+    }
+  }
+}
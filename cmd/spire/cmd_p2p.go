@@ -0,0 +1,117 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+
+	"github.com/chronicleprotocol/oracle-suite/cmd"
+	"github.com/chronicleprotocol/oracle-suite/pkg/config/spire"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/libp2p"
+)
+
+func NewP2PCmd(c *spire.Config, f *cmd.ConfigFlags) *cobra.Command {
+	var adminAddr string
+	cc := &cobra.Command{
+		Use:   "p2p",
+		Args:  cobra.NoArgs,
+		Short: "Inspects the libp2p transport using the admin API",
+	}
+	cc.AddCommand(
+		newP2PQueryCmd(c, f, &adminAddr, "peers", 0, "Lists connected peers",
+			func(ctx context.Context, client *libp2p.AdminClient, _ []string) (any, error) {
+				return client.Peers(ctx)
+			},
+		),
+		newP2PQueryCmd(c, f, &adminAddr, "topics", 0, "Lists subscribed topics and mesh peers",
+			func(ctx context.Context, client *libp2p.AdminClient, _ []string) (any, error) {
+				return client.Topics(ctx)
+			},
+		),
+		newP2PQueryCmd(c, f, &adminAddr, "blocked", 0, "Lists blocked peers and IP addresses",
+			func(ctx context.Context, client *libp2p.AdminClient, _ []string) (any, error) {
+				return client.Blocked(ctx)
+			},
+		),
+		newP2PQueryCmd(c, f, &adminAddr, "block MULTIADDR", 1, "Blocks a peer ID and/or IP address",
+			func(ctx context.Context, client *libp2p.AdminClient, args []string) (any, error) {
+				return client.Block(ctx, args[0])
+			},
+		),
+		newP2PQueryCmd(c, f, &adminAddr, "unblock MULTIADDR", 1, "Unblocks a peer ID and/or IP address",
+			func(ctx context.Context, client *libp2p.AdminClient, args []string) (any, error) {
+				return client.Unblock(ctx, args[0])
+			},
+		),
+	)
+	cc.PersistentFlags().StringVar(
+		&adminAddr,
+		"admin-addr",
+		"",
+		"address of the libp2p admin API, if empty, the address from the config is used",
+	)
+	return cc
+}
+
+func newP2PQueryCmd(
+	c *spire.Config,
+	f *cmd.ConfigFlags,
+	adminAddr *string,
+	use string,
+	nArgs int,
+	short string,
+	query func(ctx context.Context, client *libp2p.AdminClient, args []string) (any, error),
+) *cobra.Command {
+
+	return &cobra.Command{
+		Use:   use,
+		Args:  cobra.ExactArgs(nArgs),
+		Short: short,
+		RunE: func(_ *cobra.Command, args []string) error {
+			addr := *adminAddr
+			if addr == "" {
+				if err := f.Load(c); err != nil {
+					return fmt.Errorf(`config error: %w`, err)
+				}
+				if c.Transport.LibP2P != nil {
+					addr = c.Transport.LibP2P.AdminListenAddr
+				}
+			}
+			if addr == "" {
+				return errors.New("the libp2p admin API address is not configured")
+			}
+			ctx, ctxCancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer ctxCancel()
+			res, err := query(ctx, libp2p.NewAdminClient(addr), args)
+			if err != nil {
+				return err
+			}
+			bts, err := json.Marshal(res)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s\n", string(bts))
+			return nil
+		},
+	}
+}
@@ -28,11 +28,15 @@ import (
 	"github.com/chronicleprotocol/oracle-suite/pkg/config/spire"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/replay"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/chanutil"
 )
 
 func NewStreamCmd(c *spire.Config, f *cmd.ConfigFlags, l *cmd.LoggerFlags) *cobra.Command {
-	var raw bool
+	var (
+		raw    bool
+		record string
+	)
 	cc := &cobra.Command{
 		Use:   "stream [TOPIC...]",
 		Args:  cobra.MinimumNArgs(0),
@@ -58,6 +62,26 @@ func NewStreamCmd(c *spire.Config, f *cmd.ConfigFlags, l *cmd.LoggerFlags) *cobr
 					err = sErr
 				}
 			}()
+			if record != "" {
+				file, err := os.Create(record)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				recorder, err := replay.NewRecorder(replay.RecorderConfig{
+					Transport: services.Transport,
+					Topics:    topics,
+					Writer:    file,
+					Logger:    logger,
+				})
+				if err != nil {
+					return err
+				}
+				if err := recorder.Start(ctx); err != nil {
+					return err
+				}
+				defer func() { <-recorder.Wait() }()
+			}
 			sink := chanutil.NewFanIn[transport.ReceivedMessage]()
 			for _, s := range topics {
 				ch := services.Transport.Messages(s)
@@ -113,6 +137,12 @@ func NewStreamCmd(c *spire.Config, f *cmd.ConfigFlags, l *cmd.LoggerFlags) *cobr
 		false,
 		"show raw messages",
 	)
+	cc.Flags().StringVar(
+		&record,
+		"record",
+		"",
+		"record received messages to a file that can be replayed later",
+	)
 	var format string
 	cc.Flags().StringVarP(&format, "output", "o", "", "(here for backward compatibility)")
 	return cc
@@ -32,9 +32,11 @@ func main() {
 
 	c.AddCommand(
 		cmd.NewRunCmd(&config, &cf, &lf),
+		cmd.NewConfigCmd(&config, &cf),
 		NewStreamCmd(&config, &cf, &lf),
 		NewPullCmd(&config, &cf, &lf),
 		NewPushCmd(&config, &cf, &lf),
+		NewP2PCmd(&config, &cf),
 	)
 
 	var bootstrapConfig BootstrapConfig
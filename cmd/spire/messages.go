@@ -33,8 +33,9 @@ import (
 // integrations and updates.
 
 const (
-	priceMessageType = "price"
-	greetMessageType = "greet"
+	priceMessageType     = "price"
+	dataPointMessageType = "data_point"
+	greetMessageType     = "greet"
 )
 
 type streamType struct {
@@ -63,9 +64,11 @@ func handleMessage(msg transport.ReceivedMessage) streamType {
 	case *messages.Price:
 		v = handleLegacyPriceMessage(msgType)
 	case *messages.DataPoint:
-		switch msgType.Point.Value.(type) { //nolint:gocritic
+		switch msgType.Point.Value.(type) {
 		case value.Tick:
 			v = handleTickDataPointMessage(msgType)
+		case value.Rate, value.Bar, value.Vector:
+			v = handleDataPointMessage(msgType)
 		}
 	case *messages.MuSigInitialize:
 		v = handleMuSigInitializeMessage(msgType)
@@ -142,6 +145,35 @@ func handleTickDataPointMessage(msg *messages.DataPoint) streamType {
 	}
 }
 
+func handleDataPointMessage(msg *messages.DataPoint) streamType {
+	var signatureType string
+	switch msg.Point.Value.(type) {
+	case value.Rate:
+		signatureType = "median/v1" // rates are signed the same way as ticks
+	case value.Bar:
+		signatureType = "bar/v1"
+	case value.Vector:
+		signatureType = "vector/v1"
+	}
+	return streamType{
+		Type:    dataPointMessageType,
+		Version: "1.0",
+		Data: map[string]any{
+			"wat":   msg.Model,
+			"value": msg.Point.Value,
+			"age":   msg.Point.Time.Unix(),
+		},
+		Meta: maputil.Filter(map[string]any{
+			"trace": msg.Point.Meta["trace"],
+		}, removeEmptyFields),
+		Signature: msg.ECDSASignature.String(),
+		Signatures: []map[string]any{{
+			"type":      signatureType,
+			"signature": msg.ECDSASignature.String(),
+		}},
+	}
+}
+
 func handleMuSigSignatureMessage(msg *messages.MuSigSignature) streamType {
 	msm := handleMuSigMessage(msg.MuSigMessage)
 
@@ -215,15 +247,19 @@ func handleMuSigTerminateMessage(msg *messages.MuSigTerminate) streamType {
 }
 
 func handleGreetMessage(msg *messages.Greet) streamType {
+	data := map[string]any{
+		"public_key_x": hexutil.BigIntToHex(msg.PublicKeyX),
+		"public_key_y": hexutil.BigIntToHex(msg.PublicKeyY),
+		"web_url":      msg.WebURL,
+		"greet":        msg.Signature.String(),
+	}
+	if len(msg.Capabilities) > 0 {
+		data["capabilities"] = msg.Capabilities
+	}
 	return streamType{
 		Type:    greetMessageType,
 		Version: "0.1", // this means that the message is a WIP
-		Data: map[string]any{
-			"public_key_x": hexutil.BigIntToHex(msg.PublicKeyX),
-			"public_key_y": hexutil.BigIntToHex(msg.PublicKeyY),
-			"web_url":      msg.WebURL,
-			"greet":        msg.Signature.String(),
-		},
+		Data:    data,
 	}
 }
 
@@ -138,6 +138,55 @@ func TestHandleMessage(t *testing.T) {
 		assert.JSONEq(t, expectedJSON, string(resultJSON))
 	})
 
+	t.Run("handleDataPointMessage", func(t *testing.T) {
+		msg := &messages.DataPoint{
+			Model: "DSR/RATE",
+			Point: datapoint.Point{
+				Value: value.NewRate(1.05, time.Second),
+				Time:  time.Unix(1234567890, 0),
+			},
+			ECDSASignature: types.MustSignatureFromHex("0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef01"),
+		}
+		receivedMessage := transport.ReceivedMessage{
+			Message: msg,
+			Meta:    createMeta(messages.DataPointV1MessageName),
+		}
+		result := handleMessage(receivedMessage)
+
+		expectedJSON := `{
+			"type": "data_point",
+			"version": "1.0",
+			"data": {
+				"wat": "DSR/RATE",
+				"value": {
+					"rate": "1.05",
+					"period": "1s"
+				},
+				"age": 1234567890
+			},
+			"signature": "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef01",
+			"signatures": [
+				{
+					"signature": "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef01",
+					"type": "median/v1"
+				}
+			],
+			"meta": {
+				"transport": "libp2p",
+				"user_agent": "spire/v0.0.0",
+				"message_id": "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef",
+				"peer_id": "peer1",
+				"peer_addr": "0x1234567890abcdef1234567890abcdef1234567890abcdef",
+				"received_from_peer_id": "peer2",
+				"received_from_peer_addr": "0x234567890abcdef1234567890abcdef123456789",
+				"topic": "data_point/v1"
+			}
+		}`
+		resultJSON, err := json.Marshal(result)
+		assert.Nil(t, err)
+		assert.JSONEq(t, expectedJSON, string(resultJSON))
+	})
+
 	t.Run("handleMuSigInitializeMessage", func(t *testing.T) {
 		msg := &messages.MuSigInitialize{
 			SessionID:    types.MustHashFromHex("0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef", types.PadNone),
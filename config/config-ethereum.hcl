@@ -18,6 +18,18 @@ ethereum {
   # environment variable along with CFG_ETH_KEYS and CFG_ETH_PASS.
   rand_keys = env("CFG_ETH_FROM", "") == "" ? ["default"] : []
 
+  # Remote signer used instead of the keystore if the CFG_ETH_REMOTE_SIGNER_URL
+  # environment variable is set. Only the CFG_ETH_FROM key is allowed to be used.
+  dynamic "remote_signer" {
+    for_each = env("CFG_ETH_REMOTE_SIGNER_URL", "") == "" ? [] : [1]
+    labels   = ["default"]
+    content {
+      url               = env("CFG_ETH_REMOTE_SIGNER_URL", "")
+      timeout           = tonumber(env("CFG_ETH_REMOTE_SIGNER_TIMEOUT", "10"))
+      allowed_addresses = [env("CFG_ETH_FROM", "")]
+    }
+  }
+
   dynamic "key" {
     for_each = env("CFG_ETH_FROM", "") == "" ? [] : [1]
     labels   = ["default"]
@@ -25,6 +37,7 @@ ethereum {
       address         = env("CFG_ETH_FROM", "")
       keystore_path   = env("CFG_ETH_KEYS", "")
       passphrase_file = env("CFG_ETH_PASS", "")
+      remote_signer   = env("CFG_ETH_REMOTE_SIGNER_URL", "") == "" ? "" : "default"
     }
   }
 
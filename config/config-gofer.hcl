@@ -1,4 +1,8 @@
 gofer {
+  # Address of the admin API that allows to reload origins and data models using the POST /reload request.
+  # Configuration is also reloaded when the SIGHUP signal is received.
+  reload_listen_addr = env("CFG_GOFER_RELOAD_LISTEN_ADDR", "")
+
   origin "balancerV2" {
     type = "balancerV2"
     contracts "ethereum" {
@@ -0,0 +1,48 @@
+rpc_splitter {
+  # Address on which the RPC server will listen.
+  listen_addr = env("CFG_RPC_SPLITTER_LISTEN_ADDR", "127.0.0.1:8545")
+
+  # List of RPC endpoints. At least one endpoint must be provided.
+  # Subscriptions are available only if WebSocket endpoints are used.
+  endpoints = explode(var.item_separator, env("CFG_RPC_SPLITTER_ENDPOINTS", ""))
+
+  # Minimum number of responses required to resolve a response.
+  # (optional) if not set, all endpoints but one must respond.
+  min_responses = tonumber(env("CFG_RPC_SPLITTER_MIN_RESPONSES", "0"))
+
+  # Maximum number of blocks behind the endpoint with the highest block number.
+  max_blocks_behind = tonumber(env("CFG_RPC_SPLITTER_MAX_BLOCKS_BEHIND", "0"))
+
+  # Time for which responses that depend on the chain head are cached, in seconds.
+  # Zero disables the cache.
+  cache_ttl = tonumber(env("CFG_RPC_SPLITTER_CACHE_TTL", "0"))
+
+  # Number of blocks after which responses for a specific block are considered immutable.
+  cache_reorg_depth = tonumber(env("CFG_RPC_SPLITTER_CACHE_REORG_DEPTH", "64"))
+
+  # Total timeout for all endpoints, in seconds.
+  timeout = tonumber(env("CFG_RPC_SPLITTER_TIMEOUT", "10"))
+
+  # Time to wait for slower endpoints, in seconds.
+  graceful_timeout = tonumber(env("CFG_RPC_SPLITTER_GRACEFUL_TIMEOUT", "1"))
+
+  # List of origins allowed to make cross-origin requests.
+  cors_origins = explode(var.item_separator, env("CFG_RPC_SPLITTER_CORS_ORIGINS", ""))
+
+  # List of origins from which WebSocket connections are accepted.
+  ws_origins = explode(var.item_separator, env("CFG_RPC_SPLITTER_WS_ORIGINS", ""))
+
+  # Path of the health check endpoint.
+  health_check_path = env("CFG_RPC_SPLITTER_HEALTH_CHECK_PATH", "/health")
+
+  # Number of the most recent disagreements between endpoints kept in memory and exposed by the admin API.
+  # Zero disables forensics, unless the forensics file is set.
+  forensics_size = tonumber(env("CFG_RPC_SPLITTER_FORENSICS_SIZE", "0"))
+
+  # Path to a file to which disagreements are appended as JSON lines.
+  forensics_file = env("CFG_RPC_SPLITTER_FORENSICS_FILE", "")
+
+  # Address on which the admin API will listen. Empty disables the admin API.
+  # It should not be available publicly.
+  admin_listen_addr = env("CFG_RPC_SPLITTER_ADMIN_LISTEN_ADDR", "")
+}
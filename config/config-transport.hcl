@@ -11,6 +11,10 @@ variables {
     var.item_separator,
     try(var.static_address_books[var.environment], [])
   )))
+
+  nats_enable = tobool(env("CFG_NATS_ENABLE", "0"))
+
+  negotiation_enable = tobool(env("CFG_NEGOTIATION_ENABLE", "0"))
 }
 
 transport {
@@ -28,6 +32,9 @@ transport {
       disable_discovery    = tobool(env("CFG_LIBP2P_DISABLE_DISCOVERY", "0"))
       ethereum_key         = "default"
       external_addr        = env("CFG_LIBP2P_EXTERNAL_ADDR", env("CFG_LIBP2P_EXTERNAL_IP", ""))
+      mdns                 = tobool(env("CFG_LIBP2P_MDNS", "0"))
+      address_book_path    = env("CFG_LIBP2P_ADDRESS_BOOK_PATH", "")
+      admin_listen_addr    = env("CFG_LIBP2P_ADMIN_LISTEN_ADDR", "")
     }
   }
 
@@ -58,4 +65,25 @@ transport {
       }
     }
   }
+
+  # NATS transport configuration. Enabled if CFG_NATS_ENABLE is set to anything evaluated to `true`.
+  dynamic "nats" {
+    for_each = var.nats_enable ? [1] : []
+    content {
+      feeds          = var.feeds
+      url            = env("CFG_NATS_URL", "nats://localhost:4222")
+      subject_prefix = env("CFG_NATS_SUBJECT_PREFIX", "")
+      ethereum_key   = "default"
+    }
+  }
+
+  # Message version negotiation. Enabled if CFG_NEGOTIATION_ENABLE is set to anything evaluated to `true`.
+  dynamic "negotiation" {
+    for_each = var.negotiation_enable ? [1] : []
+    content {
+      interval     = tonumber(env("CFG_NEGOTIATION_INTERVAL", "60"))
+      dual_publish = explode(var.item_separator, env("CFG_NEGOTIATION_DUAL_PUBLISH", ""))
+      ethereum_key = "default"
+    }
+  }
 }
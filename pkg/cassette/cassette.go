@@ -0,0 +1,138 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package cassette provides a record and replay mode for HTTP and JSON-RPC
+// clients.
+//
+// In the record mode, requests are passed to the underlying client and
+// responses are stored as fixture files in a directory. In the replay mode,
+// responses are read from the fixture files and the underlying client is
+// never called, which allows to run tests that depend on external services
+// offline and deterministically.
+//
+// Fixtures are keyed by the request, so replayed responses do not depend on
+// the order of requests. If the same request is recorded more than once,
+// the most recent response is kept.
+package cassette
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrFixtureNotFound is returned in the replay mode if there is no fixture
+// for a request.
+var ErrFixtureNotFound = errors.New("fixture not found")
+
+// Mode is a mode in which a cassette operates.
+type Mode int
+
+const (
+	// Record mode passes requests to the underlying client and stores
+	// responses as fixtures.
+	Record Mode = iota
+
+	// Replay mode returns responses from fixtures without calling the
+	// underlying client.
+	Replay
+)
+
+// Cassette stores and reads fixtures in a directory.
+type Cassette struct {
+	dir  string
+	mode Mode
+}
+
+// New creates a new Cassette instance that stores fixtures in the given
+// directory. In the record mode, the directory is created if it does not
+// exist.
+func New(dir string, mode Mode) (*Cassette, error) {
+	switch mode {
+	case Record:
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("cassette: unable to create directory: %w", err)
+		}
+	case Replay:
+		info, err := os.Stat(dir)
+		if err != nil {
+			return nil, fmt.Errorf("cassette: %w", err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("cassette: %s is not a directory", dir)
+		}
+	default:
+		return nil, fmt.Errorf("cassette: unknown mode: %d", mode)
+	}
+	return &Cassette{dir: dir, mode: mode}, nil
+}
+
+// Mode returns the mode of the cassette.
+func (c *Cassette) Mode() Mode {
+	return c.mode
+}
+
+// path returns the path of the fixture file for the given request key.
+func (c *Cassette) path(kind, key string) string {
+	h := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, fmt.Sprintf("%s-%s.json", kind, hex.EncodeToString(h[:16])))
+}
+
+// write stores the fixture for the given request key.
+//
+// The fixture is written to a temporary file first, so concurrent readers
+// never see a partially written fixture.
+func (c *Cassette) write(kind, key string, fixture any) error {
+	b, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cassette: unable to marshal fixture: %w", err)
+	}
+	f, err := os.CreateTemp(c.dir, ".fixture-*")
+	if err != nil {
+		return fmt.Errorf("cassette: unable to write fixture: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return fmt.Errorf("cassette: unable to write fixture: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("cassette: unable to write fixture: %w", err)
+	}
+	if err := os.Rename(f.Name(), c.path(kind, key)); err != nil {
+		return fmt.Errorf("cassette: unable to write fixture: %w", err)
+	}
+	return nil
+}
+
+// read reads the fixture for the given request key. The desc argument
+// describes the request in errors, so it must not contain credentials.
+func (c *Cassette) read(kind, key, desc string, fixture any) error {
+	b, err := os.ReadFile(c.path(kind, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("cassette: %w: %s", ErrFixtureNotFound, desc)
+	}
+	if err != nil {
+		return fmt.Errorf("cassette: unable to read fixture: %w", err)
+	}
+	if err := json.Unmarshal(b, fixture); err != nil {
+		return fmt.Errorf("cassette: unable to unmarshal fixture %s: %w", c.path(kind, key), err)
+	}
+	return nil
+}
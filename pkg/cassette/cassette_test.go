@@ -0,0 +1,171 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cassette
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP(t *testing.T) {
+	dir := t.TempDir()
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"path":"` + r.URL.Path + `","body":"` + string(body) + `"}`))
+	}))
+	defer srv.Close()
+
+	get := func(c *Cassette, path, body string) (*http.Response, string, error) {
+		client := &http.Client{Transport: c.RoundTripper(nil)}
+		res, err := client.Post(srv.URL+path, "text/plain", strings.NewReader(body))
+		if err != nil {
+			return nil, "", err
+		}
+		defer res.Body.Close()
+		b, err := io.ReadAll(res.Body)
+		return res, string(b), err
+	}
+
+	// Record.
+	rec, err := New(dir, Record)
+	require.NoError(t, err)
+	_, body, err := get(rec, "/a", "x")
+	require.NoError(t, err)
+	assert.Equal(t, `{"path":"/a","body":"x"}`, body)
+	_, _, err = get(rec, "/b", "y")
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	// Replay.
+	rep, err := New(dir, Replay)
+	require.NoError(t, err)
+	res, body, err := get(rep, "/b", "y")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, res.StatusCode)
+	assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+	assert.Equal(t, `{"path":"/b","body":"y"}`, body)
+	_, body, err = get(rep, "/a", "x")
+	require.NoError(t, err)
+	assert.Equal(t, `{"path":"/a","body":"x"}`, body)
+	assert.Equal(t, 2, calls)
+
+	// Requests that were not recorded.
+	_, _, err = get(rep, "/a", "z")
+	assert.True(t, errors.Is(err, ErrFixtureNotFound))
+}
+
+func TestHTTP_Redact(t *testing.T) {
+	dir := t.TempDir()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=s3cr3t-cookie")
+		_, _ = w.Write([]byte(`{"symbol":"` + r.URL.Query().Get("symbol") + `"}`))
+	}))
+	defer srv.Close()
+
+	get := func(c *Cassette) (*http.Response, string) {
+		client := &http.Client{Transport: c.RoundTripper(nil)}
+		res, err := client.Get(srv.URL + "/ticker?symbol=ETH&apikey=s3cr3t-key")
+		require.NoError(t, err)
+		defer res.Body.Close()
+		b, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return res, string(b)
+	}
+
+	rec, err := New(dir, Record)
+	require.NoError(t, err)
+	get(rec)
+
+	// Fixtures must not contain credentials.
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	b, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "s3cr3t")
+	assert.Contains(t, string(b), "application/json")
+
+	// Redacted fixtures can still be replayed.
+	rep, err := New(dir, Replay)
+	require.NoError(t, err)
+	res, body := get(rep)
+	assert.Equal(t, `{"symbol":"ETH"}`, body)
+	assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+	assert.Equal(t, redacted, res.Header.Get("Set-Cookie"))
+}
+
+type fakeTransport struct {
+	calls int
+}
+
+func (f *fakeTransport) Call(_ context.Context, result any, method string, args ...any) error {
+	f.calls++
+	if method == "fail" {
+		return errors.New("failure")
+	}
+	*result.(*map[string]any) = map[string]any{"method": method, "args": args}
+	return nil
+}
+
+func TestTransport(t *testing.T) {
+	dir := t.TempDir()
+	next := &fakeTransport{}
+
+	// Record.
+	rec, err := New(dir, Record)
+	require.NoError(t, err)
+	var res map[string]any
+	require.NoError(t, rec.Transport("a", next).Call(context.Background(), &res, "eth_call", "0x1"))
+	assert.EqualError(t, rec.Transport("a", next).Call(context.Background(), &res, "fail"), "failure")
+	assert.Equal(t, 2, next.calls)
+
+	// Replay.
+	rep, err := New(dir, Replay)
+	require.NoError(t, err)
+	res = nil
+	require.NoError(t, rep.Transport("a", next).Call(context.Background(), &res, "eth_call", "0x1"))
+	assert.Equal(t, map[string]any{"method": "eth_call", "args": []any{"0x1"}}, res)
+	assert.EqualError(t, rep.Transport("a", next).Call(context.Background(), &res, "fail"), "failure")
+	assert.Equal(t, 2, next.calls)
+
+	// Calls made by other clients or with other parameters were not recorded.
+	err = rep.Transport("b", next).Call(context.Background(), &res, "eth_call", "0x1")
+	assert.True(t, errors.Is(err, ErrFixtureNotFound))
+	err = rep.Transport("a", next).Call(context.Background(), &res, "eth_call", "0x2")
+	assert.True(t, errors.Is(err, ErrFixtureNotFound))
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(t.TempDir()+"/missing", Replay)
+	assert.Error(t, err)
+	_, err = New(t.TempDir(), Mode(5))
+	assert.Error(t, err)
+}
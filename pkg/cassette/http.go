@@ -0,0 +1,198 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cassette
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"unicode/utf8"
+)
+
+// redacted replaces values that may contain credentials in fixtures.
+const redacted = "[REDACTED]"
+
+// fixtureHeaders lists response headers whose values are stored in fixtures
+// as they are. Values of other headers, such as Set-Cookie, may contain
+// credentials, so they are redacted.
+var fixtureHeaders = map[string]bool{
+	"Cache-Control":    true,
+	"Content-Encoding": true,
+	"Content-Language": true,
+	"Content-Length":   true,
+	"Content-Type":     true,
+	"Date":             true,
+	"Etag":             true,
+	"Last-Modified":    true,
+	"Vary":             true,
+}
+
+type httpFixture struct {
+	Request  httpFixtureRequest  `json:"request"`
+	Response httpFixtureResponse `json:"response"`
+}
+
+type httpFixtureRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type httpFixtureResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+
+	// Body is used for UTF-8 encoded bodies, so fixtures can be easily read
+	// and edited. Other bodies are stored in the RawBody field.
+	Body    string `json:"body,omitempty"`
+	RawBody []byte `json:"raw_body,omitempty"`
+}
+
+// RoundTripper returns an http.RoundTripper that records responses of the
+// given round tripper or replays them, depending on the cassette mode.
+//
+// Requests are identified by the method, URL and body. If next is nil,
+// http.DefaultTransport is used.
+//
+// Query values, URL passwords and values of response headers other than
+// those needed to interpret the body are redacted in recorded fixtures,
+// because they often contain API keys and session cookies.
+func (c *Cassette) RoundTripper(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &roundTripper{cassette: c, next: next}
+}
+
+type roundTripper struct {
+	cassette *Cassette
+	next     http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	fixture := httpFixture{
+		Request: httpFixtureRequest{
+			Method: req.Method,
+			URL:    redactURL(req.URL),
+			Body:   string(body),
+		},
+	}
+	key := fmt.Sprintf("%s %s\n%s", req.Method, req.URL, body)
+	if t.cassette.mode == Replay {
+		desc := fmt.Sprintf("%s %s", req.Method, fixture.Request.URL)
+		if err := t.cassette.read("http", key, desc, &fixture); err != nil {
+			return nil, err
+		}
+		return fixture.Response.response(req), nil
+	}
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+	fixture.Response = httpFixtureResponse{
+		StatusCode: res.StatusCode,
+		Header:     redactHeader(res.Header),
+	}
+	if utf8.Valid(resBody) {
+		fixture.Response.Body = string(resBody)
+	} else {
+		fixture.Response.RawBody = resBody
+	}
+	if err := t.cassette.write("http", key, fixture); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// redactURL returns the URL with query values and the password redacted.
+func redactURL(u *url.URL) string {
+	r := *u
+	if _, ok := r.User.Password(); ok {
+		r.User = url.UserPassword(r.User.Username(), redacted)
+	}
+	if r.RawQuery != "" {
+		query := r.Query()
+		for _, values := range query {
+			for i := range values {
+				values[i] = redacted
+			}
+		}
+		r.RawQuery = query.Encode()
+	}
+	return r.String()
+}
+
+// redactHeader returns a copy of the header with values of headers that are
+// not listed in fixtureHeaders redacted.
+func redactHeader(h http.Header) http.Header {
+	if h == nil {
+		return nil
+	}
+	r := make(http.Header, len(h))
+	for name, values := range h {
+		if fixtureHeaders[http.CanonicalHeaderKey(name)] {
+			r[name] = values
+			continue
+		}
+		r[name] = make([]string, len(values))
+		for i := range values {
+			r[name][i] = redacted
+		}
+	}
+	return r
+}
+
+func (r httpFixtureResponse) response(req *http.Request) *http.Response {
+	body := r.RawBody
+	if body == nil {
+		body = []byte(r.Body)
+	}
+	header := r.Header
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,96 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cassette
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/defiweb/go-eth/rpc/transport"
+)
+
+type rpcFixture struct {
+	Client string          `json:"client"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// Transport returns a JSON-RPC transport that records results of calls made
+// using the given transport or replays them, depending on the cassette mode.
+//
+// Calls are identified by the client name, the method and the parameters.
+// The client name is used to distinguish between clients connected to
+// different networks.
+func (c *Cassette) Transport(client string, next transport.Transport) transport.Transport {
+	return &rpcTransport{cassette: c, client: client, next: next}
+}
+
+type rpcTransport struct {
+	cassette *Cassette
+	client   string
+	next     transport.Transport
+}
+
+// Call implements the transport.Transport interface.
+func (t *rpcTransport) Call(ctx context.Context, result any, method string, args ...any) error {
+	if args == nil {
+		args = []any{}
+	}
+	params, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("cassette: unable to marshal parameters: %w", err)
+	}
+	fixture := rpcFixture{
+		Client: t.client,
+		Method: method,
+		Params: params,
+	}
+	key := fmt.Sprintf("%s %s %s", t.client, method, params)
+	if t.cassette.mode == Replay {
+		if err := t.cassette.read("rpc", key, key, &fixture); err != nil {
+			return err
+		}
+		if fixture.Error != "" {
+			return errors.New(fixture.Error)
+		}
+		if result == nil || fixture.Result == nil {
+			return nil
+		}
+		return json.Unmarshal(fixture.Result, result)
+	}
+	callErr := t.next.Call(ctx, result, method, args...)
+	if ctx.Err() != nil {
+		// Do not record calls interrupted by the caller.
+		return callErr
+	}
+	switch {
+	case callErr != nil:
+		fixture.Error = callErr.Error()
+	case result != nil:
+		fixture.Result, err = json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("cassette: unable to marshal result: %w", err)
+		}
+	}
+	if err := t.cassette.write("rpc", key, fixture); err != nil {
+		return err
+	}
+	return callErr
+}
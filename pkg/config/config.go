@@ -32,6 +32,7 @@ import (
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/globals"
 	utilHCL "github.com/chronicleprotocol/oracle-suite/pkg/util/hcl"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/hcl/ext/include"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/hcl/ext/secrets"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/hcl/ext/variables"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/hcl/funcs"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/sliceutil"
@@ -144,8 +145,19 @@ var explodeFunc = function.New(&function.Spec{
 
 // LoadFiles loads the given paths into the given config, merging contents of
 // multiple HCL files specified by the "include" attribute using glob patterns,
-// and expanding dynamic blocks before decoding the HCL content.
+// resolving secrets, and expanding dynamic blocks before decoding the HCL
+// content.
 func LoadFiles(config any, paths []string) error {
+	return loadFiles(hclContext, config, paths)
+}
+
+// LoadFilesRedacted works like LoadFiles, but values returned by the secret
+// function are replaced by the result of the redact function.
+func LoadFilesRedacted(config any, paths []string, redact func(string) string) error {
+	return loadFiles(utilHCL.RedactSecrets(hclContext, redact), config, paths)
+}
+
+func loadFiles(ctx *hcl.EvalContext, config any, paths []string) error {
 	var body hcl.Body
 	var diags hcl.Diagnostics
 	wd, err := os.Getwd()
@@ -158,13 +170,16 @@ func LoadFiles(config any, paths []string) error {
 	if len(paths) > 0 {
 		wd = filepath.Dir(paths[0])
 	}
-	if body, diags = include.Include(hclContext, body, wd, 10); diags.HasErrors() {
+	if body, diags = include.Include(ctx, body, wd, 10); diags.HasErrors() {
+		return diags
+	}
+	if body, diags = secrets.Secrets(ctx, body); diags.HasErrors() {
 		return diags
 	}
-	if body, diags = variables.Variables(hclContext, body); diags.HasErrors() {
+	if body, diags = variables.Variables(ctx, body); diags.HasErrors() {
 		return diags
 	}
-	if diags = utilHCL.Decode(hclContext, dynblock.Expand(body, hclContext), config); diags.HasErrors() {
+	if diags = utilHCL.Decode(ctx, dynblock.Expand(body, ctx), config); diags.HasErrors() {
 		return diags
 	}
 	return nil
@@ -172,7 +187,17 @@ func LoadFiles(config any, paths []string) error {
 
 // LoadEmbeds populates config with data from []utilHCL.NamedBytes into the given config,
 // and expanding dynamic blocks before decoding the HCL content.
-func LoadEmbeds(config any, embeds [][]byte) (err error) {
+func LoadEmbeds(config any, embeds [][]byte) error {
+	return loadEmbeds(hclContext, config, embeds)
+}
+
+// LoadEmbedsRedacted works like LoadEmbeds, but values returned by the secret
+// function are replaced by the result of the redact function.
+func LoadEmbedsRedacted(config any, embeds [][]byte, redact func(string) string) error {
+	return loadEmbeds(utilHCL.RedactSecrets(hclContext, redact), config, embeds)
+}
+
+func loadEmbeds(ctx *hcl.EvalContext, config any, embeds [][]byte) (err error) {
 	var body hcl.Body
 	var diags hcl.Diagnostics
 	if err != nil {
@@ -181,10 +206,13 @@ func LoadEmbeds(config any, embeds [][]byte) (err error) {
 	if body, diags = utilHCL.ParseBytesList(embeds); diags.HasErrors() {
 		return diags
 	}
-	if body, diags = variables.Variables(hclContext, body); diags.HasErrors() {
+	if body, diags = secrets.Secrets(ctx, body); diags.HasErrors() {
 		return diags
 	}
-	if diags = utilHCL.Decode(hclContext, dynblock.Expand(body, hclContext), config); diags.HasErrors() {
+	if body, diags = variables.Variables(ctx, body); diags.HasErrors() {
+		return diags
+	}
+	if diags = utilHCL.Decode(ctx, dynblock.Expand(body, ctx), config); diags.HasErrors() {
 		return diags
 	}
 	return nil
@@ -193,3 +221,11 @@ func LoadEmbeds(config any, embeds [][]byte) (err error) {
 type HasDefaults interface {
 	DefaultEmbeds() [][]byte
 }
+
+// HasLoader is implemented by configs that need to load the configuration
+// again after services are created, e.g. to reload it without restarting
+// the application. The loader populates the given config in the same way
+// as it was populated initially.
+type HasLoader interface {
+	SetLoader(load func(config any) error)
+}
@@ -16,6 +16,7 @@ import (
 const (
 	defaultFreshnessThreshold = time.Minute
 	defaultExpiryThreshold    = time.Minute * 5
+	defaultRouteMaxHops       = 2
 )
 
 type configDataModel struct {
@@ -83,6 +84,73 @@ type configNodeMedian struct {
 	MinValues int `hcl:"min_values"`
 }
 
+// configNodeRate is a configuration for a Rate node.
+type configNodeRate struct {
+	configNode
+
+	// Period is the period over which the rate applies, in seconds.
+	Period int `hcl:"period,optional"`
+}
+
+// configNodeVector is a configuration for a Vector node.
+type configNodeVector struct {
+	configNode
+
+	Labels []string `hcl:"labels,optional"`
+}
+
+// configNodeBar is a configuration for a Bar node.
+type configNodeBar struct {
+	Pair value.Pair `hcl:"pair,label"`
+
+	configNode
+
+	// Interval is the duration of the bar, in seconds.
+	Interval int `hcl:"interval"`
+}
+
+// configNodeRoute is a configuration for a Route node.
+//
+// Routes are discovered from pairs queried from the listed origins in all
+// data models.
+type configNodeRoute struct {
+	Pair value.Pair `hcl:"pair,label"`
+
+	Origins            []string `hcl:"origins"`
+	MaxHops            int      `hcl:"max_hops,optional"`
+	MinValues          int      `hcl:"min_values,optional"`
+	FreshnessThreshold int      `hcl:"freshness_threshold,optional"`
+	ExpiryThreshold    int      `hcl:"expiry_threshold,optional"`
+
+	// edges contains all origin queries available for route discovery.
+	// It is set before the graph is built.
+	edges []graph.RouteEdge
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
+}
+
+// configNodeExpression is a configuration for an Expression node.
+type configNodeExpression struct {
+	Pair value.Pair `hcl:"pair,label"`
+
+	Formula string                      `hcl:"formula"`
+	Inputs  []configNodeExpressionInput `hcl:"input,block"`
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
+}
+
+// configNodeExpressionInput is a named input of an Expression node. It must
+// contain exactly one node.
+type configNodeExpressionInput struct {
+	Name string `hcl:"name,label"`
+
+	configNode
+}
+
 // DeviationCircuitBreaker is a configuration for a DeviationCircuitBreaker node.
 type DeviationCircuitBreaker struct {
 	configNode
@@ -119,6 +187,11 @@ var nodeSchema = &hcl.BodySchema{
 		{Type: "indirect", LabelNames: []string{}},
 		{Type: "median", LabelNames: []string{}},
 		{Type: "deviation_circuit_breaker", LabelNames: []string{}},
+		{Type: "route", LabelNames: []string{"pair"}},
+		{Type: "expression", LabelNames: []string{"pair"}},
+		{Type: "rate", LabelNames: []string{}},
+		{Type: "vector", LabelNames: []string{}},
+		{Type: "bar", LabelNames: []string{"pair"}},
 	},
 }
 
@@ -149,6 +222,16 @@ func (c *configNode) PostDecodeBlock(
 			node = &configNodeMedian{}
 		case "deviation_circuit_breaker":
 			node = &DeviationCircuitBreaker{}
+		case "route":
+			node = &configNodeRoute{}
+		case "expression":
+			node = &configNodeExpression{}
+		case "rate":
+			node = &configNodeRate{}
+		case "vector":
+			node = &configNodeVector{}
+		case "bar":
+			node = &configNodeBar{}
 		}
 		if diags := utilHCL.DecodeBlock(ctx, block, node); diags.HasErrors() {
 			return diags
@@ -162,6 +245,159 @@ func (c *configNode) hclRange() hcl.Range {
 	return c.Range
 }
 
+func (c *configNode) children() []configDynamicNode {
+	return c.Nodes
+}
+
+// walkConfigNodes calls the given function for each node in the given
+// configuration tree.
+func walkConfigNodes(fn func(configDynamicNode), nodes ...configDynamicNode) {
+	for _, node := range nodes {
+		fn(node)
+		if n, ok := node.(interface{ children() []configDynamicNode }); ok {
+			walkConfigNodes(fn, n.children()...)
+		}
+	}
+}
+
+func (c *configNodeExpression) hclRange() hcl.Range {
+	return c.Range
+}
+
+func (c *configNodeExpression) children() []configDynamicNode {
+	var nodes []configDynamicNode
+	for _, input := range c.Inputs {
+		nodes = append(nodes, input.Nodes...)
+	}
+	return nodes
+}
+
+// buildGraph builds a node for each input of the expression.
+func (c *configNodeExpression) buildGraph(
+	origins map[string]origin.Origin,
+	roots map[string]graph.Node,
+) ([]graph.Node, error) {
+
+	nodes := make([]graph.Node, len(c.Inputs))
+	for i, input := range c.Inputs {
+		inputNodes, err := input.buildGraph(origins, roots)
+		if err != nil {
+			return nil, err
+		}
+		if len(inputNodes) != 1 {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   fmt.Sprintf("Expression input %s must have exactly one node", input.Name),
+				Subject:  input.Range.Ptr(),
+			}
+		}
+		nodes[i] = inputNodes[0]
+	}
+	return nodes, nil
+}
+
+func (c *configNodeRoute) hclRange() hcl.Range {
+	return c.Range
+}
+
+// buildGraph builds a node for each route found from the base to the quote
+// asset of the pair. Single hop routes are origin nodes, inverted if needed,
+// and longer routes are indirect nodes.
+func (c *configNodeRoute) buildGraph(origins map[string]origin.Origin, _ map[string]graph.Node) ([]graph.Node, error) {
+	allowed := make(map[string]bool, len(c.Origins))
+	for _, name := range c.Origins {
+		if _, ok := origins[name]; !ok {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   fmt.Sprintf("Unknown origin: %s", name),
+				Subject:  c.Range.Ptr(),
+			}
+		}
+		allowed[name] = true
+	}
+	maxHops := c.MaxHops
+	if maxHops == 0 {
+		maxHops = defaultRouteMaxHops
+	}
+	if maxHops < 0 {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   "Maximum number of hops must be greater than zero",
+			Subject:  c.Range.Ptr(),
+		}
+	}
+	var edges []graph.RouteEdge
+	for _, e := range c.edges {
+		if allowed[e.Origin] {
+			edges = append(edges, e)
+		}
+	}
+	routes := graph.FindRoutes(c.Pair, edges, maxHops)
+	if len(routes) == 0 {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   fmt.Sprintf("No routes found for pair %s using at most %d hops", c.Pair, maxHops),
+			Subject:  c.Range.Ptr(),
+		}
+	}
+
+	// Origin nodes are shared between routes that use the same edge.
+	originNodes := make(map[graph.RouteEdge]graph.Node)
+	originNode := func(e graph.RouteEdge) (graph.Node, error) {
+		if n, ok := originNodes[e]; ok {
+			return n, nil
+		}
+		n, err := buildOriginNode(&configNodeOrigin{
+			Origin:             e.Origin,
+			Query:              cty.StringVal(e.Pair.String()),
+			FreshnessThreshold: c.FreshnessThreshold,
+			ExpiryThreshold:    c.ExpiryThreshold,
+			configNode:         configNode{Range: c.Range},
+		}, origins)
+		if err != nil {
+			return nil, err
+		}
+		originNodes[e] = n
+		return n, nil
+	}
+
+	nodes := make([]graph.Node, 0, len(routes))
+	for _, route := range routes {
+		hops := make([]graph.Node, len(route))
+		for i, e := range route {
+			n, err := originNode(e)
+			if err != nil {
+				return nil, err
+			}
+			hops[i] = n
+		}
+		var node graph.Node
+		switch {
+		case len(route) > 1:
+			node = graph.NewTickIndirectNode()
+		case route[0].Pair.Equal(c.Pair):
+			nodes = append(nodes, hops[0])
+			continue
+		default:
+			node = graph.NewTickInvertNode()
+		}
+		if err := node.AddNodes(hops...); err != nil {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   err.Error(),
+				Subject:  c.Range.Ptr(),
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
 func (c *configNode) buildGraph(origins map[string]origin.Origin, roots map[string]graph.Node) ([]graph.Node, error) {
 	nodes := make([]graph.Node, len(c.Nodes))
 	for i, node := range c.Nodes {
@@ -208,6 +444,57 @@ func buildNode(
 		return graph.NewTickMedianNode(node.MinValues), nil
 	case *DeviationCircuitBreaker:
 		return graph.NewDevCircuitBreakerNode(), nil
+	case *configNodeExpression:
+		names := make([]string, len(node.Inputs))
+		for i, input := range node.Inputs {
+			names[i] = input.Name
+		}
+		n, err := graph.NewTickExpressionNode(node.Pair, node.Formula, names)
+		if err != nil {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   err.Error(),
+				Subject:  node.Range.Ptr(),
+			}
+		}
+		return n, nil
+	case *configNodeRate:
+		if node.Period < 0 {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   "Period must not be negative",
+				Subject:  node.Range.Ptr(),
+			}
+		}
+		return graph.NewRateNode(time.Duration(node.Period) * time.Second), nil
+	case *configNodeVector:
+		if len(node.Labels) > 0 && len(node.Labels) != len(node.Nodes) {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   "Number of labels must match number of nodes",
+				Subject:  node.Range.Ptr(),
+			}
+		}
+		return graph.NewVectorNode(node.Labels), nil
+	case *configNodeBar:
+		if node.Interval <= 0 {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   "Interval must be greater than zero",
+				Subject:  node.Range.Ptr(),
+			}
+		}
+		return graph.NewBarNode(node.Pair, time.Duration(node.Interval)*time.Second), nil
+	case *configNodeRoute:
+		minValues := node.MinValues
+		if minValues == 0 {
+			minValues = 1
+		}
+		return graph.NewTickRouteNode(node.Pair, minValues), nil
 	default:
 		return nil, fmt.Errorf("unsupported node type")
 	}
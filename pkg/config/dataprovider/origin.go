@@ -16,11 +16,17 @@
 package dataprovider
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"sort"
 
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
 	"github.com/hashicorp/hcl/v2"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/origin"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
 	utilHCL "github.com/chronicleprotocol/oracle-suite/pkg/util/hcl"
 )
 
@@ -31,6 +37,11 @@ type configOrigin struct {
 	// Type is the type of the origin.
 	Type string `hcl:"type"`
 
+	// MinFetchInterval is the minimum number of seconds between consecutive
+	// fetches from the origin. It is used only if background refresh is
+	// enabled.
+	MinFetchInterval int `hcl:"min_fetch_interval,optional"`
+
 	OriginConfig any // Handled by PostDecodeBlock method.
 
 	// HCL fields:
@@ -44,12 +55,14 @@ type configOriginStatic struct{}
 
 // configOriginTickGenericJQ is a configuration for the TickGenericJQ origin.
 type configOriginTickGenericJQ struct {
-	URL string `hcl:"url"` // Do not use config.URL because it encodes $ sign
-	JQ  string `hcl:"jq"`
+	URL     string            `hcl:"url"` // Do not use config.URL because it encodes $ sign
+	JQ      string            `hcl:"jq"`
+	Headers map[string]string `hcl:"headers,optional" json:"-"`
 }
 
 type configOriginIShares struct {
-	URL string `hcl:"url"`
+	URL     string            `hcl:"url"`
+	Headers map[string]string `hcl:"headers,optional" json:"-"`
 }
 
 type configBalancerContracts struct {
@@ -65,6 +78,7 @@ type configOriginBalancer struct {
 	// value should be the token0 address of pool.
 	// If the pool is WeightedPool2Tokens, `references` should not contain the reference key of that pool.
 	Contracts configBalancerContracts `hcl:"contracts,block"`
+	Liquidity *configLiquidity        `hcl:"liquidity,block,optional"`
 }
 
 type configCurveContracts struct {
@@ -77,6 +91,7 @@ type configCurveContracts struct {
 
 type configOriginCurve struct {
 	Contracts configCurveContracts `hcl:"contracts,block"`
+	Liquidity *configLiquidity     `hcl:"liquidity,block,optional"`
 }
 
 type configContracts struct {
@@ -97,21 +112,82 @@ type configOriginSDAI struct {
 }
 
 type configOriginSushiswap struct {
-	Contracts configContracts `hcl:"contracts,block"`
+	Contracts configContracts  `hcl:"contracts,block"`
+	Liquidity *configLiquidity `hcl:"liquidity,block,optional"`
 }
 
 type configOriginUniswapV2 struct {
-	Contracts configContracts `hcl:"contracts,block"`
+	Contracts configContracts  `hcl:"contracts,block"`
+	Liquidity *configLiquidity `hcl:"liquidity,block,optional"`
 }
 
 type configOriginUniswapV3 struct {
-	Contracts configContracts `hcl:"contracts,block"`
+	Contracts configContracts  `hcl:"contracts,block"`
+	Liquidity *configLiquidity `hcl:"liquidity,block,optional"`
+
+	// Quoter is the address of the QuoterV2 contract. It is required if
+	// the liquidity block is used.
+	Quoter types.Address `hcl:"quoter,optional"`
 }
 
 type configOriginWrappedStakedETH struct {
 	Contracts configContracts `hcl:"contracts,block"`
 }
 
+// configLiquidity configures DEX origins to quote execution prices for
+// a given trade size instead of marginal prices.
+type configLiquidity struct {
+	// TradeSize is the amount of tokens sold in a simulated swap, keyed by
+	// the token symbol.
+	TradeSize map[string]float64 `hcl:"trade_size"`
+
+	// BothSides enables quoting swaps in both directions and averaging
+	// the execution prices.
+	BothSides bool `hcl:"both_sides,optional"`
+
+	// HCL fields:
+	Range hcl.Range `hcl:",range" json:"-"`
+}
+
+func (c *configLiquidity) PostDecodeBlock(
+	_ *hcl.EvalContext,
+	_ *hcl.BodySchema,
+	_ *hcl.Block,
+	_ *hcl.BodyContent) hcl.Diagnostics {
+
+	if len(c.TradeSize) == 0 {
+		return hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   "Trade size must be set for at least one token",
+			Subject:  c.Range.Ptr(),
+		}}
+	}
+	for symbol, size := range c.TradeSize {
+		if size <= 0 {
+			return hcl.Diagnostics{{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   fmt.Sprintf("Trade size of %s must be greater than zero", symbol),
+				Subject:  c.Range.Ptr(),
+			}}
+		}
+	}
+	return nil
+}
+
+// liquidityConfig returns the origin liquidity configuration. If the
+// liquidity block is not set, marginal prices are used.
+func (c *configLiquidity) liquidityConfig() origin.LiquidityConfig {
+	if c == nil {
+		return origin.LiquidityConfig{}
+	}
+	return origin.LiquidityConfig{
+		TradeSize: c.TradeSize,
+		BothSides: c.BothSides,
+	}
+}
+
 // averageFromBlocks is a list of blocks distances from the latest blocks from
 // which prices will be averaged.
 var averageFromBlocks = []int64{0, 10, 20}
@@ -122,6 +198,15 @@ func (c *configOrigin) PostDecodeBlock(
 	_ *hcl.Block,
 	_ *hcl.BodyContent) hcl.Diagnostics {
 
+	if c.MinFetchInterval < 0 {
+		return hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   "Minimum fetch interval must not be negative",
+			Subject:  c.Range.Ptr(),
+		}}
+	}
+
 	var config any
 	switch c.Type {
 	case "static":
@@ -163,6 +248,67 @@ func (c *configOrigin) PostDecodeBlock(
 	return nil
 }
 
+// configHash returns a hash of the origin type and its configuration.
+//
+// Fields that are specific to the feed operator, such as HTTP headers which
+// usually contain API keys, are excluded from the hash.
+func (c *configOrigin) configHash() (types.Hash, error) {
+	b, err := json.Marshal(map[string]any{
+		"type":   c.Type,
+		"config": c.OriginConfig,
+	})
+	if err != nil {
+		return types.Hash{}, err
+	}
+	return crypto.Keccak256(b), nil
+}
+
+// pairs returns the pairs that can be queried from the origin, based on the
+// pools listed in its configuration. A pool with more than two tokens
+// provides a pair for every combination of its tokens. Nil is returned for
+// origins that do not list their pairs, such as exchange APIs.
+func (c *configOrigin) pairs() []value.Pair {
+	var pools []origin.ContractAddresses
+	switch o := c.OriginConfig.(type) {
+	case *configOriginBalancer:
+		pools = append(pools, o.Contracts.ContractAddresses)
+	case *configOriginCurve:
+		pools = append(pools, o.Contracts.StableSwapContractAddresses, o.Contracts.CryptoSwapContractAddresses)
+	case *configOriginDSR:
+		pools = append(pools, o.Contracts.ContractAddresses)
+	case *configOriginRocketPool:
+		pools = append(pools, o.Contracts.ContractAddresses)
+	case *configOriginSDAI:
+		pools = append(pools, o.Contracts.ContractAddresses)
+	case *configOriginSushiswap:
+		pools = append(pools, o.Contracts.ContractAddresses)
+	case *configOriginUniswapV2:
+		pools = append(pools, o.Contracts.ContractAddresses)
+	case *configOriginUniswapV3:
+		pools = append(pools, o.Contracts.ContractAddresses)
+	case *configOriginWrappedStakedETH:
+		pools = append(pools, o.Contracts.ContractAddresses)
+	default:
+		return nil
+	}
+	var pairs []value.Pair
+	for _, addresses := range pools {
+		for tokens := range addresses {
+			for i := 0; i < len(tokens) && tokens[i] != ""; i++ {
+				for j := i + 1; j < len(tokens) && tokens[j] != ""; j++ {
+					pairs = append(pairs, value.Pair{Base: tokens[i], Quote: tokens[j]})
+				}
+			}
+		}
+	}
+	// Map iteration order is random, so pairs are sorted to make the
+	// discovered routes deterministic.
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].String() < pairs[j].String()
+	})
+	return pairs
+}
+
 func (c *configOrigin) configureOrigin(d Dependencies) (origin.Origin, error) {
 	switch o := c.OriginConfig.(type) {
 	case *configOriginStatic:
@@ -171,7 +317,7 @@ func (c *configOrigin) configureOrigin(d Dependencies) (origin.Origin, error) {
 		origin, err := origin.NewTickGenericJQ(origin.TickGenericJQConfig{
 			URL:     o.URL,
 			Query:   o.JQ,
-			Headers: nil,
+			Headers: httpHeaders(o.Headers),
 			Client:  d.HTTPClient,
 			Logger:  d.Logger,
 		})
@@ -190,6 +336,7 @@ func (c *configOrigin) configureOrigin(d Dependencies) (origin.Origin, error) {
 			ContractAddresses:  o.Contracts.ContractAddresses,
 			ReferenceAddresses: o.Contracts.ReferenceAddresses,
 			Blocks:             averageFromBlocks,
+			Liquidity:          o.Liquidity.liquidityConfig(),
 			Logger:             d.Logger,
 		})
 		if err != nil {
@@ -207,6 +354,7 @@ func (c *configOrigin) configureOrigin(d Dependencies) (origin.Origin, error) {
 			StableSwapContractAddresses: o.Contracts.StableSwapContractAddresses,
 			CryptoSwapContractAddresses: o.Contracts.CryptoSwapContractAddresses,
 			Blocks:                      averageFromBlocks,
+			Liquidity:                   o.Liquidity.liquidityConfig(),
 			Logger:                      d.Logger,
 		})
 		if err != nil {
@@ -237,7 +385,7 @@ func (c *configOrigin) configureOrigin(d Dependencies) (origin.Origin, error) {
 	case *configOriginIShares:
 		origin, err := origin.NewIShares(origin.ISharesConfig{
 			URL:     o.URL,
-			Headers: nil,
+			Headers: httpHeaders(o.Headers),
 			Client:  d.HTTPClient,
 			Logger:  d.Logger,
 		})
@@ -287,6 +435,7 @@ func (c *configOrigin) configureOrigin(d Dependencies) (origin.Origin, error) {
 			Client:            d.Clients[o.Contracts.EthereumClient],
 			ContractAddresses: o.Contracts.ContractAddresses,
 			Blocks:            averageFromBlocks,
+			Liquidity:         o.Liquidity.liquidityConfig(),
 			Logger:            d.Logger,
 		})
 		if err != nil {
@@ -303,6 +452,7 @@ func (c *configOrigin) configureOrigin(d Dependencies) (origin.Origin, error) {
 			Client:            d.Clients[o.Contracts.EthereumClient],
 			ContractAddresses: o.Contracts.ContractAddresses,
 			Blocks:            averageFromBlocks,
+			Liquidity:         o.Liquidity.liquidityConfig(),
 			Logger:            d.Logger,
 		})
 		if err != nil {
@@ -319,6 +469,8 @@ func (c *configOrigin) configureOrigin(d Dependencies) (origin.Origin, error) {
 			Client:            d.Clients[o.Contracts.EthereumClient],
 			ContractAddresses: o.Contracts.ContractAddresses,
 			Blocks:            averageFromBlocks,
+			QuoterAddress:     o.Quoter,
+			Liquidity:         o.Liquidity.liquidityConfig(),
 			Logger:            d.Logger,
 		})
 		if err != nil {
@@ -349,3 +501,15 @@ func (c *configOrigin) configureOrigin(d Dependencies) (origin.Origin, error) {
 	}
 	return nil, fmt.Errorf("unknown origin %s", c.Type)
 }
+
+// httpHeaders converts headers defined in the config to http.Header.
+func httpHeaders(headers map[string]string) http.Header {
+	if len(headers) == 0 {
+		return nil
+	}
+	h := make(http.Header, len(headers))
+	for k, v := range headers {
+		h.Set(k, v)
+	}
+	return h
+}
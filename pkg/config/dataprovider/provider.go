@@ -19,50 +19,219 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/defiweb/go-eth/types"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/graph"
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/origin"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/config/ethereum"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/sliceutil"
 )
 
+// defaultRefreshLead is the default duration before the freshness threshold
+// of an origin node lapses at which the node is refreshed in the background.
+const defaultRefreshLead = 5 * time.Second
+
 type Dependencies struct {
 	HTTPClient *http.Client
 	Clients    ethereum.ClientRegistry
 	Logger     log.Logger
+
+	// WrapOrigin is an optional function that wraps origins before they
+	// are used to fetch data points, e.g. to record or replay them.
+	WrapOrigin func(name string, o origin.Origin) origin.Origin
 }
 
 type Config struct {
 	Origins    []configOrigin    `hcl:"origin,block"`
 	DataModels []configDataModel `hcl:"data_model,block"`
 
+	// ReloadListenAddr is the address of the admin API that allows to
+	// reload origins and data models without restarting the application,
+	// using the POST /reload request. The configuration is also reloaded
+	// when the SIGHUP signal is received. If empty, the admin API is
+	// disabled.
+	ReloadListenAddr string `hcl:"reload_listen_addr,optional"`
+
+	// BackgroundRefresh enables refreshing of origins in the background,
+	// so that data points are returned without waiting for origins. If
+	// not set, origins are queried when data points are requested.
+	BackgroundRefresh *configBackgroundRefresh `hcl:"background_refresh,block,optional"`
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
+}
+
+type configBackgroundRefresh struct {
+	// Lead is the number of seconds before the freshness threshold of
+	// an origin node lapses at which the node is refreshed.
+	Lead int `hcl:"lead,optional"`
+
+	// Jitter is the maximum number of seconds by which refreshes are
+	// randomly brought forward to spread the load on origins.
+	Jitter int `hcl:"jitter,optional"`
+
 	// HCL fields:
 	Range   hcl.Range       `hcl:",range"`
 	Content hcl.BodyContent `hcl:",content"`
 }
 
+type RefresherDependencies struct {
+	Provider *graph.ReloadableProvider
+	Logger   log.Logger
+}
+
 func (c *Config) ConfigureDataProvider(d Dependencies) (datapoint.Provider, error) {
+	return c.configureGraphProvider(d)
+}
+
+func (c *Config) configureGraphProvider(d Dependencies) (graph.Provider, error) {
 	var err error
 
 	// Configure origins:
 	origins, err := c.configureOrigins(d)
 	if err != nil {
-		return nil, err
+		return graph.Provider{}, err
 	}
 
 	// Configure data models:
 	models, err := c.configureDataModels(origins)
 	if err != nil {
-		return nil, err
+		return graph.Provider{}, err
 	}
 
 	// Configure data provider:
-	return graph.NewProvider(models, graph.NewUpdater(origins, d.Logger)), nil
+	if d.WrapOrigin != nil {
+		wrapped := make(map[string]origin.Origin, len(origins))
+		for name, o := range origins {
+			wrapped[name] = d.WrapOrigin(name, o)
+		}
+		origins = wrapped
+	}
+	originHashes, err := c.originHashes()
+	if err != nil {
+		return graph.Provider{}, err
+	}
+	updater := graph.NewUpdater(origins, d.Logger)
+	if c.BackgroundRefresh != nil {
+		return graph.NewBackgroundProvider(models, updater, c.rateLimits()).WithOriginHashes(originHashes), nil
+	}
+	return graph.NewProvider(models, updater).WithOriginHashes(originHashes), nil
+}
+
+// ConfigureRefresher returns a service that refreshes origins used by the
+// given provider in the background. If background refresh is not enabled,
+// nil is returned.
+func (c *Config) ConfigureRefresher(d RefresherDependencies) (*graph.Refresher, error) {
+	if c.BackgroundRefresh == nil {
+		return nil, nil
+	}
+	lead := time.Duration(c.BackgroundRefresh.Lead) * time.Second
+	if lead == 0 {
+		lead = defaultRefreshLead
+	}
+	r, err := graph.NewRefresher(graph.RefresherConfig{
+		Provider: d.Provider.Provider,
+		Lead:     lead,
+		Jitter:   time.Duration(c.BackgroundRefresh.Jitter) * time.Second,
+		Logger:   d.Logger,
+	})
+	if err != nil {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Runtime error",
+			Detail:   fmt.Sprintf("Failed to create the background refresher: %v", err),
+			Subject:  c.BackgroundRefresh.Range.Ptr(),
+		}
+	}
+	return r, nil
+}
+
+// prepareRoutes sets the list of available origin queries on all route
+// nodes. Available queries are pairs listed in the configuration of origins,
+// such as pools of DEX origins, and pairs queried by origin nodes in all data
+// models, which covers origins that do not list their pairs.
+func (c *Config) prepareRoutes() {
+	var (
+		nodes  []configDynamicNode
+		routes []*configNodeRoute
+		edges  []graph.RouteEdge
+	)
+	seen := map[graph.RouteEdge]bool{}
+	for _, o := range c.Origins {
+		for _, pair := range o.pairs() {
+			e := graph.RouteEdge{Origin: o.Name, Pair: pair}
+			if !seen[e] {
+				seen[e] = true
+				edges = append(edges, e)
+			}
+		}
+	}
+	for _, dm := range c.DataModels {
+		nodes = append(nodes, dm.Nodes...)
+	}
+	walkConfigNodes(func(n configDynamicNode) {
+		switch n := n.(type) {
+		case *configNodeRoute:
+			routes = append(routes, n)
+		case *configNodeOrigin:
+			if !n.Query.IsKnown() || n.Query.IsNull() || !n.Query.Type().Equals(cty.String) {
+				return
+			}
+			pair, err := value.PairFromString(n.Query.AsString())
+			if err != nil {
+				return
+			}
+			e := graph.RouteEdge{Origin: n.Origin, Pair: pair}
+			if !seen[e] {
+				seen[e] = true
+				edges = append(edges, e)
+			}
+		}
+	}, nodes...)
+	for _, r := range routes {
+		r.edges = edges
+	}
+}
+
+// rateLimits returns the minimum duration between consecutive fetches for
+// origins that have it configured.
+func (c *Config) rateLimits() map[string]time.Duration {
+	rateLimits := map[string]time.Duration{}
+	for _, o := range c.Origins {
+		if o.MinFetchInterval > 0 {
+			rateLimits[o.Name] = time.Duration(o.MinFetchInterval) * time.Second
+		}
+	}
+	return rateLimits
+}
+
+// originHashes returns hashes of origin configurations, keyed by the origin
+// name. They are included in model hashes, so feeds can detect that they use
+// different origin configurations for the same data model.
+func (c *Config) originHashes() (map[string]types.Hash, error) {
+	hashes := make(map[string]types.Hash, len(c.Origins))
+	for _, o := range c.Origins {
+		hash, err := o.configHash()
+		if err != nil {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Runtime error",
+				Detail:   fmt.Sprintf("Failed to calculate the configuration hash of the %s origin: %v", o.Name, err),
+				Subject:  o.Range.Ptr(),
+			}
+		}
+		hashes[o.Name] = hash
+	}
+	return hashes, nil
 }
 
 func (c *Config) configureOrigins(d Dependencies) (map[string]origin.Origin, error) {
@@ -85,6 +254,9 @@ func (c *Config) configureDataModels(origins map[string]origin.Origin) (map[stri
 		models[pm.Name] = graph.NewReferenceNode()
 	}
 
+	// Provide route nodes with pairs available from origins.
+	c.prepareRoutes()
+
 	// Configure each data model.
 	for _, pm := range c.DataModels {
 		dataModel, err := pm.configureDataModel(origins, models)
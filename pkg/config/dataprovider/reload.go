@@ -0,0 +1,95 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dataprovider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/graph"
+	"github.com/chronicleprotocol/oracle-suite/pkg/reloader"
+)
+
+type ReloaderDependencies struct {
+	Dependencies
+
+	// Provider is the provider which underlying provider is replaced after
+	// the configuration is reloaded.
+	Provider *graph.ReloadableProvider
+
+	// Load loads the new configuration.
+	Load func() (*Config, error)
+
+	// Validate is an optional function that validates the new provider
+	// before it is used, e.g. to verify that all required data models
+	// are present.
+	Validate func(ctx context.Context, p datapoint.Provider) error
+}
+
+// ConfigureReloadableDataProvider returns a data provider which configuration
+// can be reloaded using the returned Reloader.
+func (c *Config) ConfigureReloadableDataProvider(d Dependencies) (*graph.ReloadableProvider, error) {
+	p, err := c.configureGraphProvider(d)
+	if err != nil {
+		return nil, err
+	}
+	return graph.NewReloadableProvider(p), nil
+}
+
+// ConfigureReloader returns a service that reloads origins and data models
+// when the SIGHUP signal is received or when requested using the admin API.
+//
+// If the new configuration is invalid, it is rejected, and the previous one
+// is still used. Adding or removing the background_refresh block is
+// rejected, and changes to its attributes are applied after restart.
+func (c *Config) ConfigureReloader(d ReloaderDependencies) (*reloader.Reloader, error) {
+	r, err := reloader.New(reloader.Config{
+		Reload: func(ctx context.Context) error {
+			cfg, err := d.Load()
+			if err != nil {
+				return err
+			}
+			if (cfg.BackgroundRefresh == nil) != (c.BackgroundRefresh == nil) {
+				return fmt.Errorf("background refresh cannot be enabled or disabled without restart")
+			}
+			p, err := cfg.configureGraphProvider(d.Dependencies)
+			if err != nil {
+				return err
+			}
+			if d.Validate != nil {
+				if err := d.Validate(ctx, p); err != nil {
+					return err
+				}
+			}
+			d.Provider.Swap(p)
+			return nil
+		},
+		ListenAddr: c.ReloadListenAddr,
+		Logger:     d.Logger,
+	})
+	if err != nil {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Runtime error",
+			Detail:   fmt.Sprintf("Failed to create the reloader: %v", err),
+			Subject:  c.Range.Ptr(),
+		}
+	}
+	return r, nil
+}
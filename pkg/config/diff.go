@@ -0,0 +1,294 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+	ctyJSON "github.com/zclconf/go-cty/cty/json"
+)
+
+// ChangeKind is a kind of the change between two configurations.
+type ChangeKind int
+
+const (
+	Added ChangeKind = iota
+	Removed
+	Modified
+)
+
+// String implements the fmt.Stringer interface.
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "+"
+	case Removed:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+// Change describes a single difference between two configurations.
+type Change struct {
+	// Kind is the kind of the change.
+	Kind ChangeKind
+
+	// Path is the path to the changed value, using names of HCL blocks
+	// and attributes, e.g. `gofer.data_model["BTC/USD"].origin["binance"]`.
+	// Blocks are identified by their labels, and elements of lists of
+	// scalar values are identified by their values.
+	Path []string
+
+	// Old and New are values before and after the change. Empty for
+	// added and removed values respectively.
+	Old, New string
+}
+
+// String returns the path as a string.
+func (c Change) String() string {
+	return strings.Join(c.Path, ".")
+}
+
+// Diff compares two configurations of the same type and returns a list of
+// differences between them, sorted by path.
+//
+// Only fields with HCL tags are compared. Ranges and contents of HCL bodies
+// are ignored, so moving a block within a file or to a different file does
+// not result in a change.
+func Diff(a, b any) []Change {
+	am, bm := map[string]leaf{}, map[string]leaf{}
+	flatten(am, nil, reflect.ValueOf(a))
+	flatten(bm, nil, reflect.ValueOf(b))
+	var changes []Change
+	for k, av := range am {
+		bv, ok := bm[k]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Kind: Removed, Path: av.path, Old: av.value})
+		case av.value != bv.value:
+			changes = append(changes, Change{Kind: Modified, Path: av.path, Old: av.value, New: bv.value})
+		}
+	}
+	for k, bv := range bm {
+		if _, ok := am[k]; !ok {
+			changes = append(changes, Change{Kind: Added, Path: bv.path, New: bv.value})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].String() < changes[j].String()
+	})
+	return changes
+}
+
+type leaf struct {
+	path  []string
+	value string
+}
+
+var (
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	stringerType      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	ctyValueType      = reflect.TypeOf(cty.Value{})
+)
+
+// flatten converts a value into a map of leaf values keyed by their paths.
+func flatten(m map[string]leaf, path []string, v reflect.Value) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if s, ok := scalar(v); ok {
+		add(m, path, s)
+		return
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if _, tagged := f.Tag.Lookup("hcl"); f.Anonymous && !tagged {
+				// Fields of embedded structs are decoded as if they were
+				// fields of the parent struct.
+				flatten(m, path, v.Field(i))
+				continue
+			}
+			name, ok := fieldName(f)
+			if !ok {
+				continue
+			}
+			flatten(m, appendPath(path, name), v.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			e := v.Index(i)
+			if s, ok := scalar(e); ok {
+				// Lists of scalar values are compared as sets.
+				add(m, appendPath(path, fmt.Sprintf("%s[%q]", last(path), s)), "")
+				continue
+			}
+			flatten(m, appendPath(path, fmt.Sprintf("%s[%s]", last(path), elemKey(e, i))), e)
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		for _, k := range keys {
+			ks, _ := scalar(k)
+			flatten(m, appendPath(path, fmt.Sprintf("%s[%q]", last(path), ks)), v.MapIndex(k))
+		}
+	}
+}
+
+// scalar returns a string representation of a value that is not a
+// container. It returns false for structs, slices and maps, unless they can
+// be converted to text.
+func scalar(v reflect.Value) (string, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", true
+		}
+		v = v.Elem()
+	}
+	if v.Type() == ctyValueType {
+		cv := v.Interface().(cty.Value)
+		if cv.IsNull() || !cv.IsWhollyKnown() {
+			return "", true
+		}
+		b, err := ctyJSON.Marshal(cv, cv.Type())
+		if err != nil {
+			return cv.GoString(), true
+		}
+		return string(b), true
+	}
+	if v.CanInterface() {
+		if reflect.PointerTo(v.Type()).Implements(textMarshalerType) && v.CanAddr() {
+			if b, err := v.Addr().Interface().(encoding.TextMarshaler).MarshalText(); err == nil {
+				return string(b), true
+			}
+		}
+		if v.Type().Implements(textMarshalerType) {
+			if b, err := v.Interface().(encoding.TextMarshaler).MarshalText(); err == nil {
+				return string(b), true
+			}
+		}
+		if v.Type().Implements(stringerType) {
+			return v.Interface().(fmt.Stringer).String(), true
+		}
+		if reflect.PointerTo(v.Type()).Implements(stringerType) && v.CanAddr() {
+			return v.Addr().Interface().(fmt.Stringer).String(), true
+		}
+	}
+	switch v.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return fmt.Sprintf("%x", v.Bytes()), true
+		}
+		return "", false
+	}
+	return fmt.Sprint(v.Interface()), true
+}
+
+// fieldName returns the HCL name of the field. It returns false for fields
+// that do not contain configuration values. Exported fields without the
+// HCL tag are assumed to be populated from the HCL body during decoding.
+func fieldName(f reflect.StructField) (string, bool) {
+	if !f.IsExported() {
+		return "", false
+	}
+	switch f.Type.Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return "", false
+	}
+	tag, ok := f.Tag.Lookup("hcl")
+	if !ok {
+		return strings.ToLower(f.Name), true
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	switch {
+	case name == "" && opts != "label":
+		// Range, content and remaining body.
+		return "", false
+	case name == "":
+		return strings.ToLower(f.Name), true
+	}
+	return name, true
+}
+
+// elemKey returns a key that identifies an element of a list of blocks.
+// Blocks are identified by their labels. Blocks without labels are
+// identified by a contract address, if they have one, or by their index.
+func elemKey(v reflect.Value, idx int) string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return fmt.Sprint(idx)
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return fmt.Sprint(idx)
+	}
+	var labels []string
+	var addr string
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		tag := f.Tag.Get("hcl")
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		if strings.HasSuffix(tag, ",label") {
+			s, _ := scalar(v.Field(i))
+			labels = append(labels, s)
+		}
+		if tag == "contract_addr" {
+			addr, _ = scalar(v.Field(i))
+		}
+	}
+	switch {
+	case len(labels) > 0:
+		return fmt.Sprintf("%q", strings.Join(labels, " "))
+	case addr != "":
+		return fmt.Sprintf("%q", addr)
+	}
+	return fmt.Sprint(idx)
+}
+
+func add(m map[string]leaf, path []string, value string) {
+	m[strings.Join(path, ".")] = leaf{path: path, value: value}
+}
+
+// appendPath appends an element to the path. If the element is an indexed
+// element of the last path element, it replaces the last element.
+func appendPath(path []string, elem string) []string {
+	p := make([]string, 0, len(path)+1)
+	p = append(p, path...)
+	if len(p) > 0 && strings.HasPrefix(elem, p[len(p)-1]+"[") {
+		p[len(p)-1] = elem
+		return p
+	}
+	return append(p, elem)
+}
+
+func last(path []string) string {
+	if len(path) == 0 {
+		return ""
+	}
+	return path[len(path)-1]
+}
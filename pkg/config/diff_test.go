@@ -0,0 +1,96 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type diffTestConfig struct {
+	Feeds    []string          `hcl:"feeds"`
+	Interval int               `hcl:"interval"`
+	URL      *URL              `hcl:"url,optional"`
+	Models   []diffTestModel   `hcl:"data_model,block"`
+	Contract []diffTestMedian  `hcl:"median,block"`
+	Range    hcl.Range         `hcl:",range"`
+	Content  hcl.BodyContent   `hcl:",content"`
+	Extra    map[string]string `hcl:"extra,optional"`
+	loader   func()
+}
+
+type diffTestModel struct {
+	Name string `hcl:"name,label"`
+	diffTestNode
+}
+
+type diffTestNode struct {
+	Query cty.Value `hcl:"query"`
+	Nodes []any
+}
+
+type diffTestMedian struct {
+	ContractAddr string  `hcl:"contract_addr"`
+	Spread       float64 `hcl:"spread"`
+}
+
+func TestDiff(t *testing.T) {
+	oldCfg := diffTestConfig{
+		Feeds:    []string{"0x1", "0x2"},
+		Interval: 60,
+		Models: []diffTestModel{
+			{Name: "BTC/USD", diffTestNode: diffTestNode{Query: cty.StringVal("BTC/USD")}},
+			{Name: "ETH/USD", diffTestNode: diffTestNode{Query: cty.StringVal("ETH/USD")}},
+		},
+		Contract: []diffTestMedian{{ContractAddr: "0xa", Spread: 1}},
+		Range:    hcl.Range{Filename: "old.hcl"},
+		Extra:    map[string]string{"a": "1"},
+	}
+	newCfg := diffTestConfig{
+		Feeds:    []string{"0x2", "0x3"},
+		Interval: 60,
+		Models: []diffTestModel{
+			{Name: "ETH/USD", diffTestNode: diffTestNode{Query: cty.StringVal("ETH/USD")}},
+			{Name: "BTC/USD", diffTestNode: diffTestNode{Query: cty.StringVal("XBT/USD")}},
+		},
+		Contract: []diffTestMedian{{ContractAddr: "0xa", Spread: 2}},
+		Range:    hcl.Range{Filename: "new.hcl"},
+		Extra:    map[string]string{"a": "1"},
+	}
+
+	changes := Diff(&oldCfg, &newCfg)
+	var paths []string
+	for _, c := range changes {
+		paths = append(paths, c.Kind.String()+" "+c.String())
+	}
+	assert.Equal(t, []string{
+		`~ data_model["BTC/USD"].query`,
+		`- feeds["0x1"]`,
+		`+ feeds["0x3"]`,
+		`~ median["0xa"].spread`,
+	}, paths)
+	require.Len(t, changes, 4)
+	assert.Equal(t, `"BTC/USD"`, changes[0].Old)
+	assert.Equal(t, `"XBT/USD"`, changes[0].New)
+	assert.Equal(t, "1", changes[3].Old)
+	assert.Equal(t, "2", changes[3].New)
+
+	assert.Empty(t, Diff(&oldCfg, &oldCfg))
+}
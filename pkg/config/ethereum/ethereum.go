@@ -16,6 +16,7 @@
 package ethereum
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"net/http"
@@ -32,8 +33,10 @@ import (
 	"github.com/hashicorp/hcl/v2"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/config"
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/remotesigner"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 	"github.com/chronicleprotocol/oracle-suite/pkg/rpcsplitter"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/sliceutil"
 )
 
 const LoggerTag = "CONFIG_ETHEREUM"
@@ -43,6 +46,8 @@ const (
 	defaultTotalTimeout       = 10
 	defaultGracefulTimeout    = 1
 	defaultGasLimitMultiplier = 1.25
+
+	defaultRemoteSignerTimeout uint32 = 10
 )
 
 type (
@@ -53,6 +58,10 @@ type (
 type Dependencies struct {
 	// Logger is the logger that is used by RPC-Splitter.
 	Logger log.Logger
+
+	// WrapTransport is an optional function that wraps the RPC transport
+	// of every client, e.g. to record or replay calls.
+	WrapTransport func(client string, t transport.Transport) transport.Transport
 }
 
 // Config contains the configuration for Ethereum clients and keys.
@@ -63,6 +72,9 @@ type Config struct {
 	// RandKeys is a list of random keys.
 	RandKeys []string `hcl:"rand_keys,optional"`
 
+	// RemoteSigners is a list of remote signers that can be used by keys.
+	RemoteSigners []ConfigRemoteSigner `hcl:"remote_signer,block"`
+
 	// Clients is a list of Ethereum clients.
 	Clients []ConfigClient `hcl:"client,block"`
 
@@ -84,18 +96,56 @@ type ConfigKey struct {
 	// Address is the address of the key in hex format.
 	Address types.Address `hcl:"address"`
 
-	// KeystorePath is the path to the keystore directory.
-	KeystorePath string `hcl:"keystore_path"`
+	// KeystorePath is the path to the keystore directory. Required unless
+	// the remote signer is used.
+	KeystorePath string `hcl:"keystore_path,optional"`
 
 	// PassphraseFile is the path to the file containing the passphrase for the
 	// key. If empty, then the passphrase is not provided.
 	PassphraseFile string `hcl:"passphrase_file,optional"`
 
+	// Passphrase is the passphrase for the key. It is meant to be used with
+	// the secret function. Cannot be used together with PassphraseFile.
+	Passphrase string `hcl:"passphrase,optional"`
+
+	// RemoteSigner is the name of the remote signer that holds the key. If
+	// set, the key is not read from the keystore and all signing requests
+	// are delegated to the remote signer.
+	RemoteSigner string `hcl:"remote_signer,optional"`
+
 	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
 	Content hcl.BodyContent `hcl:",content"`
 
 	// Configured key:
-	key wallet.Key
+	key          wallet.Key
+	remoteSigner *ConfigRemoteSigner
+}
+
+// ConfigRemoteSigner contains the configuration for a remote signer that
+// implements the EIP-3030 JSON-RPC methods, such as web3signer.
+type ConfigRemoteSigner struct {
+	// Name is the unique name of the remote signer that can be referenced by
+	// keys.
+	Name string `hcl:"name,label"`
+
+	// URL is the JSON-RPC endpoint of the remote signer.
+	URL config.URL `hcl:"url"`
+
+	// Timeout is the timeout for a single signing request, in seconds.
+	Timeout uint32 `hcl:"timeout,optional"`
+
+	// Headers are sent with every request to the remote signer, e.g. to
+	// authenticate the client.
+	Headers map[string]string `hcl:"headers,optional"`
+
+	// AllowedAddresses is the list of addresses of keys that are allowed to
+	// be used with the remote signer. If empty, all keys are allowed.
+	AllowedAddresses []types.Address `hcl:"allowed_addresses,optional"`
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
 }
 
 // ConfigClient contains the configuration for an Ethereum client.
@@ -124,6 +174,15 @@ type ConfigClient struct {
 	// block number.
 	MaxBlocksBehind uint64 `hcl:"max_blocks_behind,optional"`
 
+	// CacheTTL is the time for which responses that depend on the chain head
+	// are cached, in seconds. If zero, the cache is disabled.
+	CacheTTL uint32 `hcl:"cache_ttl,optional"`
+
+	// CacheReorgDepth is the number of blocks after which responses for
+	// a specific block are considered immutable. Responses for more recent
+	// blocks are not cached. If zero, 64 blocks are used.
+	CacheReorgDepth uint64 `hcl:"cache_reorg_depth,optional"`
+
 	// Key configuration:
 
 	// EthereumKey is the name of the Ethereum key to use for signing
@@ -146,7 +205,8 @@ type ConfigClient struct {
 	Content hcl.BodyContent `hcl:",content"`
 
 	// Configured services:
-	client rpc.RPC
+	client        rpc.RPC
+	wrapTransport func(client string, t transport.Transport) transport.Transport
 }
 
 // KeyRegistry returns the list of configured Ethereum keys.
@@ -179,7 +239,7 @@ func (c *Config) prepare(d Dependencies) error {
 	if err := c.prepareKeys(logger); err != nil {
 		return err
 	}
-	if err := c.prepareClients(logger); err != nil {
+	if err := c.prepareClients(logger, d.WrapTransport); err != nil {
 		return err
 	}
 	c.prepared = true
@@ -187,9 +247,35 @@ func (c *Config) prepare(d Dependencies) error {
 }
 
 func (c *Config) prepareKeys(logger log.Logger) error {
-	// Keys from the keystore.
+	// Remote signers.
+	signers := make(map[string]*ConfigRemoteSigner)
+	for i, signerCfg := range c.RemoteSigners {
+		if _, ok := signers[signerCfg.Name]; ok {
+			return &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   fmt.Sprintf("Remote signer with name %q already exists", signerCfg.Name),
+				Subject:  signerCfg.Range.Ptr(),
+			}
+		}
+		signers[signerCfg.Name] = &c.RemoteSigners[i]
+	}
+
+	// Keys from the keystore or remote signers.
 	c.keys = make(map[string]wallet.Key)
-	for _, keyCfg := range c.Keys {
+	for i := range c.Keys {
+		keyCfg := &c.Keys[i]
+		if keyCfg.RemoteSigner != "" {
+			keyCfg.remoteSigner = signers[keyCfg.RemoteSigner]
+			if keyCfg.remoteSigner == nil {
+				return &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Validation error",
+					Detail:   fmt.Sprintf("Remote signer %q is not configured", keyCfg.RemoteSigner),
+					Subject:  keyCfg.Content.Attributes["remote_signer"].Range.Ptr(),
+				}
+			}
+		}
 		key, err := keyCfg.Key(logger)
 		if err != nil {
 			return err
@@ -228,7 +314,7 @@ func (c *Config) prepareKeys(logger log.Logger) error {
 	return nil
 }
 
-func (c *Config) prepareClients(logger log.Logger) error {
+func (c *Config) prepareClients(logger log.Logger, wrap func(string, transport.Transport) transport.Transport) error {
 	c.clients = make(map[string]rpc.RPC)
 	for _, clientCfg := range c.Clients {
 		if _, ok := c.clients[clientCfg.Name]; ok {
@@ -239,6 +325,7 @@ func (c *Config) prepareClients(logger log.Logger) error {
 				Subject:  clientCfg.Range.Ptr(),
 			}
 		}
+		clientCfg.wrapTransport = wrap
 		client, err := clientCfg.Client(logger, c.keys)
 		if err != nil {
 			return err
@@ -275,14 +362,39 @@ func (c *ConfigKey) Key(logger log.Logger) (wallet.Key, error) {
 		}
 	}
 
-	// Get passphrase.
-	passphrase, err := readAccountPassphrase(c.PassphraseFile)
-	if err != nil {
+	// Use the remote signer if configured.
+	if c.remoteSigner != nil {
+		return c.remoteKey(logger)
+	}
+	if c.KeystorePath == "" {
 		return nil, &hcl.Diagnostic{
 			Severity: hcl.DiagError,
 			Summary:  "Validation error",
-			Detail:   fmt.Sprintf("Failed to read Ethereum key passphrase: %v", err),
-			Subject:  c.Content.Attributes["passphrase_file"].Range.Ptr(),
+			Detail:   "Either keystore_path or remote_signer must be set",
+			Subject:  c.Range.Ptr(),
+		}
+	}
+
+	// Get passphrase.
+	passphrase := c.Passphrase
+	if c.PassphraseFile != "" {
+		if c.Passphrase != "" {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   "Only one of passphrase and passphrase_file can be set",
+				Subject:  c.Content.Attributes["passphrase_file"].Range.Ptr(),
+			}
+		}
+		var err error
+		passphrase, err = readAccountPassphrase(c.PassphraseFile)
+		if err != nil {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   fmt.Sprintf("Failed to read Ethereum key passphrase: %v", err),
+				Subject:  c.Content.Attributes["passphrase_file"].Range.Ptr(),
+			}
 		}
 	}
 
@@ -306,6 +418,65 @@ func (c *ConfigKey) Key(logger log.Logger) (wallet.Key, error) {
 	return key, nil
 }
 
+func (c *ConfigKey) remoteKey(logger log.Logger) (wallet.Key, error) {
+	if c.KeystorePath != "" || c.PassphraseFile != "" || c.Passphrase != "" {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   "The keystore_path, passphrase and passphrase_file cannot be used together with remote_signer",
+			Subject:  c.Content.Attributes["remote_signer"].Range.Ptr(),
+		}
+	}
+	s := c.remoteSigner
+	if len(s.AllowedAddresses) > 0 && !sliceutil.Contains(s.AllowedAddresses, c.Address) {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   fmt.Sprintf("Address %s is not allowed to be used with the remote signer %q", c.Address, s.Name),
+			Subject:  c.Content.Attributes["address"].Range.Ptr(),
+		}
+	}
+	timeout := defaultRemoteSignerTimeout
+	if s.Timeout > 0 {
+		timeout = s.Timeout
+	}
+	headers := http.Header{}
+	for k, v := range s.Headers {
+		headers.Set(k, v)
+	}
+	key, err := remotesigner.New(remotesigner.Config{
+		URL:     s.URL.String(),
+		Address: c.Address,
+		Headers: headers,
+		Timeout: time.Duration(timeout) * time.Second,
+	})
+	if err != nil {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   fmt.Sprintf("Failed to create remote signer key: %v", err),
+			Subject:  s.Range.Ptr(),
+		}
+	}
+	if err := key.Verify(context.Background()); err != nil {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Runtime error",
+			Detail:   fmt.Sprintf("Failed to verify remote signer key: %v", err),
+			Subject:  c.Content.Attributes["remote_signer"].Range.Ptr(),
+		}
+	}
+
+	logger.
+		WithField("name", c.Name).
+		WithField("address", key.Address().String()).
+		WithField("remoteSigner", s.Name).
+		Info("Ethereum Key")
+
+	c.key = key
+	return key, nil
+}
+
 // Client returns the configured RPC client.
 func (c *ConfigClient) Client(logger log.Logger, keys KeyRegistry) (rpc.RPC, error) {
 	if c == nil {
@@ -368,6 +539,9 @@ func (c *ConfigClient) Client(logger log.Logger, keys KeyRegistry) (rpc.RPC, err
 	if err != nil {
 		return nil, err
 	}
+	if c.wrapTransport != nil {
+		rpcTransport = c.wrapTransport(c.Name, rpcTransport)
+	}
 	opts := []rpc.ClientOptions{
 		rpc.WithTransport(rpcTransport),
 		rpc.WithTXModifiers(
@@ -478,15 +652,17 @@ func (c *ConfigClient) transport(logger log.Logger) (transport.Transport, error)
 	}
 	// In theory, we don't need to use RPC-Splitter for a single endpoint, but
 	// to make the application behavior consistent we use it.
-	splitter, err := rpcsplitter.NewTransport(
-		splitterVirtualHost,
-		nil,
+	opts := []rpcsplitter.Option{
 		rpcsplitter.WithEndpoints(rpcURLs),
-		rpcsplitter.WithTotalTimeout(time.Second*time.Duration(c.Timeout)),
-		rpcsplitter.WithGracefulTimeout(time.Second*time.Duration(c.GracefulTimeout)),
+		rpcsplitter.WithTotalTimeout(time.Second * time.Duration(c.Timeout)),
+		rpcsplitter.WithGracefulTimeout(time.Second * time.Duration(c.GracefulTimeout)),
 		rpcsplitter.WithRequirements(minimumRequiredResponses(len(c.RPCURLs)), int(c.MaxBlocksBehind)),
 		rpcsplitter.WithLogger(logger),
-	)
+	}
+	if c.CacheTTL > 0 {
+		opts = append(opts, rpcsplitter.WithCache(time.Second*time.Duration(c.CacheTTL), c.CacheReorgDepth))
+	}
+	splitter, err := rpcsplitter.NewTransport(splitterVirtualHost, nil, opts...)
 	if err != nil {
 		return nil, &hcl.Diagnostic{
 			Severity: hcl.DiagError,
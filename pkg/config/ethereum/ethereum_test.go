@@ -4,10 +4,12 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/defiweb/go-eth/wallet"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/config"
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
 )
 
@@ -41,6 +43,8 @@ func TestConfig(t *testing.T) {
 				assert.Equal(t, uint32(10), cfg.Clients[1].Timeout)
 				assert.Equal(t, uint32(5), cfg.Clients[1].GracefulTimeout)
 				assert.Equal(t, uint64(100), cfg.Clients[1].MaxBlocksBehind)
+				assert.Equal(t, uint32(1), cfg.Clients[1].CacheTTL)
+				assert.Equal(t, uint64(64), cfg.Clients[1].CacheReorgDepth)
 				assert.Equal(t, "key2", cfg.Clients[1].EthereumKey)
 				assert.Equal(t, uint64(1), cfg.Clients[1].ChainID)
 				assert.Equal(t, "eip1559", cfg.Clients[1].TransactionType)
@@ -86,3 +90,83 @@ func TestConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_RemoteSigner(t *testing.T) {
+	local := wallet.NewRandomKey()
+	signer := mocks.NewRemoteSigner(local)
+	defer signer.Close()
+
+	t.Setenv("TEST_REMOTE_SIGNER_URL", signer.URL)
+	t.Setenv("TEST_REMOTE_SIGNER_ADDRESS", local.Address().String())
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			path: "remote-signer.hcl",
+		},
+		{
+			name:    "not allowed",
+			path:    "remote-signer-not-allowed.hcl",
+			wantErr: "is not allowed to be used with the remote signer",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var cfg Config
+			err := config.LoadFiles(&cfg, []string{"./testdata/" + test.path})
+			require.NoError(t, err)
+
+			keys, err := cfg.KeyRegistry(Dependencies{Logger: null.New()})
+			if test.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, keys, "key")
+			assert.Equal(t, local.Address(), keys["key"].Address())
+
+			sig, err := keys["key"].SignMessage([]byte("foo"))
+			require.NoError(t, err)
+			assert.True(t, local.VerifyMessage([]byte("foo"), *sig))
+		})
+	}
+}
+
+func TestConfig_Passphrase(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{
+			name: "secret",
+			path: "passphrase.hcl",
+		},
+		{
+			name:    "conflict",
+			path:    "passphrase-conflict.hcl",
+			wantErr: "Only one of passphrase and passphrase_file can be set",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var cfg Config
+			err := config.LoadFiles(&cfg, []string{"./testdata/" + test.path})
+			require.NoError(t, err)
+
+			keys, err := cfg.KeyRegistry(Dependencies{Logger: null.New()})
+			if test.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "0x2d800d93b065ce011af83f316cef9f0d005b0aa4", keys["key"].Address().String())
+		})
+	}
+}
@@ -26,6 +26,8 @@ client "client2" {
   timeout                     = 10
   graceful_timeout            = 5
   max_blocks_behind           = 100
+  cache_ttl                   = 1
+  cache_reorg_depth           = 64
   ethereum_key                = "key2"
   chain_id                    = 1
   tx_type                     = "eip1559"
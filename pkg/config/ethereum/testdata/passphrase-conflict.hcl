@@ -0,0 +1,6 @@
+key "key" {
+  address         = "0x2d800d93b065ce011af83f316cef9f0d005b0aa4"
+  keystore_path   = "./testdata/keystore"
+  passphrase      = "test123"
+  passphrase_file = "./testdata/keystore/passphrase"
+}
@@ -0,0 +1,5 @@
+key "key" {
+  address       = "0x2d800d93b065ce011af83f316cef9f0d005b0aa4"
+  keystore_path = "./testdata/keystore"
+  passphrase    = secret("file", "./testdata/keystore/passphrase")
+}
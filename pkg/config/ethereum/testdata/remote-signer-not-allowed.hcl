@@ -0,0 +1,9 @@
+remote_signer "signer" {
+  url               = env("TEST_REMOTE_SIGNER_URL", "")
+  allowed_addresses = ["0x2d800d93b065ce011af83f316cef9f0d005b0aa4"]
+}
+
+key "key" {
+  address       = env("TEST_REMOTE_SIGNER_ADDRESS", "")
+  remote_signer = "signer"
+}
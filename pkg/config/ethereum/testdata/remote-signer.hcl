@@ -0,0 +1,11 @@
+remote_signer "signer" {
+  url               = env("TEST_REMOTE_SIGNER_URL", "")
+  timeout           = 5
+  headers           = { "Authorization" = "Bearer token" }
+  allowed_addresses = [env("TEST_REMOTE_SIGNER_ADDRESS", "")]
+}
+
+key "key" {
+  address       = env("TEST_REMOTE_SIGNER_ADDRESS", "")
+  remote_signer = "signer"
+}
@@ -29,6 +29,8 @@ import (
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/negotiator"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/sliceutil"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/timeutil"
 )
 
@@ -46,6 +48,15 @@ type Config struct {
 
 	DataModels []string `hcl:"data_models"`
 
+	// Models is an optional per-model update schedule. Data models without
+	// a schedule are published at the interval defined above.
+	Models []configModel `hcl:"data_model,block"`
+
+	// Dispersion defines the limits of the dispersion of sources used to
+	// calculate data points. Data points that exceed them are not signed
+	// and not broadcast.
+	Dispersion *configDispersion `hcl:"dispersion,block,optional"`
+
 	// HCL fields:
 	Range   hcl.Range       `hcl:",range"`
 	Content hcl.BodyContent `hcl:",content"`
@@ -54,10 +65,59 @@ type Config struct {
 	feed *feed.Feed
 }
 
+type configModel struct {
+	// Name is the name of the data model. It must be listed in the
+	// data_models attribute.
+	Name string `hcl:",label"`
+
+	// Interval is the interval at which to publish the data model in
+	// seconds. If zero, the feed interval is used.
+	Interval uint32 `hcl:"interval,optional"`
+
+	// Deviation is the minimum change of the value since the last
+	// broadcast required to publish the data model again. A deviation is
+	// represented as a percentage point, e.g. 1 means 1%. If zero, the
+	// data model is published at every interval.
+	Deviation float64 `hcl:"deviation,optional"`
+
+	// Heartbeat is the maximum time between broadcasts in seconds. When
+	// exceeded, the data model is published regardless of the deviation.
+	// It is required if the deviation is set and cannot be used without it.
+	Heartbeat uint32 `hcl:"heartbeat,optional"`
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
+}
+
+type configDispersion struct {
+	// MaxStdDev is the maximum standard deviation relative to the median.
+	MaxStdDev float64 `hcl:"max_stddev,optional"`
+
+	// MaxMAD is the maximum median absolute deviation relative to the median.
+	MaxMAD float64 `hcl:"max_mad,optional"`
+
+	// MaxSpread is the maximum difference between the largest and the
+	// smallest value relative to the median.
+	MaxSpread float64 `hcl:"max_spread,optional"`
+
+	// MinValidSources is the minimum number of sources with valid values.
+	MinValidSources int `hcl:"min_valid_sources,optional"`
+
+	// MinVolumeCoverage is the minimum fraction of the volume covered by
+	// sources with valid values.
+	MinVolumeCoverage float64 `hcl:"min_volume_coverage,optional"`
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
+}
+
 type Dependencies struct {
 	KeysRegistry ethereumConfig.KeyRegistry
 	DataProvider datapoint.Provider
 	Transport    transport.Service
+	Negotiator   *negotiator.Negotiator
 	Logger       log.Logger
 }
 
@@ -82,17 +142,49 @@ func (c *Config) ConfigureFeed(d Dependencies) (*feed.Feed, error) {
 			Subject:  c.Content.Attributes["ethereum_key"].Range.Ptr(),
 		}
 	}
-	hooks := []feed.Hook{
+	interval := timeutil.NewTicker(time.Second * time.Duration(c.Interval))
+	models, err := c.configureModels(interval)
+	if err != nil {
+		return nil, err
+	}
+	var hooks []feed.Hook
+	if c.Dispersion != nil {
+		d := c.Dispersion
+		if d.MaxStdDev < 0 || d.MaxMAD < 0 || d.MaxSpread < 0 || d.MinValidSources < 0 || d.MinVolumeCoverage < 0 {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   "Dispersion limits cannot be negative",
+				Subject:  d.Range.Ptr(),
+			}
+		}
+		hooks = append(hooks, feed.NewDispersionHook(feed.DispersionLimits{
+			MaxStdDev:         d.MaxStdDev,
+			MaxMAD:            d.MaxMAD,
+			MaxSpread:         d.MaxSpread,
+			MinValidSources:   d.MinValidSources,
+			MinVolumeCoverage: d.MinVolumeCoverage,
+		}))
+	}
+	hooks = append(hooks,
 		feed.NewTickPrecisionHook(tickPriceBroadcastMaxPrecision, tickVolumeBroadcastMaxPrecision),
 		feed.NewTickTraceHook(),
+	)
+	signers := []datapoint.Signer{
+		signer.NewTickSigner(ethereumKey),
+		signer.NewRateSigner(ethereumKey),
+		signer.NewBarSigner(ethereumKey),
+		signer.NewVectorSigner(ethereumKey),
 	}
 	cfg := feed.Config{
 		DataModels:   c.DataModels,
 		DataProvider: d.DataProvider,
-		Signers:      []datapoint.Signer{signer.NewTickSigner(ethereumKey)},
+		Signers:      signers,
 		Hooks:        hooks,
 		Transport:    d.Transport,
-		Interval:     timeutil.NewTicker(time.Second * time.Duration(c.Interval)),
+		Negotiator:   d.Negotiator,
+		Interval:     interval,
+		Models:       models,
 		Logger:       d.Logger,
 	}
 	feedService, err := feed.New(cfg)
@@ -107,3 +199,67 @@ func (c *Config) ConfigureFeed(d Dependencies) (*feed.Feed, error) {
 	c.feed = feedService
 	return feedService, nil
 }
+
+// configureModels returns the per-model update schedule. Models with the
+// same interval share a ticker, so they are fetched together.
+func (c *Config) configureModels(interval *timeutil.Ticker) (map[string]feed.ModelConfig, error) {
+	if len(c.Models) == 0 {
+		return nil, nil
+	}
+	tickers := map[uint32]*timeutil.Ticker{c.Interval: interval}
+	models := make(map[string]feed.ModelConfig, len(c.Models))
+	for _, m := range c.Models {
+		if _, ok := models[m.Name]; ok {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   fmt.Sprintf("Duplicate schedule for the %q data model", m.Name),
+				Subject:  m.Range.Ptr(),
+			}
+		}
+		if !sliceutil.Contains(c.DataModels, m.Name) {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   fmt.Sprintf("Data model %q is not listed in data_models", m.Name),
+				Subject:  m.Range.Ptr(),
+			}
+		}
+		if m.Deviation < 0 {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   "Deviation cannot be negative",
+				Subject:  m.Content.Attributes["deviation"].Range.Ptr(),
+			}
+		}
+		if m.Heartbeat > 0 && m.Deviation == 0 {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   "Heartbeat can be used only together with deviation",
+				Subject:  m.Content.Attributes["heartbeat"].Range.Ptr(),
+			}
+		}
+		if m.Deviation > 0 && m.Heartbeat == 0 {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   "Heartbeat is required when deviation is set",
+				Subject:  m.Content.Attributes["deviation"].Range.Ptr(),
+			}
+		}
+		mc := feed.ModelConfig{
+			Deviation: m.Deviation,
+			Heartbeat: time.Second * time.Duration(m.Heartbeat),
+		}
+		if m.Interval > 0 {
+			if _, ok := tickers[m.Interval]; !ok {
+				tickers[m.Interval] = timeutil.NewTicker(time.Second * time.Duration(m.Interval))
+			}
+			mc.Interval = tickers[m.Interval]
+		}
+		models[m.Name] = mc
+	}
+	return models, nil
+}
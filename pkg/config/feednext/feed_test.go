@@ -2,6 +2,7 @@ package feed
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -12,6 +13,7 @@ import (
 	ethereumMocks "github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/local"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/timeutil"
 )
 
 func TestConfig(t *testing.T) {
@@ -49,6 +51,52 @@ func TestConfig(t *testing.T) {
 				assert.NotNil(t, feed)
 			},
 		},
+		{
+			name: "models",
+			path: "models.hcl",
+			test: func(t *testing.T, cfg *Config) {
+				require.Len(t, cfg.Models, 2)
+				assert.Equal(t, "RETH/ETH", cfg.Models[0].Name)
+				assert.Equal(t, uint32(300), cfg.Models[0].Interval)
+				assert.Equal(t, 0.5, cfg.Models[0].Deviation)
+				assert.Equal(t, uint32(3600), cfg.Models[0].Heartbeat)
+				assert.Equal(t, "BTC/USD", cfg.Models[1].Name)
+				assert.Equal(t, uint32(0), cfg.Models[1].Interval)
+				assert.Equal(t, 1.0, cfg.Models[1].Deviation)
+				assert.Equal(t, uint32(600), cfg.Models[1].Heartbeat)
+
+				interval := timeutil.NewTicker(time.Minute)
+				models, err := cfg.configureModels(interval)
+				require.NoError(t, err)
+				require.Len(t, models, 2)
+				assert.Equal(t, 5*time.Minute, models["RETH/ETH"].Interval.Duration())
+				assert.Equal(t, time.Hour, models["RETH/ETH"].Heartbeat)
+				assert.Nil(t, models["BTC/USD"].Interval)
+			},
+		},
+		{
+			name: "models-invalid",
+			path: "models-invalid.hcl",
+			test: func(t *testing.T, cfg *Config) {
+				_, err := cfg.ConfigureFeed(Dependencies{
+					KeysRegistry: ethereum.KeyRegistry{"key": &ethereumMocks.Key{}},
+					DataProvider: graph.NewProvider(nil, nil),
+					Transport:    local.New([]byte("test"), 1, nil),
+					Logger:       null.New(),
+				})
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "not listed in data_models")
+			},
+		},
+		{
+			name: "models-no-heartbeat",
+			path: "models-no-heartbeat.hcl",
+			test: func(t *testing.T, cfg *Config) {
+				_, err := cfg.configureModels(timeutil.NewTicker(time.Minute))
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "Heartbeat is required when deviation is set")
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -0,0 +1,10 @@
+ethereum_key = "key"
+interval     = 60
+
+data_models = [
+  "ETH/USD",
+]
+
+data_model "BTC/USD" {
+  deviation = 1
+}
@@ -0,0 +1,19 @@
+ethereum_key = "key"
+interval     = 60
+
+data_models = [
+  "ETH/USD",
+  "BTC/USD",
+  "RETH/ETH",
+]
+
+data_model "RETH/ETH" {
+  interval  = 300
+  deviation = 0.5
+  heartbeat = 3600
+}
+
+data_model "BTC/USD" {
+  deviation = 1
+  heartbeat = 600
+}
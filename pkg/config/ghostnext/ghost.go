@@ -27,12 +27,17 @@ import (
 	feedConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/feednext"
 	loggerConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/logger"
 	transportConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/graph"
 	"github.com/chronicleprotocol/oracle-suite/pkg/feed"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/reloader"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/sliceutil"
 
 	pkgSupervisor "github.com/chronicleprotocol/oracle-suite/pkg/supervisor"
 	pkgTransport "github.com/chronicleprotocol/oracle-suite/pkg/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/negotiator"
 )
 
 // Config is the configuration for Ghost.
@@ -46,6 +51,9 @@ type Config struct {
 	// HCL fields:
 	Remain  hcl.Body        `hcl:",remain"` // To ignore unknown blocks.
 	Content hcl.BodyContent `hcl:",content"`
+
+	// loader is used to reload the configuration.
+	loader func(config any) error
 }
 
 func (Config) DefaultEmbeds() [][]byte {
@@ -59,6 +67,11 @@ func (Config) DefaultEmbeds() [][]byte {
 	}
 }
 
+// SetLoader implements the config.HasLoader interface.
+func (c *Config) SetLoader(load func(config any) error) {
+	c.loader = load
+}
+
 // Services returns the services configured for Lair.
 func (c *Config) Services(baseLogger log.Logger, appName string, appVersion string) (pkgSupervisor.Service, error) {
 	logger, err := c.Logger.Logger(loggerConfig.Dependencies{
@@ -77,9 +90,11 @@ func (c *Config) Services(baseLogger log.Logger, appName string, appVersion stri
 	if err != nil {
 		return nil, err
 	}
-	messageMap, err := messages.AllMessagesMap.SelectByTopic(
-		messages.DataPointV1MessageName,
-	)
+	topics := []string{messages.DataPointV1MessageName}
+	if c.Transport.Negotiation != nil {
+		topics = append(topics, messages.GreetV1MessageName)
+	}
+	messageMap, err := messages.AllMessagesMap.SelectByTopic(topics...)
 	if err != nil {
 		return nil, err
 	}
@@ -94,34 +109,95 @@ func (c *Config) Services(baseLogger log.Logger, appName string, appVersion stri
 	if err != nil {
 		return nil, err
 	}
-	dataProvider, err := c.Gofer.ConfigureDataProvider(configGoferNext.Dependencies{
+	negotiatorService, err := c.Transport.Negotiator(transportConfig.NegotiatorDependencies{
+		Keys:       keys,
+		Transport:  transport,
+		Topics:     []string{messages.DataPointV1MessageName},
+		Logger:     logger,
+		AppName:    appName,
+		AppVersion: appVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	dataProviderDeps := configGoferNext.Dependencies{
 		Clients: clients,
 		Logger:  logger,
+	}
+	dataProvider, err := c.Gofer.ConfigureReloadableDataProvider(dataProviderDeps)
+	if err != nil {
+		return nil, err
+	}
+	refresherService, err := c.Gofer.ConfigureRefresher(configGoferNext.RefresherDependencies{
+		Provider: dataProvider,
+		Logger:   logger,
 	})
 	if err != nil {
 		return nil, err
 	}
+	var reloaderService *reloader.Reloader
+	if c.loader != nil {
+		reloaderService, err = c.Gofer.ConfigureReloader(configGoferNext.ReloaderDependencies{
+			Dependencies: dataProviderDeps,
+			Provider:     dataProvider,
+			Load: func() (*configGoferNext.Config, error) {
+				var cfg Config
+				if err := c.loader(&cfg); err != nil {
+					return nil, err
+				}
+				return &cfg.Gofer, nil
+			},
+			Validate: func(ctx context.Context, p datapoint.Provider) error {
+				return validateDataModels(ctx, p, c.Ghost.DataModels)
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
 	feedService, err := c.Ghost.ConfigureFeed(feedConfig.Dependencies{
 		KeysRegistry: keys,
 		DataProvider: dataProvider,
 		Transport:    transport,
+		Negotiator:   negotiatorService,
 		Logger:       logger,
 	})
 	if err != nil {
 		return nil, err
 	}
 	return &Services{
-		Feed:      feedService,
-		Transport: transport,
-		Logger:    logger,
+		Feed:         feedService,
+		DataProvider: dataProvider,
+		Transport:    transport,
+		Negotiator:   negotiatorService,
+		Reloader:     reloaderService,
+		Refresher:    refresherService,
+		Logger:       logger,
 	}, nil
 }
 
+// validateDataModels verifies that the data provider supports all data
+// models used by the feed, so a reloaded configuration does not stop
+// broadcasting of any of them.
+func validateDataModels(ctx context.Context, p datapoint.Provider, models []string) error {
+	names := p.ModelNames(ctx)
+	for _, m := range models {
+		if !sliceutil.Contains(names, m) {
+			return fmt.Errorf("data model %s is used by the feed but is not configured", m)
+		}
+	}
+	return nil
+}
+
 // Services returns the services that are configured from the Config struct.
 type Services struct {
-	Feed      *feed.Feed
-	Transport pkgTransport.Service
-	Logger    log.Logger
+	Feed         *feed.Feed
+	DataProvider datapoint.Provider
+	Transport    pkgTransport.Service
+	Negotiator   *negotiator.Negotiator
+	Reloader     *reloader.Reloader
+	Refresher    *graph.Refresher
+	Logger       log.Logger
 
 	supervisor *pkgSupervisor.Supervisor
 }
@@ -133,6 +209,15 @@ func (s *Services) Start(ctx context.Context) error {
 	}
 	s.supervisor = pkgSupervisor.New(s.Logger)
 	s.supervisor.Watch(s.Transport, s.Feed)
+	if s.Negotiator != nil {
+		s.supervisor.Watch(s.Negotiator)
+	}
+	if s.Reloader != nil {
+		s.supervisor.Watch(s.Reloader)
+	}
+	if s.Refresher != nil {
+		s.supervisor.Watch(s.Refresher)
+	}
 	if l, ok := s.Logger.(pkgSupervisor.Service); ok {
 		s.supervisor.Watch(l)
 	}
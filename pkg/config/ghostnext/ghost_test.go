@@ -16,8 +16,10 @@
 package ghostnext
 
 import (
+	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/config"
@@ -47,3 +49,50 @@ func TestConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_Reload(t *testing.T) {
+	tests := []struct {
+		path    string
+		models  []string
+		wantErr string
+	}{
+		{
+			path:   "reload-valid.hcl",
+			models: []string{"BTC/USD", "ETH/USD"},
+		},
+		{
+			path:    "reload-missing-model.hcl",
+			models:  []string{"BTC/USD"},
+			wantErr: "data model BTC/USD is used by the feed but is not configured",
+		},
+		{
+			path:    "reload-cycle.hcl",
+			models:  []string{"BTC/USD"},
+			wantErr: "Cycle detected",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			path := "./testdata/config.hcl"
+			var cfg Config
+			cfg.SetLoader(func(c any) error {
+				return config.LoadFiles(c, []string{path})
+			})
+			require.NoError(t, config.LoadFiles(&cfg, []string{path}))
+			services, err := cfg.Services(null.New(), "", "")
+			require.NoError(t, err)
+			s := services.(*Services)
+			require.NotNil(t, s.Reloader)
+
+			path = "./testdata/" + test.path
+			err = s.Reloader.Reload(context.Background())
+			if test.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.models, s.DataProvider.ModelNames(context.Background()))
+		})
+	}
+}
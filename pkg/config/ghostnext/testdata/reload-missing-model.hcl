@@ -0,0 +1,39 @@
+ghost {
+  ethereum_key = "key1"
+  interval     = 60
+
+  data_models = [
+    "BTC/USD"
+  ]
+}
+
+gofer {
+  origin "coinbase" {
+    type = "tick_generic_jq"
+    url  = "https://api.pro.coinbase.com/products/$${ucbase}-$${ucquote}/ticker"
+    jq   = "{price: .price, time: .time, volume: .volume}"
+  }
+
+  data_model "ETH/USD" {
+    origin "coinbase" { query = "ETH/USD" }
+  }
+}
+
+ethereum {
+  rand_keys = ["key1"]
+
+  client "client1" {
+    rpc_urls     = ["https://rpc1.example"]
+    chain_id     = 1
+    ethereum_key = "key1"
+  }
+}
+
+transport {
+  libp2p {
+    feeds             = ["0x1234567890123456789012345678901234567890"]
+    listen_addrs      = ["/ip4/0.0.0.0/tcp/6000"]
+    disable_discovery = false
+    ethereum_key      = "key1"
+  }
+}
@@ -23,11 +23,15 @@ import (
 	"github.com/hashicorp/hcl/v2"
 
 	"github.com/chronicleprotocol/oracle-suite/config"
+	"github.com/chronicleprotocol/oracle-suite/pkg/cassette"
 	dataproviderConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/dataprovider"
 	ethereumConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/ethereum"
 	loggerConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/logger"
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/graph"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/origin"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/reloader"
 	pkgSupervisor "github.com/chronicleprotocol/oracle-suite/pkg/supervisor"
 )
 
@@ -40,6 +44,15 @@ type Config struct {
 	// HCL fields:
 	Remain  hcl.Body        `hcl:",remain"` // To ignore unknown blocks.
 	Content hcl.BodyContent `hcl:",content"`
+
+	// loader is used to reload the configuration.
+	loader func(config any) error
+
+	// wrapOrigin is used to wrap origins, e.g. to record or replay them.
+	wrapOrigin func(name string, o origin.Origin) origin.Origin
+
+	// cassette is used to record or replay HTTP and RPC responses.
+	cassette *cassette.Cassette
 }
 
 func (Config) DefaultEmbeds() [][]byte {
@@ -54,6 +67,8 @@ func (Config) DefaultEmbeds() [][]byte {
 // Services returns the services that are configured from the Config struct.
 type Services struct {
 	DataProvider datapoint.Provider
+	Reloader     *reloader.Reloader
+	Refresher    *graph.Refresher
 	Logger       log.Logger
 
 	supervisor *pkgSupervisor.Supervisor
@@ -68,6 +83,12 @@ func (s *Services) Start(ctx context.Context) error {
 	if p, ok := s.DataProvider.(pkgSupervisor.Service); ok {
 		s.supervisor.Watch(p)
 	}
+	if s.Reloader != nil {
+		s.supervisor.Watch(s.Reloader)
+	}
+	if s.Refresher != nil {
+		s.supervisor.Watch(s.Refresher)
+	}
 	if l, ok := s.Logger.(pkgSupervisor.Service); ok {
 		s.supervisor.Watch(l)
 	}
@@ -89,20 +110,71 @@ func (c *Config) Services(baseLogger log.Logger, appName, appVersion string) (pk
 	if err != nil {
 		return nil, err
 	}
-	clients, err := c.Ethereum.ClientRegistry(ethereumConfig.Dependencies{Logger: logger})
+	ethereumDeps := ethereumConfig.Dependencies{Logger: logger}
+	httpClient := &http.Client{}
+	if c.cassette != nil {
+		ethereumDeps.WrapTransport = c.cassette.Transport
+		httpClient.Transport = c.cassette.RoundTripper(nil)
+	}
+	clients, err := c.Ethereum.ClientRegistry(ethereumDeps)
 	if err != nil {
 		return nil, err
 	}
-	priceProvider, err := c.Gofer.ConfigureDataProvider(dataproviderConfig.Dependencies{
-		HTTPClient: &http.Client{},
+	dataProviderDeps := dataproviderConfig.Dependencies{
+		HTTPClient: httpClient,
 		Clients:    clients,
 		Logger:     logger,
+		WrapOrigin: c.wrapOrigin,
+	}
+	priceProvider, err := c.Gofer.ConfigureReloadableDataProvider(dataProviderDeps)
+	if err != nil {
+		return nil, err
+	}
+	refresherService, err := c.Gofer.ConfigureRefresher(dataproviderConfig.RefresherDependencies{
+		Provider: priceProvider,
+		Logger:   logger,
 	})
 	if err != nil {
 		return nil, err
 	}
+	var reloaderService *reloader.Reloader
+	if c.loader != nil {
+		reloaderService, err = c.Gofer.ConfigureReloader(dataproviderConfig.ReloaderDependencies{
+			Dependencies: dataProviderDeps,
+			Provider:     priceProvider,
+			Load: func() (*dataproviderConfig.Config, error) {
+				var cfg Config
+				if err := c.loader(&cfg); err != nil {
+					return nil, err
+				}
+				return &cfg.Gofer, nil
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &Services{
 		DataProvider: priceProvider,
+		Reloader:     reloaderService,
+		Refresher:    refresherService,
 		Logger:       logger,
 	}, nil
 }
+
+// SetLoader implements the config.HasLoader interface.
+func (c *Config) SetLoader(load func(config any) error) {
+	c.loader = load
+}
+
+// SetOriginWrapper sets a function that wraps origins before they are used
+// to fetch data points, e.g. to record or replay them.
+func (c *Config) SetOriginWrapper(wrap func(name string, o origin.Origin) origin.Origin) {
+	c.wrapOrigin = wrap
+}
+
+// SetCassette sets a cassette used to record or replay responses of HTTP
+// requests made by origins and of calls made by Ethereum clients.
+func (c *Config) SetCassette(cas *cassette.Cassette) {
+	c.cassette = cas
+}
@@ -33,6 +33,7 @@ import (
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/negotiator"
 )
 
 type Services struct {
@@ -42,15 +43,19 @@ type Services struct {
 }
 
 type Dependencies struct {
-	Clients   ethereumConfig.ClientRegistry
-	Transport transport.Service
-	Logger    log.Logger
+	Clients    ethereumConfig.ClientRegistry
+	Transport  transport.Service
+	Negotiator *negotiator.Negotiator // Optional.
+	Logger     log.Logger
 }
 
 type Config struct {
 	// Median is a list of Median contracts to watch.
 	Median []configMedian `hcl:"median,block"`
 
+	// Rate is a list of Rate contracts to watch.
+	Rate []configRate `hcl:"rate,block"`
+
 	// Scribe is a list of Scribe contracts to watch.
 	Scribe []configScribe `hcl:"scribe,block"`
 
@@ -103,6 +108,14 @@ type configMedian struct {
 	Feeds []types.Address `hcl:"feeds"`
 }
 
+type configRate struct {
+	configCommon
+
+	// Period is the period in seconds over which the rate applies. It must
+	// match the period of the rate data model used by feeds.
+	Period uint32 `hcl:"period"`
+}
+
 type configScribe struct {
 	configCommon
 
@@ -143,6 +156,9 @@ func (c *Config) Relay(d Dependencies) (*Services, error) {
 	for _, cfg := range c.Median {
 		medianDataModels = append(medianDataModels, cfg.DataModel)
 	}
+	for _, cfg := range c.Rate {
+		medianDataModels = append(medianDataModels, cfg.DataModel)
+	}
 	for _, cfg := range c.Scribe {
 		scribeDataModels = append(scribeDataModels, cfg.DataModel)
 	}
@@ -157,12 +173,17 @@ func (c *Config) Relay(d Dependencies) (*Services, error) {
 		}).
 		Debug("Data models")
 
-	// Create a data point store service for all median contracts.
+	// Create a data point store service for all median and rate contracts.
+	recoverers := []datapoint.Recoverer{
+		signer.NewTickRecoverer(crypto.ECRecoverer),
+		signer.NewRateRecoverer(crypto.ECRecoverer),
+	}
 	priceStoreSrv, err := datapointStore.New(datapointStore.Config{
 		Storage:    datapointStore.NewMemoryStorage(),
 		Transport:  d.Transport,
+		Negotiator: d.Negotiator,
 		Models:     dataModels,
-		Recoverers: []datapoint.Recoverer{signer.NewTickRecoverer(crypto.ECRecoverer)},
+		Recoverers: recoverers,
 		Logger:     d.Logger,
 	})
 	if err != nil {
@@ -183,6 +204,7 @@ func (c *Config) Relay(d Dependencies) (*Services, error) {
 
 	var (
 		medianCfgs   []relay.ConfigMedian
+		rateCfgs     []relay.ConfigRate
 		scribeCfgs   []relay.ConfigScribe
 		opScribeCfgs []relay.ConfigOptimisticScribe
 	)
@@ -214,6 +236,35 @@ func (c *Config) Relay(d Dependencies) (*Services, error) {
 			Ticker:          timeutil.NewTicker(time.Second * time.Duration(cfg.Interval)),
 		})
 	}
+	for _, cfg := range c.Rate {
+		client, ok := d.Clients[cfg.EthereumClient]
+		if !ok {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   fmt.Sprintf("Ethereum client %q is not configured", cfg.EthereumClient),
+				Subject:  cfg.Content.Attributes["ethereum_client"].Range.Ptr(),
+			}
+		}
+
+		logger.
+			WithField("contract", "Rate").
+			WithField("period", cfg.Period).
+			WithFields(configCommonFields(cfg.configCommon)).
+			Info("Contract")
+
+		rateCfgs = append(rateCfgs, relay.ConfigRate{
+			DataModel:       cfg.DataModel,
+			ContractAddress: cfg.ContractAddr,
+			FeedAddresses:   cfg.Feeds,
+			Client:          client,
+			DataPointStore:  priceStoreSrv,
+			Period:          time.Second * time.Duration(cfg.Period),
+			Spread:          cfg.Spread,
+			Expiration:      time.Second * time.Duration(cfg.Expiration),
+			Ticker:          timeutil.NewTicker(time.Second * time.Duration(cfg.Interval)),
+		})
+	}
 	for _, cfg := range c.Scribe {
 		client, ok := d.Clients[cfg.EthereumClient]
 		if !ok {
@@ -270,6 +321,7 @@ func (c *Config) Relay(d Dependencies) (*Services, error) {
 
 	relaySrv, err := relay.New(relay.Config{
 		Medians:           medianCfgs,
+		Rates:             rateCfgs,
 		Scribes:           scribeCfgs,
 		OptimisticScribes: opScribeCfgs,
 		Logger:            d.Logger,
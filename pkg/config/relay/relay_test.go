@@ -31,6 +31,17 @@ func TestConfig(t *testing.T) {
 					types.MustAddressFromHex("0x1122334455667788990011223344556677889900"),
 				}, cfg.Median[0].Feeds)
 
+				assert.Equal(t, "client1", cfg.Rate[0].EthereumClient)
+				assert.Equal(t, "0x4567890123456789012345678901234567890123", cfg.Rate[0].ContractAddr.String())
+				assert.Equal(t, "DSR_RATE", cfg.Rate[0].DataModel)
+				assert.Equal(t, uint32(1), cfg.Rate[0].Period)
+				assert.Equal(t, float64(1), cfg.Rate[0].Spread)
+				assert.Equal(t, uint32(3600), cfg.Rate[0].Expiration)
+				assert.Equal(t, uint32(60), cfg.Rate[0].Interval)
+				assert.Equal(t, []types.Address{
+					types.MustAddressFromHex("0x0011223344556677889900112233445566778899"),
+				}, cfg.Rate[0].Feeds)
+
 				assert.Equal(t, "client2", cfg.Scribe[0].EthereumClient)
 				assert.Equal(t, "0x2345678901234567890123456789012345678901", cfg.Scribe[0].ContractAddr.String())
 				assert.Equal(t, "BTC/USD", cfg.Scribe[0].DataModel)
@@ -12,6 +12,19 @@ median {
   ]
 }
 
+rate {
+  ethereum_client = "client1"
+  contract_addr   = "0x4567890123456789012345678901234567890123"
+  data_model      = "DSR_RATE"
+  period          = 1
+  spread          = 1
+  expiration      = 3600
+  interval        = 60
+  feeds           = [
+    "0x0011223344556677889900112233445566778899",
+  ]
+}
+
 scribe {
   ethereum_client = "client2"
   contract_addr   = "0x2345678901234567890123456789012345678901"
@@ -0,0 +1,91 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpcsplitter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+var errForensicsFileClosed = errors.New("forensics file is not open")
+
+// forensicsFile is a sink for forensics records that appends them to a file.
+//
+// The file is opened when the service is started and closed when the context
+// is canceled, so building the configuration does not touch the file.
+type forensicsFile struct {
+	mu     sync.Mutex
+	ctx    context.Context
+	waitCh chan error
+	path   string
+	file   *os.File
+}
+
+func newForensicsFile(path string) *forensicsFile {
+	return &forensicsFile{
+		waitCh: make(chan error, 1),
+		path:   path,
+	}
+}
+
+// Start implements the supervisor.Service interface.
+func (f *forensicsFile) Start(ctx context.Context) error {
+	if f.ctx != nil {
+		return errors.New("service can be started only once")
+	}
+	if ctx == nil {
+		return errors.New("context must not be nil")
+	}
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("unable to open the forensics file: %w", err)
+	}
+	f.mu.Lock()
+	f.ctx = ctx
+	f.file = file
+	f.mu.Unlock()
+	go f.contextCancelHandler()
+	return nil
+}
+
+// Wait implements the supervisor.Service interface.
+func (f *forensicsFile) Wait() <-chan error {
+	return f.waitCh
+}
+
+// Write implements the io.Writer interface. It returns an error if the file
+// is not open.
+func (f *forensicsFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return 0, errForensicsFileClosed
+	}
+	return f.file.Write(p)
+}
+
+func (f *forensicsFile) contextCancelHandler() {
+	<-f.ctx.Done()
+	f.mu.Lock()
+	err := f.file.Close()
+	f.file = nil
+	f.mu.Unlock()
+	f.waitCh <- err
+	close(f.waitCh)
+}
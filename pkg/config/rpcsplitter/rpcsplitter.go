@@ -0,0 +1,425 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpcsplitter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/chronicleprotocol/oracle-suite/config"
+	pkgConfig "github.com/chronicleprotocol/oracle-suite/pkg/config"
+	loggerConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/logger"
+	"github.com/chronicleprotocol/oracle-suite/pkg/httpserver"
+	"github.com/chronicleprotocol/oracle-suite/pkg/httpserver/middleware"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/rpcsplitter"
+	"github.com/chronicleprotocol/oracle-suite/pkg/supervisor"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/sliceutil"
+)
+
+const (
+	defaultTotalTimeout    = 10
+	defaultGracefulTimeout = 1
+	defaultHealthCheckPath = "/health"
+
+	// HTTP server timeouts, the same as in go-ethereum:
+	httpReadTimeout  = 30 * time.Second
+	httpWriteTimeout = 30 * time.Second
+	httpIdleTimeout  = 120 * time.Second
+)
+
+// Config is the configuration for the RPC-Splitter.
+type Config struct {
+	RPCSplitter ConfigRPCSplitter    `hcl:"rpc_splitter,block"`
+	Logger      *loggerConfig.Config `hcl:"logger,block,optional"`
+
+	// HCL fields:
+	Remain  hcl.Body        `hcl:",remain"` // To ignore unknown blocks.
+	Content hcl.BodyContent `hcl:",content"`
+}
+
+func (Config) DefaultEmbeds() [][]byte {
+	return [][]byte{
+		config.Defaults,
+		config.RPCSplitter,
+	}
+}
+
+// Services returns the services that are configured from the Config struct.
+type Services struct {
+	Server      *httpserver.HTTPServer
+	AdminServer *httpserver.HTTPServer
+	Logger      log.Logger
+
+	forensicsFile *forensicsFile
+	supervisor    *supervisor.Supervisor
+}
+
+// Start implements the supervisor.Service interface.
+func (s *Services) Start(ctx context.Context) error {
+	if s.supervisor != nil {
+		return fmt.Errorf("services already started")
+	}
+	s.supervisor = supervisor.New(s.Logger)
+	if s.forensicsFile != nil {
+		// The forensics file must be opened before the server starts.
+		s.supervisor.Watch(s.forensicsFile)
+	}
+	s.supervisor.Watch(s.Server)
+	if s.AdminServer != nil {
+		s.supervisor.Watch(s.AdminServer)
+	}
+	if l, ok := s.Logger.(supervisor.Service); ok {
+		s.supervisor.Watch(l)
+	}
+	return s.supervisor.Start(ctx)
+}
+
+// Wait implements the supervisor.Service interface.
+func (s *Services) Wait() <-chan error {
+	return s.supervisor.Wait()
+}
+
+// Services returns the services configured for the RPC-Splitter.
+func (c *Config) Services(baseLogger log.Logger, appName string, appVersion string) (supervisor.Service, error) {
+	logger, err := c.Logger.Logger(loggerConfig.Dependencies{
+		AppName:    appName,
+		AppVersion: appVersion,
+		BaseLogger: baseLogger,
+	})
+	if err != nil {
+		return nil, err
+	}
+	srv, err := c.RPCSplitter.Server(Dependencies{Logger: logger})
+	if err != nil {
+		return nil, err
+	}
+	adminSrv, err := c.RPCSplitter.AdminServer(Dependencies{Logger: logger})
+	if err != nil {
+		return nil, err
+	}
+	return &Services{
+		Server:        srv,
+		AdminServer:   adminSrv,
+		Logger:        logger,
+		forensicsFile: c.RPCSplitter.forensicsFile,
+	}, nil
+}
+
+type Dependencies struct {
+	Logger log.Logger
+}
+
+type ConfigRPCSplitter struct {
+	// ListenAddr is the address on which the RPC server will listen,
+	// e.g. "127.0.0.1:8545".
+	ListenAddr string `hcl:"listen_addr"`
+
+	// Endpoints is a list of RPC endpoints. Both HTTP and WebSocket
+	// endpoints are supported, but subscriptions are available only on
+	// WebSocket endpoints.
+	Endpoints []pkgConfig.URL `hcl:"endpoints"`
+
+	// MinResponses is the minimum number of responses required to resolve
+	// a response. If zero, all endpoints but one must respond, unless there
+	// is only one endpoint.
+	MinResponses int `hcl:"min_responses,optional"`
+
+	// MaxBlocksBehind is the maximum number of blocks behind the endpoint
+	// with the highest block number. Endpoints that are further behind are
+	// ignored when determining the latest block number.
+	MaxBlocksBehind int `hcl:"max_blocks_behind,optional"`
+
+	// CacheTTL is the time for which responses that depend on the chain head
+	// are cached, in seconds. If zero, the cache is disabled.
+	CacheTTL uint32 `hcl:"cache_ttl,optional"`
+
+	// CacheReorgDepth is the number of blocks after which responses for
+	// a specific block are considered immutable. Responses for more recent
+	// blocks are not cached. If zero, 64 blocks are used.
+	CacheReorgDepth uint64 `hcl:"cache_reorg_depth,optional"`
+
+	// Timeout is the total timeout for all endpoints, in seconds.
+	Timeout uint32 `hcl:"timeout,optional"`
+
+	// GracefulTimeout is the time to wait for slower endpoints, in seconds.
+	// After this time, the response is resolved if there are enough
+	// responses.
+	GracefulTimeout uint32 `hcl:"graceful_timeout,optional"`
+
+	// CORSOrigins is a list of origins allowed to make cross-origin
+	// requests. Use "*" to allow all origins. If empty, CORS headers are
+	// not sent.
+	CORSOrigins []string `hcl:"cors_origins,optional"`
+
+	// WebsocketOrigins is a list of origins from which WebSocket
+	// connections are accepted.
+	WebsocketOrigins []string `hcl:"ws_origins,optional"`
+
+	// HealthCheckPath is the path of the health check endpoint.
+	HealthCheckPath string `hcl:"health_check_path,optional"`
+
+	// AdminListenAddr is the address on which the admin API will listen.
+	// The admin API exposes statistics of the endpoints and recorded
+	// disagreements, so it should not be available publicly.
+	// If empty, the admin API is disabled.
+	AdminListenAddr string `hcl:"admin_listen_addr,optional"`
+
+	// ForensicsSize is the number of the most recent disagreements between
+	// endpoints that are kept in memory and exposed by the admin API.
+	// If zero and ForensicsFile is empty, forensics is disabled.
+	ForensicsSize int `hcl:"forensics_size,optional"`
+
+	// ForensicsFile is the path to a file to which disagreements are
+	// appended as JSON lines.
+	ForensicsFile string `hcl:"forensics_file,optional"`
+
+	// Methods is a list of options that override the default requirements
+	// and timeouts for specific methods.
+	Methods []configMethod `hcl:"method,block"`
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
+
+	// Configured services:
+	server        *httpserver.HTTPServer
+	adminServer   *httpserver.HTTPServer
+	forensicsFile *forensicsFile
+}
+
+type configMethod struct {
+	// Name is the name of the RPC method, e.g. "eth_call".
+	Name string `hcl:",label"`
+
+	// MinResponses overrides the minimum number of responses.
+	MinResponses int `hcl:"min_responses,optional"`
+
+	// Timeout overrides the total timeout, in seconds.
+	Timeout uint32 `hcl:"timeout,optional"`
+
+	// GracefulTimeout overrides the graceful timeout, in seconds.
+	GracefulTimeout uint32 `hcl:"graceful_timeout,optional"`
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
+}
+
+// Server returns the HTTP server that serves the RPC-Splitter.
+func (c *ConfigRPCSplitter) Server(d Dependencies) (*httpserver.HTTPServer, error) {
+	if c.server != nil {
+		return c.server, nil
+	}
+	if len(c.Endpoints) == 0 {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   "At least one endpoint must be configured",
+			Subject:  c.Content.Attributes["endpoints"].Range.Ptr(),
+		}
+	}
+	if c.MinResponses == 0 {
+		c.MinResponses = minimumRequiredResponses(len(c.Endpoints))
+	}
+	if c.MinResponses < 0 || c.MinResponses > len(c.Endpoints) {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   "Minimum number of responses must be between 1 and the number of endpoints",
+			Subject:  c.Content.Attributes["min_responses"].Range.Ptr(),
+		}
+	}
+	if c.MaxBlocksBehind < 0 {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   "Maximum number of blocks behind must not be negative",
+			Subject:  c.Content.Attributes["max_blocks_behind"].Range.Ptr(),
+		}
+	}
+	if c.Timeout == 0 {
+		c.Timeout = defaultTotalTimeout
+	}
+	if c.GracefulTimeout == 0 {
+		c.GracefulTimeout = defaultGracefulTimeout
+	}
+	if c.HealthCheckPath == "" {
+		c.HealthCheckPath = defaultHealthCheckPath
+	}
+	endpoints := make([]string, len(c.Endpoints))
+	for i, u := range c.Endpoints {
+		endpoints[i] = u.String()
+	}
+	opts := []rpcsplitter.Option{
+		rpcsplitter.WithEndpoints(endpoints),
+		rpcsplitter.WithRequirements(c.MinResponses, c.MaxBlocksBehind),
+		rpcsplitter.WithTotalTimeout(time.Second * time.Duration(c.Timeout)),
+		rpcsplitter.WithGracefulTimeout(time.Second * time.Duration(c.GracefulTimeout)),
+		rpcsplitter.WithWebsocketOrigins(c.WebsocketOrigins),
+		rpcsplitter.WithLogger(d.Logger),
+	}
+	if c.CacheTTL > 0 {
+		opts = append(opts, rpcsplitter.WithCache(time.Second*time.Duration(c.CacheTTL), c.CacheReorgDepth))
+	}
+	if c.ForensicsSize < 0 {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   "Forensics size must not be negative",
+			Subject:  c.Content.Attributes["forensics_size"].Range.Ptr(),
+		}
+	}
+	if c.ForensicsSize > 0 || c.ForensicsFile != "" {
+		var sink io.Writer
+		if c.ForensicsFile != "" {
+			// The file is opened when the services are started.
+			c.forensicsFile = newForensicsFile(c.ForensicsFile)
+			sink = c.forensicsFile
+		}
+		opts = append(opts, rpcsplitter.WithForensics(c.ForensicsSize, sink))
+	}
+	names := map[string]bool{}
+	for _, m := range c.Methods {
+		if names[m.Name] {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   fmt.Sprintf("Duplicate options for method %q", m.Name),
+				Subject:  m.Range.Ptr(),
+			}
+		}
+		names[m.Name] = true
+		if m.MinResponses < 0 || m.MinResponses > len(c.Endpoints) {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   "Minimum number of responses must be between 1 and the number of endpoints",
+				Subject:  m.Content.Attributes["min_responses"].Range.Ptr(),
+			}
+		}
+		opts = append(opts, rpcsplitter.WithMethodOptions(m.Name, rpcsplitter.MethodOptions{
+			MinResponses:    m.MinResponses,
+			TotalTimeout:    time.Second * time.Duration(m.Timeout),
+			GracefulTimeout: time.Second * time.Duration(m.GracefulTimeout),
+		}))
+	}
+	for _, e := range endpoints {
+		d.Logger.
+			WithField("url", e).
+			Info("RPC endpoint")
+	}
+	handler, err := rpcsplitter.NewServer(opts...)
+	if err != nil {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Runtime error",
+			Detail:   fmt.Sprintf("Failed to create RPC-Splitter: %v", err),
+			Subject:  c.Range.Ptr(),
+		}
+	}
+	srv := httpserver.New(&http.Server{
+		Addr:              c.ListenAddr,
+		Handler:           handler,
+		ReadTimeout:       httpReadTimeout,
+		ReadHeaderTimeout: httpReadTimeout,
+		WriteTimeout:      httpWriteTimeout,
+		IdleTimeout:       httpIdleTimeout,
+	})
+	// Middlewares are called in the reverse order they were added.
+	if len(c.CORSOrigins) > 0 {
+		srv.Use(&middleware.CORS{
+			Origin:  corsOrigin(c.CORSOrigins),
+			Headers: func(*http.Request) string { return "Content-Type" },
+			Methods: func(*http.Request) string { return "GET, POST, OPTIONS" },
+		})
+	}
+	srv.Use(&middleware.HealthCheck{Path: c.HealthCheckPath})
+	srv.Use(&middleware.Logger{Log: d.Logger})
+	srv.Use(&middleware.Recover{Recover: func(err any) {
+		d.Logger.
+			WithField("panic", err).
+			WithAdvice("This is a bug and needs to be investigated").
+			Error("RPC-Splitter handler panicked")
+	}})
+	if c.AdminListenAddr != "" {
+		admin, err := rpcsplitter.NewAdminHandler(handler)
+		if err != nil {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Runtime error",
+				Detail:   fmt.Sprintf("Failed to create the admin API: %v", err),
+				Subject:  c.Content.Attributes["admin_listen_addr"].Range.Ptr(),
+			}
+		}
+		adminSrv := httpserver.New(&http.Server{
+			Addr:              c.AdminListenAddr,
+			Handler:           admin,
+			ReadTimeout:       httpReadTimeout,
+			ReadHeaderTimeout: httpReadTimeout,
+			WriteTimeout:      httpWriteTimeout,
+			IdleTimeout:       httpIdleTimeout,
+		})
+		adminSrv.Use(&middleware.Recover{Recover: func(err any) {
+			d.Logger.
+				WithField("panic", err).
+				WithAdvice("This is a bug and needs to be investigated").
+				Error("Admin API handler panicked")
+		}})
+		c.adminServer = adminSrv
+	}
+	c.server = srv
+	return srv, nil
+}
+
+// AdminServer returns the HTTP server that serves the admin API, or nil if
+// the admin API is disabled.
+func (c *ConfigRPCSplitter) AdminServer(d Dependencies) (*httpserver.HTTPServer, error) {
+	if _, err := c.Server(d); err != nil {
+		return nil, err
+	}
+	return c.adminServer, nil
+}
+
+// corsOrigin returns a function that returns the value of the
+// Access-Control-Allow-Origin header for a request.
+func corsOrigin(origins []string) func(r *http.Request) string {
+	return func(r *http.Request) string {
+		if sliceutil.Contains(origins, "*") {
+			return "*"
+		}
+		origin := r.Header.Get("Origin")
+		if sliceutil.Contains(origins, origin) {
+			return origin
+		}
+		return ""
+	}
+}
+
+// minimumRequiredResponses returns the minimum number of responses required
+// to resolve a response. It is the same as in the Ethereum client
+// configuration.
+func minimumRequiredResponses(endpoints int) int {
+	if endpoints < 2 {
+		return endpoints
+	}
+	return endpoints - 1
+}
@@ -0,0 +1,147 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpcsplitter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/config"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		test    func(*testing.T, *Config)
+		wantErr string
+	}{
+		{
+			name: "valid",
+			path: "config.hcl",
+			test: func(t *testing.T, cfg *Config) {
+				c := cfg.RPCSplitter
+				assert.Equal(t, "127.0.0.1:8545", c.ListenAddr)
+				require.Len(t, c.Endpoints, 3)
+				assert.Equal(t, "https://rpc1.example", c.Endpoints[0].String())
+				assert.Equal(t, 5, c.MaxBlocksBehind)
+				assert.Equal(t, uint32(20), c.Timeout)
+				assert.Equal(t, uint32(2), c.GracefulTimeout)
+				assert.Equal(t, []string{"*"}, c.CORSOrigins)
+				assert.Equal(t, []string{"https://app.example"}, c.WebsocketOrigins)
+				assert.Equal(t, "/healthz", c.HealthCheckPath)
+				assert.Equal(t, "127.0.0.1:8546", c.AdminListenAddr)
+				assert.Equal(t, uint32(2), c.CacheTTL)
+				assert.Equal(t, uint64(12), c.CacheReorgDepth)
+				assert.Equal(t, 100, c.ForensicsSize)
+				require.Len(t, c.Methods, 2)
+				assert.Equal(t, "eth_call", c.Methods[0].Name)
+				assert.Equal(t, 3, c.Methods[0].MinResponses)
+				assert.Equal(t, uint32(30), c.Methods[0].Timeout)
+				assert.Equal(t, uint32(5), c.Methods[0].GracefulTimeout)
+				assert.Equal(t, "eth_blockNumber", c.Methods[1].Name)
+				assert.Equal(t, 1, c.Methods[1].MinResponses)
+
+				services, err := cfg.Services(null.New(), "", "")
+				require.NoError(t, err)
+				require.NotNil(t, services)
+				assert.NotNil(t, services.(*Services).AdminServer)
+
+				// Default minimum number of responses:
+				assert.Equal(t, 2, cfg.RPCSplitter.MinResponses)
+			},
+		},
+		{
+			name:    "invalid-min-responses",
+			path:    "invalid-min-responses.hcl",
+			wantErr: "Minimum number of responses",
+		},
+		{
+			name:    "duplicate-method",
+			path:    "duplicate-method.hcl",
+			wantErr: "Duplicate options for method",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var cfg Config
+			err := config.LoadFiles(&cfg, []string{"./testdata/" + test.path})
+			require.NoError(t, err)
+			if test.wantErr != "" {
+				_, err := cfg.Services(null.New(), "", "")
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+				return
+			}
+			test.test(t, &cfg)
+		})
+	}
+}
+
+func TestCORSOrigin(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Header.Set("Origin", "https://app.example")
+
+	assert.Equal(t, "*", corsOrigin([]string{"*"})(r))
+	assert.Equal(t, "https://app.example", corsOrigin([]string{"https://app.example"})(r))
+	assert.Equal(t, "", corsOrigin([]string{"https://other.example"})(r))
+}
+
+func TestMinimumRequiredResponses(t *testing.T) {
+	assert.Equal(t, 0, minimumRequiredResponses(0))
+	assert.Equal(t, 1, minimumRequiredResponses(1))
+	assert.Equal(t, 1, minimumRequiredResponses(2))
+	assert.Equal(t, 4, minimumRequiredResponses(5))
+}
+
+func TestForensicsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "forensics.jsonl")
+	f := newForensicsFile(path)
+
+	// The file must not be touched before the service is started.
+	_, err := f.Write([]byte("a\n"))
+	require.ErrorIs(t, err, errForensicsFileClosed)
+	_, err = os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, f.Start(ctx))
+	_, err = f.Write([]byte("b\n"))
+	require.NoError(t, err)
+
+	cancel()
+	select {
+	case err := <-f.Wait():
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		require.Fail(t, "service did not stop")
+	}
+	_, err = f.Write([]byte("c\n"))
+	require.ErrorIs(t, err, errForensicsFileClosed)
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "b\n", string(b))
+}
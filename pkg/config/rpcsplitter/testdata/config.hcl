@@ -0,0 +1,24 @@
+rpc_splitter {
+  listen_addr       = "127.0.0.1:8545"
+  endpoints         = ["https://rpc1.example", "https://rpc2.example", "https://rpc3.example"]
+  max_blocks_behind = 5
+  timeout           = 20
+  graceful_timeout  = 2
+  cors_origins      = ["*"]
+  ws_origins        = ["https://app.example"]
+  health_check_path = "/healthz"
+  admin_listen_addr = "127.0.0.1:8546"
+  cache_ttl         = 2
+  cache_reorg_depth = 12
+  forensics_size    = 100
+
+  method "eth_call" {
+    min_responses    = 3
+    timeout          = 30
+    graceful_timeout = 5
+  }
+
+  method "eth_blockNumber" {
+    min_responses = 1
+  }
+}
@@ -0,0 +1,12 @@
+rpc_splitter {
+  listen_addr = "127.0.0.1:8545"
+  endpoints   = ["https://rpc1.example", "https://rpc2.example"]
+
+  method "eth_call" {
+    min_responses = 1
+  }
+
+  method "eth_call" {
+    min_responses = 2
+  }
+}
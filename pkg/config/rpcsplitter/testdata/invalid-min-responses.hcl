@@ -0,0 +1,5 @@
+rpc_splitter {
+  listen_addr   = "127.0.0.1:8545"
+  endpoints     = ["https://rpc1.example", "https://rpc2.example"]
+  min_responses = 3
+}
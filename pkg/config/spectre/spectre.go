@@ -34,6 +34,7 @@ import (
 	"github.com/chronicleprotocol/oracle-suite/pkg/supervisor"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/negotiator"
 )
 
 // Config is the configuration for Spectre.
@@ -64,6 +65,7 @@ type Services struct {
 	PriceStore *datapointStore.Store
 	MuSigStore *musigStore.Store
 	Transport  transport.Service
+	Negotiator *negotiator.Negotiator
 	Logger     log.Logger
 
 	supervisor *supervisor.Supervisor
@@ -81,6 +83,9 @@ func (s *Services) Start(ctx context.Context) error {
 		s.MuSigStore,
 		s.Relay,
 	)
+	if s.Negotiator != nil {
+		s.supervisor.Watch(s.Negotiator)
+	}
 	if l, ok := s.Logger.(supervisor.Service); ok {
 		s.supervisor.Watch(l)
 	}
@@ -110,7 +115,7 @@ func (c *Config) Services(baseLogger log.Logger, appName string, appVersion stri
 	if err != nil {
 		return nil, err
 	}
-	messageMap, err := messages.AllMessagesMap.SelectByTopic(
+	topics := []string{
 		messages.PriceV0MessageName, //nolint:staticcheck
 		messages.DataPointV1MessageName,
 		messages.MuSigStartV1MessageName,
@@ -118,10 +123,14 @@ func (c *Config) Services(baseLogger log.Logger, appName string, appVersion stri
 		messages.MuSigCommitmentV1MessageName,
 		messages.MuSigPartialSignatureV1MessageName,
 		messages.MuSigSignatureV1MessageName,
-	)
+	}
+	messageMap, err := messages.AllMessagesMap.SelectByTopic(topics...)
 	if err != nil {
 		return nil, err
 	}
+	if c.Transport.Negotiation != nil {
+		messageMap[messages.GreetV1MessageName] = messages.AllMessagesMap[messages.GreetV1MessageName]
+	}
 	transportSrv, err := c.Transport.Transport(transportConfig.Dependencies{
 		Keys:       keys,
 		Clients:    clients,
@@ -133,10 +142,22 @@ func (c *Config) Services(baseLogger log.Logger, appName string, appVersion stri
 	if err != nil {
 		return nil, err
 	}
+	negotiatorService, err := c.Transport.Negotiator(transportConfig.NegotiatorDependencies{
+		Keys:       keys,
+		Transport:  transportSrv,
+		Topics:     topics,
+		Logger:     logger,
+		AppName:    appName,
+		AppVersion: appVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
 	srvs, err := c.Spectre.Relay(relayConfig.Dependencies{
-		Clients:   clients,
-		Transport: transportSrv,
-		Logger:    logger,
+		Clients:    clients,
+		Transport:  transportSrv,
+		Negotiator: negotiatorService,
+		Logger:     logger,
 	})
 	if err != nil {
 		return nil, err
@@ -146,6 +167,7 @@ func (c *Config) Services(baseLogger log.Logger, appName string, appVersion stri
 		PriceStore: srvs.PriceStore,
 		MuSigStore: srvs.MuSigStore,
 		Transport:  transportSrv,
+		Negotiator: negotiatorService,
 		Logger:     logger,
 	}, nil
 }
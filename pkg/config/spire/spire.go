@@ -36,6 +36,7 @@ import (
 	pkgSupervisor "github.com/chronicleprotocol/oracle-suite/pkg/supervisor"
 	pkgTransport "github.com/chronicleprotocol/oracle-suite/pkg/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/negotiator"
 )
 
 // Config is the configuration for Spire.
@@ -100,6 +101,7 @@ type AgentServices struct {
 	SpireAgent *spire.Agent
 	Transport  pkgTransport.Service
 	PriceStore *store.Store
+	Negotiator *negotiator.Negotiator
 	Logger     log.Logger
 
 	supervisor *pkgSupervisor.Supervisor
@@ -138,6 +140,9 @@ func (s *AgentServices) Start(ctx context.Context) error {
 	}
 	s.supervisor = pkgSupervisor.New(s.Logger)
 	s.supervisor.Watch(s.Transport, s.PriceStore, s.SpireAgent)
+	if s.Negotiator != nil {
+		s.supervisor.Watch(s.Negotiator)
+	}
 	if l, ok := s.Logger.(pkgSupervisor.Service); ok {
 		s.supervisor.Watch(l)
 	}
@@ -209,9 +214,11 @@ func (c *Config) Services(baseLogger log.Logger, appName string, appVersion stri
 	if err != nil {
 		return nil, err
 	}
-	messageMap, err := messages.AllMessagesMap.SelectByTopic(
-		messages.DataPointV1MessageName,
-	)
+	topics := []string{messages.DataPointV1MessageName}
+	if c.Transport.Negotiation != nil {
+		topics = append(topics, messages.GreetV1MessageName)
+	}
+	messageMap, err := messages.AllMessagesMap.SelectByTopic(topics...)
 	if err != nil {
 		return nil, err
 	}
@@ -226,7 +233,18 @@ func (c *Config) Services(baseLogger log.Logger, appName string, appVersion stri
 	if err != nil {
 		return nil, err
 	}
-	priceStore, err := c.Spire.PriceStore(logger, transport)
+	negotiatorService, err := c.Transport.Negotiator(transportConfig.NegotiatorDependencies{
+		Keys:       keys,
+		Transport:  transport,
+		Topics:     []string{messages.DataPointV1MessageName},
+		Logger:     logger,
+		AppName:    appName,
+		AppVersion: appVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	priceStore, err := c.Spire.PriceStore(logger, transport, negotiatorService)
 	if err != nil {
 		return nil, err
 	}
@@ -238,6 +256,7 @@ func (c *Config) Services(baseLogger log.Logger, appName string, appVersion stri
 		SpireAgent: spireAgent,
 		Transport:  transport,
 		PriceStore: priceStore,
+		Negotiator: negotiatorService,
 		Logger:     logger,
 	}, nil
 }
@@ -331,15 +350,22 @@ func (c *ConfigSpire) ConfigureClient(kr ethereumConfig.KeyRegistry) (*spire.Cli
 	return client, nil
 }
 
-func (c *ConfigSpire) PriceStore(l log.Logger, t pkgTransport.Service) (*store.Store, error) {
+func (c *ConfigSpire) PriceStore(l log.Logger, t pkgTransport.Service, n *negotiator.Negotiator) (*store.Store, error) {
 	if c.priceStore != nil {
 		return c.priceStore, nil
 	}
+	recoverers := []datapoint.Recoverer{
+		signer.NewTickRecoverer(crypto.ECRecoverer),
+		signer.NewRateRecoverer(crypto.ECRecoverer),
+		signer.NewBarRecoverer(crypto.ECRecoverer),
+		signer.NewVectorRecoverer(crypto.ECRecoverer),
+	}
 	priceStore, err := store.New(store.Config{
 		Storage:    store.NewMemoryStorage(),
 		Transport:  t,
+		Negotiator: n,
 		Models:     c.Pairs,
-		Recoverers: []datapoint.Recoverer{signer.NewTickRecoverer(crypto.ECRecoverer)},
+		Recoverers: recoverers,
 		Logger:     l,
 	})
 	if err != nil {
@@ -8,6 +8,9 @@ libp2p {
   disable_discovery  = true
   ethereum_key       = "key"
   external_addr      = "/dns/eee.example.com"
+  mdns               = true
+  address_book_path  = "/tmp/address_book.json"
+  admin_listen_addr  = "127.0.0.1:9100"
 }
 
 webapi {
@@ -25,3 +28,19 @@ webapi {
     addresses = ["https://example.com/api/v1/endpoint"]
   }
 }
+
+nats {
+  feeds            = ["0x6789012345678901234567890123456789012345"]
+  url              = "nats://token@localhost:4222"
+  subject_prefix   = "test"
+  credentials_file = "/tmp/user.creds"
+  root_cas         = ["/tmp/ca.pem"]
+  ethereum_key     = "key"
+}
+
+negotiation {
+  interval     = 30
+  peer_ttl     = 120
+  dual_publish = ["data_point/v1"]
+  ethereum_key = "key"
+}
@@ -0,0 +1,5 @@
+replay {
+  path        = "./testdata/replay.bin"
+  speed       = 2.5
+  start_delay = 5
+}
@@ -42,6 +42,7 @@ func TestConfig(t *testing.T) {
 			test: func(t *testing.T, cfg *Config) {
 				assert.NotNil(t, cfg.LibP2P)
 				assert.NotNil(t, cfg.WebAPI)
+				assert.NotNil(t, cfg.NATS)
 
 				// LibP2P
 				assert.Equal(t, "0x1234567890123456789012345678901234567890", cfg.LibP2P.Feeds[0].String())
@@ -53,6 +54,9 @@ func TestConfig(t *testing.T) {
 				assert.Equal(t, []string{"/ip4/0.0.0.0/tcp/9000"}, cfg.LibP2P.BlockedAddrs)
 				assert.Equal(t, true, cfg.LibP2P.DisableDiscovery)
 				assert.Equal(t, "key", cfg.LibP2P.EthereumKey)
+				assert.Equal(t, true, cfg.LibP2P.MDNS)
+				assert.Equal(t, "/tmp/address_book.json", cfg.LibP2P.AddressBookPath)
+				assert.Equal(t, "127.0.0.1:9100", cfg.LibP2P.AdminListenAddr)
 
 				// WebAPI
 				assert.Equal(t, "0x3456789012345678901234567890123456789012", cfg.WebAPI.Feeds[0].String())
@@ -69,6 +73,20 @@ func TestConfig(t *testing.T) {
 
 				// StaticAddressBook
 				assert.Equal(t, []string{"https://example.com/api/v1/endpoint"}, cfg.WebAPI.StaticAddressBook.Addresses)
+
+				// NATS
+				assert.Equal(t, "0x6789012345678901234567890123456789012345", cfg.NATS.Feeds[0].String())
+				assert.Equal(t, "nats://token@localhost:4222", cfg.NATS.URL)
+				assert.Equal(t, "test", cfg.NATS.SubjectPrefix)
+				assert.Equal(t, "/tmp/user.creds", cfg.NATS.CredentialsFile)
+				assert.Equal(t, []string{"/tmp/ca.pem"}, cfg.NATS.RootCAs)
+				assert.Equal(t, "key", cfg.NATS.EthereumKey)
+
+				// Negotiation
+				assert.Equal(t, uint32(30), cfg.Negotiation.Interval)
+				assert.Equal(t, uint32(120), cfg.Negotiation.PeerTTL)
+				assert.Equal(t, []string{"data_point/v1"}, cfg.Negotiation.DualPublish)
+				assert.Equal(t, "key", cfg.Negotiation.EthereumKey)
 			},
 		},
 		{
@@ -100,6 +118,38 @@ func TestConfig(t *testing.T) {
 				})
 				require.NoError(t, err)
 				assert.NotNil(t, transport)
+
+				negotiator, err := cfg.Negotiator(NegotiatorDependencies{
+					Keys:      keyRegistry,
+					Transport: transport,
+					Topics:    []string{"data_point/v1"},
+					Logger:    null.New(),
+				})
+				require.NoError(t, err)
+				assert.NotNil(t, negotiator)
+
+				// Dual published topic must be supported:
+				cfg.negotiator = nil
+				_, err = cfg.Negotiator(NegotiatorDependencies{
+					Keys:      keyRegistry,
+					Transport: transport,
+					Topics:    []string{"price/v1"},
+					Logger:    null.New(),
+				})
+				require.Error(t, err)
+			},
+		},
+		{
+			name: "replay",
+			path: "replay.hcl",
+			test: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "./testdata/replay.bin", cfg.Replay.Path)
+				assert.Equal(t, 2.5, cfg.Replay.Speed)
+				assert.Equal(t, uint32(5), cfg.Replay.StartDelay)
+
+				transport, err := cfg.Transport(Dependencies{Logger: null.New()})
+				require.NoError(t, err)
+				assert.NotNil(t, transport)
 			},
 		},
 	}
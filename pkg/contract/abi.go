@@ -34,6 +34,7 @@ var (
 	abi = goethABI.NewABI()
 
 	abiMedian      *goethABI.Contract
+	abiRate        *goethABI.Contract
 	abiScribe      *goethABI.Contract
 	abiOpScribe    *goethABI.Contract
 	abiWatRegistry *goethABI.Contract
@@ -59,6 +60,20 @@ func init() {
 		)`,
 	)
 
+	abiRate, _ = abi.ParseSignatures(
+		`read()(int256 val, uint256 period, uint256 age)`,
+		`wat()(bytes32 wat)`,
+		`bar()(uint8 bar)`,
+		`poke(
+			int256[] calldata val_,
+			uint256[] calldata age_,
+			uint256 period,
+			uint8[] calldata v,
+			bytes32[] calldata r,
+			bytes32[] calldata s
+		)`,
+	)
+
 	abiScribe, _ = abi.ParseSignatures(
 		`error StaleMessage(uint32 givenAge, uint32 currentAge)`,
 		`error FutureMessage(uint32 givenAge, uint32 currentTimestamp)`,
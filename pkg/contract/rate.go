@@ -0,0 +1,208 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package contract
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	goethABI "github.com/defiweb/go-eth/abi"
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/rpc"
+	"github.com/defiweb/go-eth/types"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/errutil"
+)
+
+const RatePrecision = 18
+
+// rateMessageType is the ABI type of the message signed for rates. The
+// message is a Keccak256 hash of the ABI encoded tuple.
+var rateMessageType = goethABI.MustParseType(
+	"(bytes32 typ,bytes32 wat,uint256 age,uint256 period,int256 rate)",
+)
+
+// RateVal is a signed rate sent to the Rate contract.
+type RateVal struct {
+	Val *bn.DecFixedPointNumber // may be negative
+	Age time.Time
+	V   uint8
+	R   *big.Int
+	S   *big.Int
+}
+
+// RateData is the current rate stored in the Rate contract.
+type RateData struct {
+	Val    *bn.DecFixedPointNumber
+	Period time.Duration
+	Age    time.Time
+}
+
+// Rate is a contract that stores the median of signed rates, e.g. the DAI
+// Savings Rate, together with the period over which the rate applies.
+//
+// It works like the Median contract, but it accepts rates signed using the
+// message returned by ConstructRatePokeMessage, so rates may be negative and
+// the period is verified by the contract.
+type Rate struct {
+	client  rpc.RPC
+	address types.Address
+}
+
+func NewRate(client rpc.RPC, address types.Address) *Rate {
+	return &Rate{
+		client:  client,
+		address: address,
+	}
+}
+
+func (r *Rate) Address() types.Address {
+	return r.address
+}
+
+func (r *Rate) Read(ctx context.Context) (RateData, error) {
+	res, _, err := r.client.Call(
+		ctx,
+		types.Call{
+			To:    &r.address,
+			Input: errutil.Must(abiRate.Methods["read"].EncodeArgs()),
+		},
+		types.LatestBlockNumber,
+	)
+	if err != nil {
+		return RateData{}, fmt.Errorf("rate: read query failed: %w", err)
+	}
+	var val, period, age *big.Int
+	if err := abiRate.Methods["read"].DecodeValues(res, &val, &period, &age); err != nil {
+		return RateData{}, fmt.Errorf("rate: read query failed: %w", err)
+	}
+	return RateData{
+		Val:    bn.DecFixedPointFromRawBigInt(val, RatePrecision),
+		Period: time.Duration(period.Int64()) * time.Second,
+		Age:    time.Unix(age.Int64(), 0),
+	}, nil
+}
+
+func (r *Rate) Wat(ctx context.Context) (string, error) {
+	res, _, err := r.client.Call(
+		ctx,
+		types.Call{
+			To:    &r.address,
+			Input: errutil.Must(abiRate.Methods["wat"].EncodeArgs()),
+		},
+		types.LatestBlockNumber,
+	)
+	if err != nil {
+		return "", fmt.Errorf("rate: wat query failed: %w", err)
+	}
+	return bytes32ToString(res), nil
+}
+
+func (r *Rate) Bar(ctx context.Context) (int, error) {
+	res, _, err := r.client.Call(
+		ctx,
+		types.Call{
+			To:    &r.address,
+			Input: errutil.Must(abiRate.Methods["bar"].EncodeArgs()),
+		},
+		types.LatestBlockNumber,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("rate: bar query failed: %w", err)
+	}
+	var bar uint8
+	if err := abiRate.Methods["bar"].DecodeValues(res, &bar); err != nil {
+		return 0, fmt.Errorf("rate: bar query failed: %w", err)
+	}
+	return int(bar), nil
+}
+
+// Poke updates the rate. All rates must be signed for the given period.
+func (r *Rate) Poke(ctx context.Context, period time.Duration, vals []RateVal) (*types.Hash, *types.Transaction, error) {
+	sort.Slice(vals, func(i, j int) bool {
+		return vals[i].Val.Cmp(vals[j].Val) < 0
+	})
+	valSlice := make([]*big.Int, len(vals))
+	ageSlice := make([]uint64, len(vals))
+	vSlice := make([]uint8, len(vals))
+	rSlice := make([]*big.Int, len(vals))
+	sSlice := make([]*big.Int, len(vals))
+	for i, v := range vals {
+		if v.Val.Prec() != RatePrecision {
+			return nil, nil, fmt.Errorf("rate: poke failed: invalid precision: %d", v.Val.Prec())
+		}
+		valSlice[i] = v.Val.RawBigInt()
+		ageSlice[i] = uint64(v.Age.Unix())
+		vSlice[i] = v.V
+		rSlice[i] = v.R
+		sSlice[i] = v.S
+	}
+	calldata, err := abiRate.Methods["poke"].EncodeArgs(
+		valSlice,
+		ageSlice,
+		uint64(period.Seconds()),
+		vSlice,
+		rSlice,
+		sSlice,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("rate: poke failed: %w", err)
+	}
+	tx := (&types.Transaction{}).
+		SetTo(r.address).
+		SetInput(calldata)
+	if err := simulateTransaction(ctx, r.client, abiRate, *tx); err != nil {
+		return nil, nil, fmt.Errorf("rate: poke failed: %w", err)
+	}
+	txHash, txCpy, err := r.client.SendTransaction(ctx, *tx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("rate: poke failed: %w", err)
+	}
+	return txHash, txCpy, nil
+}
+
+// ConstructRatePokeMessage returns the message expected to be signed via
+// ECDSA for calling Rate.poke method.
+//
+// The message structure is defined as:
+// H(abi.encode(typ, wat, age, period, rate))
+//
+// Where:
+// - typ: the "rate/v1" string as bytes32
+// - wat: an asset name
+// - age: a time when the rate was observed
+// - period: a period over which the rate applies, in seconds
+// - rate: a rate value with RatePrecision decimals, may be negative
+func ConstructRatePokeMessage(wat string, rate *bn.DecFloatPointNumber, period time.Duration, age time.Time) ([]byte, error) {
+	var typ, bytes32Wat [32]byte
+	copy(typ[:], "rate/v1")
+	copy(bytes32Wat[:], wat)
+	enc, err := goethABI.EncodeValue(rateMessageType, map[string]any{
+		"typ":    typ,
+		"wat":    bytes32Wat,
+		"age":    big.NewInt(age.Unix()),
+		"period": big.NewInt(int64(period.Seconds())),
+		"rate":   rate.DecFixedPoint(RatePrecision).RawBigInt(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode rate message: %w", err)
+	}
+	return crypto.Keccak256(enc).Bytes(), nil
+}
@@ -0,0 +1,160 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package contract
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/hexutil"
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+func TestRate_Read(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(mockRPC)
+	rate := NewRate(mockClient, types.MustAddressFromHex("0x1122344556677889900112233445566778899001"))
+
+	// Rate of -0.5 for the period of 365 days:
+	res := hexutil.MustHexToBytes(
+		"0x" +
+			"fffffffffffffffffffffffffffffffffffffffffffffffff90fa4a62c4e0000" +
+			"0000000000000000000000000000000000000000000000000000000001e13380" +
+			"0000000000000000000000000000000000000000000000000000000064e7d147",
+	)
+
+	mockClient.On(
+		"Call",
+		ctx,
+		types.Call{
+			To:    &rate.address,
+			Input: hexutil.MustHexToBytes("0x57de26a4"),
+		},
+		types.LatestBlockNumber,
+	).
+		Return(
+			res,
+			&types.Call{},
+			nil,
+		)
+
+	data, err := rate.Read(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "-0.5", data.Val.String())
+	assert.Equal(t, 365*24*time.Hour, data.Period)
+	assert.Equal(t, int64(1692913991), data.Age.Unix())
+}
+
+func TestRate_Poke(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(mockRPC)
+	rate := NewRate(mockClient, types.MustAddressFromHex("0x1122344556677889900112233445566778899001"))
+
+	vals := []RateVal{
+		{
+			Val: bn.DecFixedPoint(2, 18),
+			Age: time.Unix(1888888888, 0),
+			V:   1,
+			R:   big.NewInt(3),
+			S:   big.NewInt(5),
+		},
+		{
+			Val: bn.DecFixedPoint(-1, 18),
+			Age: time.Unix(1888888889, 0),
+			V:   2,
+			R:   big.NewInt(4),
+			S:   big.NewInt(6),
+		},
+	}
+
+	// Rates are sorted, negative rates first.
+	calldata := hexutil.MustHexToBytes(
+		"0x" +
+			"34c08d74" +
+			"00000000000000000000000000000000000000000000000000000000000000c0" +
+			"0000000000000000000000000000000000000000000000000000000000000120" +
+			"0000000000000000000000000000000000000000000000000000000000000001" +
+			"0000000000000000000000000000000000000000000000000000000000000180" +
+			"00000000000000000000000000000000000000000000000000000000000001e0" +
+			"0000000000000000000000000000000000000000000000000000000000000240" +
+			"0000000000000000000000000000000000000000000000000000000000000002" +
+			"fffffffffffffffffffffffffffffffffffffffffffffffff21f494c589c0000" +
+			"0000000000000000000000000000000000000000000000001bc16d674ec80000" +
+			"0000000000000000000000000000000000000000000000000000000000000002" +
+			"0000000000000000000000000000000000000000000000000000000070962839" +
+			"0000000000000000000000000000000000000000000000000000000070962838" +
+			"0000000000000000000000000000000000000000000000000000000000000002" +
+			"0000000000000000000000000000000000000000000000000000000000000002" +
+			"0000000000000000000000000000000000000000000000000000000000000001" +
+			"0000000000000000000000000000000000000000000000000000000000000002" +
+			"0000000000000000000000000000000000000000000000000000000000000004" +
+			"0000000000000000000000000000000000000000000000000000000000000003" +
+			"0000000000000000000000000000000000000000000000000000000000000002" +
+			"0000000000000000000000000000000000000000000000000000000000000006" +
+			"0000000000000000000000000000000000000000000000000000000000000005",
+	)
+
+	mockClient.On(
+		"Call",
+		ctx,
+		types.Call{
+			To:    &rate.address,
+			Input: calldata,
+		},
+		types.LatestBlockNumber,
+	).
+		Return(
+			[]byte{},
+			&types.Call{},
+			nil,
+		)
+
+	mockClient.On(
+		"SendTransaction",
+		ctx,
+		types.Transaction{
+			Call: types.Call{
+				To:    &rate.address,
+				Input: calldata,
+			},
+		},
+	).
+		Return(
+			&types.Hash{},
+			&types.Transaction{},
+			nil,
+		)
+
+	_, _, err := rate.Poke(ctx, time.Second, vals)
+	require.NoError(t, err)
+}
+
+func Test_ConstructRatePokeMessage(t *testing.T) {
+	msg, err := ConstructRatePokeMessage("DSR", bn.DecFloatPoint(0.05), time.Second, time.Unix(1605371361, 0))
+	require.NoError(t, err)
+	assert.Equal(t, "0x234d158df2beb52ff6efac4d64cd3abef2eeca1f719035552d055b552d3bd3e9", toEIP191(msg).String())
+
+	// The period is a part of the message.
+	msg2, err := ConstructRatePokeMessage("DSR", bn.DecFloatPoint(0.05), time.Hour, time.Unix(1605371361, 0))
+	require.NoError(t, err)
+	assert.NotEqual(t, msg, msg2)
+}
@@ -91,12 +91,24 @@ type Model struct {
 
 	// Models is a list of sub models used to calculate price.
 	Models []Model
+
+	// Hash is a deterministic hash of the model definition, including the
+	// configuration of origins used by the model. Feeds that use the same
+	// model definition produce the same hash. It is set only for top-level
+	// models and may be zero if the provider does not support hashing.
+	Hash types.Hash
 }
 
 // MarshalJSON implements the json.Marshaler interface.
 func (m Model) MarshalJSON() ([]byte, error) {
-	meta := m.Meta
+	meta := make(map[string]any, len(m.Meta)+2)
+	for k, v := range m.Meta {
+		meta[k] = v
+	}
 	meta["models"] = m.Models
+	if !m.Hash.IsZero() {
+		meta["hash"] = m.Hash
+	}
 	return json.Marshal(meta)
 }
 
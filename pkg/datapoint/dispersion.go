@@ -0,0 +1,96 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package datapoint
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DispersionMetaKey is the meta key under which aggregation nodes store
+// the Dispersion of their sources.
+const DispersionMetaKey = "dispersion"
+
+// Dispersion describes how much the values used to calculate an aggregated
+// data point, e.g. a median, agree with each other.
+//
+// Relative values are expressed as a fraction of the aggregated value, e.g.
+// a StdDev of 0.01 means that the standard deviation is 1% of the median.
+type Dispersion struct {
+	// StdDev is the relative standard deviation of valid values.
+	StdDev float64 `json:"stddev"`
+
+	// MAD is the relative median absolute deviation of valid values.
+	MAD float64 `json:"mad"`
+
+	// Spread is the relative difference between the largest and the
+	// smallest valid value.
+	Spread float64 `json:"spread"`
+
+	// Min and Max are the smallest and the largest valid values.
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+
+	// ValidSources is the number of sources that provided a valid value.
+	ValidSources int `json:"valid_sources"`
+
+	// TotalSources is the number of all sources.
+	TotalSources int `json:"total_sources"`
+
+	// VolumeCoverage is the fraction of the volume reported by all sources
+	// that is reported by sources with valid values. It is nil if sources
+	// do not report volumes.
+	VolumeCoverage *float64 `json:"volume_coverage,omitempty"`
+}
+
+// DispersionFromMeta returns the Dispersion stored in the meta of the given
+// data point. The second return value is false if the data point does not
+// contain a Dispersion.
+//
+// Data points received from other feeds store the Dispersion as a decoded
+// JSON object, which is also supported.
+func DispersionFromMeta(p Point) (Dispersion, bool) {
+	switch v := p.Meta[DispersionMetaKey].(type) {
+	case nil:
+		return Dispersion{}, false
+	case Dispersion:
+		return v, true
+	case *Dispersion:
+		return *v, v != nil
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return Dispersion{}, false
+		}
+		var d Dispersion
+		if err := json.Unmarshal(b, &d); err != nil {
+			return Dispersion{}, false
+		}
+		return d, true
+	}
+}
+
+// String returns a human-readable representation of the dispersion.
+func (d Dispersion) String() string {
+	s := fmt.Sprintf(
+		"stddev=%g mad=%g spread=%g min=%g max=%g sources=%d/%d",
+		d.StdDev, d.MAD, d.Spread, d.Min, d.Max, d.ValidSources, d.TotalSources,
+	)
+	if d.VolumeCoverage != nil {
+		s += fmt.Sprintf(" volume_coverage=%g", *d.VolumeCoverage)
+	}
+	return s
+}
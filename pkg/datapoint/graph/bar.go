@@ -0,0 +1,143 @@
+package graph
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+// BarNode is a node that builds an OHLC bar from prices sampled from its
+// node during the interval that ends at the time of the latest price.
+//
+// Prices are sampled every time the DataPoint method is called and the
+// node returns a newer price, so the bar is only as accurate as the
+// refresh interval of the graph.
+//
+// It expects one node that returns a data point with an value.Tick value.
+type BarNode struct {
+	mu sync.Mutex
+
+	pair     value.Pair
+	interval time.Duration
+	node     Node
+	samples  []barSample
+}
+
+type barSample struct {
+	time  time.Time
+	price *bn.DecFloatPointNumber
+}
+
+// NewBarNode creates a new BarNode instance.
+//
+// The interval argument is the duration of the bar.
+func NewBarNode(pair value.Pair, interval time.Duration) *BarNode {
+	return &BarNode{
+		pair:     pair,
+		interval: interval,
+	}
+}
+
+// AddNodes implements the Node interface.
+//
+// Only one node is allowed. If more than one node is added, an error is
+// returned.
+func (n *BarNode) AddNodes(nodes ...Node) error {
+	if len(nodes) == 0 {
+		return nil
+	}
+	if n.node != nil {
+		return fmt.Errorf("node is already set")
+	}
+	if len(nodes) != 1 {
+		return fmt.Errorf("only 1 node is allowed")
+	}
+	n.node = nodes[0]
+	return nil
+}
+
+// Nodes implements the Node interface.
+func (n *BarNode) Nodes() []Node {
+	if n.node == nil {
+		return nil
+	}
+	return []Node{n.node}
+}
+
+// DataPoint implements the Node interface.
+func (n *BarNode) DataPoint() datapoint.Point {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.node == nil {
+		return datapoint.Point{
+			Time:  time.Now(),
+			Meta:  n.Meta(),
+			Error: fmt.Errorf("node is not set"),
+		}
+	}
+	point := n.node.DataPoint()
+	if err := point.Validate(); err != nil {
+		return datapoint.Point{
+			Time:      time.Now(),
+			SubPoints: []datapoint.Point{point},
+			Meta:      n.Meta(),
+			Error:     err,
+		}
+	}
+	tick, ok := point.Value.(value.Tick)
+	if !ok {
+		return datapoint.Point{
+			Time:      time.Now(),
+			SubPoints: []datapoint.Point{point},
+			Meta:      n.Meta(),
+			Error:     fmt.Errorf("invalid data point value, expected value.Tick"),
+		}
+	}
+
+	// Add the price if it is newer than the last sample and remove samples
+	// that are outside the interval.
+	if len(n.samples) == 0 || point.Time.After(n.samples[len(n.samples)-1].time) {
+		n.samples = append(n.samples, barSample{time: point.Time, price: tick.Price})
+	}
+	start := n.samples[len(n.samples)-1].time.Add(-n.interval)
+	for len(n.samples) > 1 && !n.samples[0].time.After(start) {
+		n.samples = n.samples[1:]
+	}
+
+	bar := value.Bar{
+		Pair:     n.pair,
+		Open:     n.samples[0].price,
+		High:     n.samples[0].price,
+		Low:      n.samples[0].price,
+		Close:    n.samples[len(n.samples)-1].price,
+		Interval: n.interval,
+	}
+	for _, s := range n.samples[1:] {
+		if s.price.Cmp(bar.High) > 0 {
+			bar.High = s.price
+		}
+		if s.price.Cmp(bar.Low) < 0 {
+			bar.Low = s.price
+		}
+	}
+	return datapoint.Point{
+		Value:     bar,
+		Time:      n.samples[len(n.samples)-1].time,
+		SubPoints: []datapoint.Point{point},
+		Meta:      n.Meta(),
+	}
+}
+
+// Meta implements the Node interface.
+func (n *BarNode) Meta() map[string]any {
+	return map[string]any{
+		"type":     "bar",
+		"pair":     n.pair,
+		"interval": n.interval,
+	}
+}
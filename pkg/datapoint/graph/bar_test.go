@@ -0,0 +1,74 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+)
+
+func TestBarNode_DataPoint(t *testing.T) {
+	pair := value.Pair{Base: "BTC", Quote: "USD"}
+	now := time.Now()
+	prices := []struct {
+		time  time.Time
+		price float64
+		want  [4]string // open, high, low, close
+	}{
+		{time: now, price: 100, want: [4]string{"100", "100", "100", "100"}},
+		{time: now.Add(time.Minute), price: 120, want: [4]string{"100", "120", "100", "120"}},
+		{time: now.Add(time.Minute), price: 120, want: [4]string{"100", "120", "100", "120"}}, // Same time, not sampled.
+		{time: now.Add(2 * time.Minute), price: 90, want: [4]string{"100", "120", "90", "90"}},
+		{time: now.Add(3 * time.Minute), price: 110, want: [4]string{"120", "120", "90", "110"}}, // First sample expired.
+	}
+	mockNode := new(mockNode)
+	node := NewBarNode(pair, 3*time.Minute)
+	require.NoError(t, node.AddNodes(mockNode))
+	for _, p := range prices {
+		mockNode.ExpectedCalls = nil
+		mockNode.On("DataPoint").Return(datapoint.Point{
+			Value: value.NewTick(pair, p.price, 0),
+			Time:  p.time,
+		})
+		point := node.DataPoint()
+		require.NoError(t, point.Validate())
+		bar := point.Value.(value.Bar)
+		assert.Equal(t, pair, bar.Pair)
+		assert.Equal(t, p.want[0], bar.Open.String())
+		assert.Equal(t, p.want[1], bar.High.String())
+		assert.Equal(t, p.want[2], bar.Low.String())
+		assert.Equal(t, p.want[3], bar.Close.String())
+		assert.Equal(t, 3*time.Minute, bar.Interval)
+		assert.Equal(t, p.time, point.Time)
+	}
+}
+
+func TestBarNode_DataPoint_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		point datapoint.Point
+	}{
+		{
+			name:  "invalid data point",
+			point: datapoint.Point{Error: errors.New("error")},
+		},
+		{
+			name:  "non tick value",
+			point: datapoint.Point{Value: value.NewRate(1, 0), Time: time.Now()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNode := new(mockNode)
+			mockNode.On("DataPoint").Return(tt.point)
+			node := NewBarNode(value.Pair{Base: "BTC", Quote: "USD"}, time.Minute)
+			require.NoError(t, node.AddNodes(mockNode))
+			assert.Error(t, node.DataPoint().Validate())
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"math"
+	"sort"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+)
+
+// tickDispersion calculates dispersion statistics of prices from the given
+// data points. Only valid data points with value.Tick values are used to
+// calculate statistics, but all data points are counted as sources.
+//
+// Relative statistics are calculated against the median of valid prices.
+// If the median is zero, relative statistics are left at zero.
+func tickDispersion(points []datapoint.Point) datapoint.Dispersion {
+	var (
+		prices      []float64
+		validVolume float64
+		totalVolume float64
+	)
+	for _, point := range points {
+		tick, ok := point.Value.(value.Tick)
+		if !ok {
+			continue
+		}
+		var volume float64
+		if tick.Volume24h != nil {
+			volume, _ = tick.Volume24h.BigFloat().Float64()
+		}
+		totalVolume += volume
+		if point.Validate() != nil {
+			continue
+		}
+		price, _ := tick.Price.BigFloat().Float64()
+		prices = append(prices, price)
+		validVolume += volume
+	}
+
+	d := datapoint.Dispersion{
+		ValidSources: len(prices),
+		TotalSources: len(points),
+	}
+	if totalVolume > 0 {
+		coverage := validVolume / totalVolume
+		d.VolumeCoverage = &coverage
+	}
+	if len(prices) == 0 {
+		return d
+	}
+
+	sort.Float64s(prices)
+	center := medianFloat(prices)
+	d.Min = prices[0]
+	d.Max = prices[len(prices)-1]
+	if center == 0 {
+		return d
+	}
+
+	var (
+		mean       float64
+		variance   float64
+		deviations = make([]float64, len(prices))
+	)
+	for _, p := range prices {
+		mean += p
+	}
+	mean /= float64(len(prices))
+	for i, p := range prices {
+		variance += (p - mean) * (p - mean)
+		deviations[i] = math.Abs(p - center)
+	}
+	variance /= float64(len(prices))
+	sort.Float64s(deviations)
+
+	d.StdDev = math.Sqrt(variance) / math.Abs(center)
+	d.MAD = medianFloat(deviations) / math.Abs(center)
+	d.Spread = (d.Max - d.Min) / math.Abs(center)
+	return d
+}
+
+// medianFloat returns the median of sorted values.
+func medianFloat(xs []float64) float64 {
+	n := len(xs)
+	if n%2 == 0 {
+		return (xs[n/2-1] + xs[n/2]) / 2
+	}
+	return xs[n/2]
+}
@@ -0,0 +1,50 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+)
+
+func TestTickMedianNode_Dispersion(t *testing.T) {
+	pair := value.Pair{Base: "A", Quote: "B"}
+	points := []datapoint.Point{
+		{Value: value.NewTick(pair, 98, 10), Time: time.Now()},
+		{Value: value.NewTick(pair, 100, 20), Time: time.Now()},
+		{Value: value.NewTick(pair, 102, 30), Time: time.Now()},
+		{Value: value.NewTick(pair, 150, 40), Time: time.Now(), Error: errors.New("stale")},
+	}
+	n := NewTickMedianNode(1)
+	for _, p := range points {
+		node := new(mockNode)
+		node.On("DataPoint").Return(p)
+		require.NoError(t, n.AddNodes(node))
+	}
+
+	point := n.DataPoint()
+	require.NoError(t, point.Validate())
+	d, ok := datapoint.DispersionFromMeta(point)
+	require.True(t, ok)
+	assert.Equal(t, 3, d.ValidSources)
+	assert.Equal(t, 4, d.TotalSources)
+	assert.Equal(t, 98.0, d.Min)
+	assert.Equal(t, 102.0, d.Max)
+	assert.InDelta(t, 0.04, d.Spread, 1e-9)
+	assert.InDelta(t, 0.02, d.MAD, 1e-9)
+	assert.InDelta(t, 0.0163299, d.StdDev, 1e-6)
+	require.NotNil(t, d.VolumeCoverage)
+	assert.InDelta(t, 0.6, *d.VolumeCoverage, 1e-9)
+}
+
+func TestTickDispersion_NoValidValues(t *testing.T) {
+	d := tickDispersion([]datapoint.Point{{Error: errors.New("error")}})
+	assert.Equal(t, 0, d.ValidSources)
+	assert.Equal(t, 1, d.TotalSources)
+	assert.Nil(t, d.VolumeCoverage)
+}
@@ -0,0 +1,335 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+// expr is an arithmetic expression that can be evaluated using values of
+// named variables.
+type expr interface {
+	eval(vars map[string]*bn.DecFloatPointNumber) (*bn.DecFloatPointNumber, error)
+	String() string
+}
+
+type (
+	exprNumber struct {
+		text string
+		x    *bn.DecFloatPointNumber
+	}
+	exprVar struct {
+		name string
+	}
+	exprNeg struct {
+		x expr
+	}
+	exprBinary struct {
+		op   byte
+		x, y expr
+	}
+	exprCall struct {
+		fn   string
+		args []expr
+	}
+)
+
+// exprFuncs is a list of functions that can be used in expressions along
+// with their minimum and maximum number of arguments. A negative maximum
+// means that the number of arguments is not limited.
+var exprFuncs = map[string][2]int{
+	"min": {1, -1},
+	"max": {1, -1},
+	"abs": {1, 1},
+}
+
+func (e exprNumber) eval(map[string]*bn.DecFloatPointNumber) (*bn.DecFloatPointNumber, error) {
+	return e.x, nil
+}
+
+func (e exprNumber) String() string {
+	return e.text
+}
+
+func (e exprVar) eval(vars map[string]*bn.DecFloatPointNumber) (*bn.DecFloatPointNumber, error) {
+	x, ok := vars[e.name]
+	if !ok {
+		return nil, fmt.Errorf("unknown variable: %s", e.name)
+	}
+	return x, nil
+}
+
+func (e exprVar) String() string {
+	return e.name
+}
+
+func (e exprNeg) eval(vars map[string]*bn.DecFloatPointNumber) (*bn.DecFloatPointNumber, error) {
+	x, err := e.x.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	return x.Neg(), nil
+}
+
+func (e exprNeg) String() string {
+	return "-" + e.x.String()
+}
+
+func (e exprBinary) eval(vars map[string]*bn.DecFloatPointNumber) (*bn.DecFloatPointNumber, error) {
+	x, err := e.x.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	y, err := e.y.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	switch e.op {
+	case '+':
+		return x.Add(y), nil
+	case '-':
+		return x.Sub(y), nil
+	case '*':
+		return x.Mul(y), nil
+	case '/':
+		if y.Sign() == 0 {
+			return nil, fmt.Errorf("division by zero in %s", e)
+		}
+		return x.Div(y), nil
+	}
+	return nil, fmt.Errorf("unknown operator: %c", e.op)
+}
+
+func (e exprBinary) String() string {
+	return fmt.Sprintf("(%s %c %s)", e.x, e.op, e.y)
+}
+
+func (e exprCall) eval(vars map[string]*bn.DecFloatPointNumber) (*bn.DecFloatPointNumber, error) {
+	args := make([]*bn.DecFloatPointNumber, len(e.args))
+	for i, arg := range e.args {
+		x, err := arg.eval(vars)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = x
+	}
+	switch e.fn {
+	case "min", "max":
+		r := args[0]
+		for _, x := range args[1:] {
+			if (e.fn == "min" && x.Cmp(r) < 0) || (e.fn == "max" && x.Cmp(r) > 0) {
+				r = x
+			}
+		}
+		return r, nil
+	case "abs":
+		return args[0].Abs(), nil
+	}
+	return nil, fmt.Errorf("unknown function: %s", e.fn)
+}
+
+func (e exprCall) String() string {
+	args := make([]string, len(e.args))
+	for i, arg := range e.args {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("%s(%s)", e.fn, strings.Join(args, ", "))
+}
+
+// exprVars returns the names of all variables used in the expression.
+func exprVars(e expr) []string {
+	var vars []string
+	var collect func(expr)
+	collect = func(e expr) {
+		switch e := e.(type) {
+		case exprVar:
+			vars = appendIfUnique(vars, e.name)
+		case exprNeg:
+			collect(e.x)
+		case exprBinary:
+			collect(e.x)
+			collect(e.y)
+		case exprCall:
+			for _, arg := range e.args {
+				collect(arg)
+			}
+		}
+	}
+	collect(e)
+	return vars
+}
+
+// parseExpr parses an arithmetic expression.
+//
+// Supported are decimal numbers, variables, the +, -, *, / operators with
+// the usual precedence, parentheses and the min, max and abs functions.
+func parseExpr(s string) (expr, error) {
+	p := &exprParser{s: s}
+	e, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.s) {
+		return nil, p.errorf("unexpected character %q", p.s[p.pos])
+	}
+	return e, nil
+}
+
+type exprParser struct {
+	s   string
+	pos int
+}
+
+func (p *exprParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid expression %q at position %d: %s", p.s, p.pos+1, fmt.Sprintf(format, args...))
+}
+
+func (p *exprParser) skipSpaces() {
+	for p.pos < len(p.s) && unicode.IsSpace(rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+// next returns the next non-space character without consuming it, or 0
+// at the end of the input.
+func (p *exprParser) next() byte {
+	p.skipSpaces()
+	if p.pos < len(p.s) {
+		return p.s[p.pos]
+	}
+	return 0
+}
+
+func (p *exprParser) parseSum() (expr, error) {
+	x, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.next()
+		if op != '+' && op != '-' {
+			return x, nil
+		}
+		p.pos++
+		y, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		x = exprBinary{op: op, x: x, y: y}
+	}
+}
+
+func (p *exprParser) parseProduct() (expr, error) {
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.next()
+		if op != '*' && op != '/' {
+			return x, nil
+		}
+		p.pos++
+		y, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		x = exprBinary{op: op, x: x, y: y}
+	}
+}
+
+func (p *exprParser) parseUnary() (expr, error) {
+	if p.next() == '-' {
+		p.pos++
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return exprNeg{x: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (expr, error) {
+	c := p.next()
+	switch {
+	case c == 0:
+		return nil, p.errorf("unexpected end of expression")
+	case c == '(':
+		p.pos++
+		x, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ')' {
+			return nil, p.errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return x, nil
+	case c == '.' || isDigit(c):
+		start := p.pos
+		for p.pos < len(p.s) && (p.s[p.pos] == '.' || isDigit(p.s[p.pos])) {
+			p.pos++
+		}
+		text := p.s[start:p.pos]
+		x := bn.DecFloatPoint(text)
+		if x == nil {
+			p.pos = start
+			return nil, p.errorf("invalid number %q", text)
+		}
+		return exprNumber{text: text, x: x}, nil
+	case isIdentStart(c):
+		start := p.pos
+		for p.pos < len(p.s) && isIdent(p.s[p.pos]) {
+			p.pos++
+		}
+		name := p.s[start:p.pos]
+		if p.next() != '(' {
+			return exprVar{name: name}, nil
+		}
+		argsRange, ok := exprFuncs[name]
+		if !ok {
+			p.pos = start
+			return nil, p.errorf("unknown function %q", name)
+		}
+		p.pos++
+		var args []expr
+		if p.next() != ')' {
+			for {
+				arg, err := p.parseSum()
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, arg)
+				if p.next() != ',' {
+					break
+				}
+				p.pos++
+			}
+		}
+		if p.next() != ')' {
+			return nil, p.errorf("missing closing parenthesis")
+		}
+		p.pos++
+		if len(args) < argsRange[0] || (argsRange[1] >= 0 && len(args) > argsRange[1]) {
+			return nil, p.errorf("invalid number of arguments for function %s", name)
+		}
+		return exprCall{fn: name, args: args}, nil
+	}
+	return nil, p.errorf("unexpected character %q", c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdent(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
@@ -143,6 +143,13 @@ func (n *OriginNode) Meta() map[string]any {
 	}
 }
 
+// updatedAt returns the time of the current data point.
+func (n *OriginNode) updatedAt() time.Time {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.dataPoint.Time
+}
+
 func (n *OriginNode) isFresh() bool {
 	return n.dataPoint.Time.Add(n.freshnessThreshold).After(time.Now())
 }
@@ -2,8 +2,13 @@ package graph
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
+	"time"
+
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/maputil"
@@ -20,8 +25,14 @@ func (e ErrModelNotFound) Error() string {
 // Provider is a data provider which uses a graph structure to provide data
 // points.
 type Provider struct {
-	models  map[string]Node
-	updater *Updater
+	models     map[string]Node
+	updater    *Updater
+	background bool
+	rateLimits map[string]time.Duration
+
+	// originHashes are hashes of origin configurations keyed by the origin
+	// name. They are used to calculate model hashes.
+	originHashes map[string]types.Hash
 }
 
 // NewProvider creates a new price data.
@@ -37,6 +48,37 @@ func NewProvider(models map[string]Node, updater *Updater) Provider {
 	}
 }
 
+// NewBackgroundProvider creates a new data provider which data points are
+// updated in the background by a Refresher instead of on every request.
+//
+// The DataPoint and DataPoints methods do not wait for origins, they return
+// the most recent data points instead. The only exception are origin nodes
+// that have never been updated, e.g. right after the application starts or
+// when the Refresher is not running, these are updated before returning the
+// data point.
+//
+// The updater is used by the Refresher to update the origin nodes. The
+// rateLimits map contains the minimum duration between consecutive fetches
+// made by the Refresher, keyed by the origin name. Origins that are not
+// listed are not rate limited.
+func NewBackgroundProvider(models map[string]Node, updater *Updater, rateLimits map[string]time.Duration) Provider {
+	return Provider{
+		models:     models,
+		updater:    updater,
+		background: true,
+		rateLimits: rateLimits,
+	}
+}
+
+// WithOriginHashes returns a copy of the provider that includes the given
+// hashes of origin configurations, keyed by the origin name, in the model
+// hashes. This way, the model hash changes when the configuration of any
+// origin used by the model changes.
+func (p Provider) WithOriginHashes(hashes map[string]types.Hash) Provider {
+	p.originHashes = hashes
+	return p
+}
+
 // ModelNames implements the data.Provider interface.
 func (p Provider) ModelNames(_ context.Context) []string {
 	return maputil.SortKeys(p.models, sort.Strings)
@@ -48,9 +90,7 @@ func (p Provider) DataPoint(ctx context.Context, model string) (datapoint.Point,
 	if !ok {
 		return datapoint.Point{}, ErrModelNotFound{model: model}
 	}
-	if p.updater != nil {
-		p.updater.Update(ctx, []Node{node})
-	}
+	p.update(ctx, []Node{node})
 	return node.DataPoint(), nil
 }
 
@@ -64,9 +104,7 @@ func (p Provider) DataPoints(ctx context.Context, models ...string) (map[string]
 		}
 		nodes[i] = node
 	}
-	if p.updater != nil {
-		p.updater.Update(ctx, nodes)
-	}
+	p.update(ctx, nodes)
 	points := make(map[string]datapoint.Point, len(models))
 	for i, model := range models {
 		points[model] = nodes[i].DataPoint()
@@ -80,7 +118,9 @@ func (p Provider) Model(_ context.Context, model string) (datapoint.Model, error
 	if !ok {
 		return datapoint.Model{}, ErrModelNotFound{model: model}
 	}
-	return nodeToModel(node), nil
+	m := nodeToModel(node)
+	m.Hash = p.modelHash(node)
+	return m, nil
 }
 
 // Models implements the data.Provider interface.
@@ -95,11 +135,49 @@ func (p Provider) Models(_ context.Context, models ...string) (map[string]datapo
 	}
 	modelsMap := make(map[string]datapoint.Model, len(models))
 	for i, model := range models {
-		modelsMap[model] = nodeToModel(nodes[i])
+		m := nodeToModel(nodes[i])
+		m.Hash = p.modelHash(nodes[i])
+		modelsMap[model] = m
 	}
 	return modelsMap, nil
 }
 
+// Refresh updates all origin nodes of all data models regardless of whether
+// they are fresh or not.
+func (p Provider) Refresh(ctx context.Context) {
+	if p.updater == nil {
+		return
+	}
+	var nodes []*OriginNode
+	Walk(func(n Node) {
+		if originNode, ok := n.(*OriginNode); ok {
+			nodes = append(nodes, originNode)
+		}
+	}, p.graphs()...)
+	p.updater.Refresh(ctx, nodes)
+}
+
+// update updates the origin nodes in the given graphs before they are read.
+func (p Provider) update(ctx context.Context, graphs []Node) {
+	switch {
+	case p.updater == nil:
+		return
+	case p.background:
+		p.updater.updateUninitialized(ctx, graphs)
+	default:
+		p.updater.Update(ctx, graphs)
+	}
+}
+
+// graphs returns the graphs of all data models.
+func (p Provider) graphs() []Node {
+	graphs := make([]Node, 0, len(p.models))
+	for _, node := range p.models {
+		graphs = append(graphs, node)
+	}
+	return graphs
+}
+
 func nodeToModel(n Node) datapoint.Model {
 	m := datapoint.Model{}
 	m.Meta = n.Meta()
@@ -111,3 +189,36 @@ func nodeToModel(n Node) datapoint.Model {
 	}
 	return m
 }
+
+// modelHash calculates a deterministic hash of the model graph. The hash is
+// calculated from the meta information of all nodes in the graph and the
+// hashes of the origin configurations.
+func (p Provider) modelHash(n Node) types.Hash {
+	b, err := json.Marshal(p.hashableNode(n))
+	if err != nil {
+		// Meta values must be marshalable to JSON, so it should never happen.
+		return types.Hash{}
+	}
+	return crypto.Keccak256(b)
+}
+
+// hashableNode returns a representation of the node graph used to
+// calculate the model hash. Maps are marshaled to JSON with sorted keys,
+// which makes the representation deterministic.
+func (p Provider) hashableNode(n Node) map[string]any {
+	meta := make(map[string]any)
+	for k, v := range n.Meta() {
+		meta[k] = v
+	}
+	if originNode, ok := n.(*OriginNode); ok {
+		if hash, ok := p.originHashes[originNode.origin]; ok {
+			meta["origin_hash"] = hash
+		}
+	}
+	nodes := make([]any, 0, len(n.Nodes()))
+	for _, n := range n.Nodes() {
+		nodes = append(nodes, p.hashableNode(n))
+	}
+	meta["nodes"] = nodes
+	return meta
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/defiweb/go-eth/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -87,3 +88,26 @@ func TestProvider_Models(t *testing.T) {
 	_, err := prov.Models(context.Background(), "model_a", "model_b")
 	require.NoError(t, err)
 }
+
+func TestProvider_ModelHash(t *testing.T) {
+	prov := newTestProvider()
+	models, err := prov.Models(context.Background(), "model_a", "model_b")
+	require.NoError(t, err)
+
+	// Hash must be deterministic and depend on the model definition.
+	modelA, err := prov.Model(context.Background(), "model_a")
+	require.NoError(t, err)
+	assert.False(t, modelA.Hash.IsZero())
+	assert.Equal(t, modelA.Hash, models["model_a"].Hash)
+	assert.NotEqual(t, models["model_a"].Hash, models["model_b"].Hash)
+
+	// Hash must depend on the origin configuration.
+	prov1 := prov.WithOriginHashes(map[string]types.Hash{"test": types.MustHashFromHex("0x01", types.PadLeft)})
+	prov2 := prov.WithOriginHashes(map[string]types.Hash{"test": types.MustHashFromHex("0x02", types.PadLeft)})
+	modelA1, err := prov1.Model(context.Background(), "model_a")
+	require.NoError(t, err)
+	modelA2, err := prov2.Model(context.Background(), "model_a")
+	require.NoError(t, err)
+	assert.NotEqual(t, modelA.Hash, modelA1.Hash)
+	assert.NotEqual(t, modelA1.Hash, modelA2.Hash)
+}
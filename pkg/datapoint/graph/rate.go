@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+// RateNode is a node that converts a numeric value into a rate over
+// the given period. E.g. it may be used to publish the DAI Savings Rate
+// returned by the DSR origin as a value.Rate.
+//
+// It expects one node that returns a data point with a value.NumericValue
+// value.
+type RateNode struct {
+	period time.Duration
+	node   Node
+}
+
+// NewRateNode creates a new RateNode instance.
+//
+// The period argument is the period over which the rate applies. Zero means
+// that the period is not specified.
+func NewRateNode(period time.Duration) *RateNode {
+	return &RateNode{period: period}
+}
+
+// AddNodes implements the Node interface.
+//
+// Only one node is allowed. If more than one node is added, an error is
+// returned.
+func (n *RateNode) AddNodes(nodes ...Node) error {
+	if len(nodes) == 0 {
+		return nil
+	}
+	if n.node != nil {
+		return fmt.Errorf("node is already set")
+	}
+	if len(nodes) != 1 {
+		return fmt.Errorf("only 1 node is allowed")
+	}
+	n.node = nodes[0]
+	return nil
+}
+
+// Nodes implements the Node interface.
+func (n *RateNode) Nodes() []Node {
+	if n.node == nil {
+		return nil
+	}
+	return []Node{n.node}
+}
+
+// DataPoint implements the Node interface.
+func (n *RateNode) DataPoint() datapoint.Point {
+	if n.node == nil {
+		return datapoint.Point{
+			Time:  time.Now(),
+			Meta:  n.Meta(),
+			Error: fmt.Errorf("node is not set"),
+		}
+	}
+	point := n.node.DataPoint()
+	if err := point.Validate(); err != nil {
+		return datapoint.Point{
+			Time:      time.Now(),
+			SubPoints: []datapoint.Point{point},
+			Meta:      n.Meta(),
+			Error:     err,
+		}
+	}
+	num, ok := point.Value.(value.NumericValue)
+	if !ok {
+		return datapoint.Point{
+			Time:      time.Now(),
+			SubPoints: []datapoint.Point{point},
+			Meta:      n.Meta(),
+			Error:     fmt.Errorf("invalid data point value, expected value.NumericValue"),
+		}
+	}
+	return datapoint.Point{
+		Value:     value.Rate{Rate: bn.DecFloatPoint(num.Number()), Period: n.period},
+		Time:      point.Time,
+		SubPoints: []datapoint.Point{point},
+		Meta:      n.Meta(),
+	}
+}
+
+// Meta implements the Node interface.
+func (n *RateNode) Meta() map[string]any {
+	return map[string]any{
+		"type":   "rate",
+		"period": n.period,
+	}
+}
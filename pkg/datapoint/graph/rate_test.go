@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+func TestRateNode_DataPoint(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		point   datapoint.Point
+		want    value.Rate
+		wantErr bool
+	}{
+		{
+			name:  "tick",
+			point: datapoint.Point{Value: value.NewTick(value.Pair{Base: "DSR", Quote: "RATE"}, 1.05, 0), Time: now},
+			want:  value.NewRate(1.05, time.Second),
+		},
+		{
+			name:  "static value",
+			point: datapoint.Point{Value: value.StaticValue{Value: bn.DecFloatPoint(-0.01)}, Time: now},
+			want:  value.NewRate(-0.01, time.Second),
+		},
+		{
+			name:    "non numeric value",
+			point:   datapoint.Point{Value: value.Vector{Values: []*bn.DecFloatPointNumber{bn.DecFloatPoint(1)}}, Time: now},
+			wantErr: true,
+		},
+		{
+			name:    "invalid data point",
+			point:   datapoint.Point{Error: errors.New("error")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNode := new(mockNode)
+			mockNode.On("DataPoint").Return(tt.point)
+			node := NewRateNode(time.Second)
+			require.NoError(t, node.AddNodes(mockNode))
+			point := node.DataPoint()
+			if tt.wantErr {
+				assert.Error(t, point.Validate())
+				return
+			}
+			require.NoError(t, point.Validate())
+			rate := point.Value.(value.Rate)
+			assert.Equal(t, tt.want.Rate.String(), rate.Rate.String())
+			assert.Equal(t, tt.want.Period, rate.Period)
+		})
+	}
+}
+
+func TestRateNode_AddNodes(t *testing.T) {
+	node := NewRateNode(time.Second)
+	require.NoError(t, node.AddNodes(new(mockNode)))
+	assert.Len(t, node.Nodes(), 1)
+	assert.Error(t, node.AddNodes(new(mockNode)))
+}
@@ -0,0 +1,282 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+)
+
+const RefresherLoggerTag = "GRAPH_REFRESHER"
+
+// defaultRefresherInterval is the default interval at which the Refresher
+// checks which origin nodes need to be refreshed.
+const defaultRefresherInterval = time.Second
+
+// maxRefresherBackoff is the maximum delay before the refresh of an origin
+// node that could not be updated is retried.
+const maxRefresherBackoff = time.Minute
+
+// RefresherConfig is the configuration for the Refresher.
+type RefresherConfig struct {
+	// Provider returns the provider which origin nodes are refreshed.
+	// It is called on every check, so the provider may be replaced while
+	// the Refresher is running, e.g. by using ReloadableProvider.Provider.
+	Provider func() Provider
+
+	// Lead is the duration before the freshness threshold of an origin node
+	// lapses at which the node is refreshed.
+	Lead time.Duration
+
+	// Jitter is the maximum random duration by which the refresh of an
+	// origin node is brought forward. It prevents all nodes that were
+	// updated at the same time from being refreshed at the same time.
+	Jitter time.Duration
+
+	// Interval is the interval at which the Refresher checks which origin
+	// nodes need to be refreshed. If zero, one second is used.
+	Interval time.Duration
+
+	// Logger is a current logger interface used by the Refresher.
+	// If nil, null logger will be used.
+	Logger log.Logger
+}
+
+// Refresher is a service that updates origin nodes in the background,
+// shortly before their freshness threshold lapses, so that data points
+// can be read without waiting for origins.
+//
+// It should be used together with a provider created by the
+// NewBackgroundProvider function. The rate limits of the provider are
+// respected, nodes of the same origin that become due within the rate
+// limit are fetched in a single batch.
+type Refresher struct {
+	mu     sync.Mutex
+	ctx    context.Context
+	waitCh chan error
+
+	provider func() Provider
+	lead     time.Duration
+	jitter   time.Duration
+	interval time.Duration
+	rand     *rand.Rand
+	log      log.Logger
+
+	// schedule contains the time at which each origin node should be
+	// refreshed.
+	schedule map[*OriginNode]time.Time
+
+	// lastFetch contains the time of the last fetch from each origin.
+	lastFetch map[string]time.Time
+
+	// inFlight contains origins which are currently being fetched.
+	inFlight map[string]bool
+
+	// failures contains the number of consecutive refreshes after which
+	// an origin node did not have a valid and fresh data point.
+	failures map[*OriginNode]int
+}
+
+// NewRefresher creates a new Refresher instance.
+func NewRefresher(cfg RefresherConfig) (*Refresher, error) {
+	if cfg.Provider == nil {
+		return nil, errors.New("provider must not be nil")
+	}
+	if cfg.Lead < 0 {
+		return nil, errors.New("lead must not be negative")
+	}
+	if cfg.Jitter < 0 {
+		return nil, errors.New("jitter must not be negative")
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = defaultRefresherInterval
+	}
+	if cfg.Interval < 0 {
+		return nil, errors.New("interval must not be negative")
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = null.New()
+	}
+	return &Refresher{
+		waitCh:    make(chan error),
+		provider:  cfg.Provider,
+		lead:      cfg.Lead,
+		jitter:    cfg.Jitter,
+		interval:  cfg.Interval,
+		rand:      rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec
+		log:       cfg.Logger.WithField("tag", RefresherLoggerTag),
+		schedule:  make(map[*OriginNode]time.Time),
+		lastFetch: make(map[string]time.Time),
+		inFlight:  make(map[string]bool),
+		failures:  make(map[*OriginNode]int),
+	}, nil
+}
+
+// Start implements the supervisor.Service interface.
+func (r *Refresher) Start(ctx context.Context) error {
+	if r.ctx != nil {
+		return errors.New("service can be started only once")
+	}
+	if ctx == nil {
+		return errors.New("context must not be nil")
+	}
+	r.log.Debug("Starting")
+	r.ctx = ctx
+	go r.refreshRoutine()
+	return nil
+}
+
+// Wait implements the supervisor.Service interface.
+func (r *Refresher) Wait() <-chan error {
+	return r.waitCh
+}
+
+// refresh refreshes origin nodes that are due and whose origins are not
+// rate limited. Nodes of the same origin are fetched in a single batch.
+func (r *Refresher) refresh(ctx context.Context, wg *sync.WaitGroup) {
+	p := r.provider()
+	if p.updater == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Group all origin nodes by origin and schedule nodes that are seen
+	// for the first time. Nodes that are no longer present in the graphs,
+	// e.g. after the provider has been replaced, are removed from the
+	// schedule.
+	now := time.Now()
+	nodes := make(map[string][]*OriginNode)
+	seen := make(map[*OriginNode]bool)
+	Walk(func(n Node) {
+		if originNode, ok := n.(*OriginNode); ok {
+			if seen[originNode] {
+				return
+			}
+			seen[originNode] = true
+			if _, ok := r.schedule[originNode]; !ok {
+				r.schedule[originNode] = r.refreshTime(originNode, now)
+			}
+			nodes[originNode.Origin()] = append(nodes[originNode.Origin()], originNode)
+		}
+	}, p.graphs()...)
+	for node := range r.schedule {
+		if !seen[node] {
+			delete(r.schedule, node)
+			delete(r.failures, node)
+		}
+	}
+
+	for origin, originNodes := range nodes {
+		if r.inFlight[origin] {
+			continue
+		}
+		rateLimit := p.rateLimits[origin]
+		if now.Before(r.lastFetch[origin].Add(rateLimit)) {
+			continue
+		}
+
+		// If at least one node is due, nodes that would become due before
+		// the next fetch is allowed by the rate limit are fetched as well,
+		// so they do not have to wait for it.
+		var due bool
+		var batch []*OriginNode
+		for _, node := range originNodes {
+			at := r.schedule[node]
+			if !now.Before(at) {
+				due = true
+			}
+			if !at.After(now.Add(rateLimit)) {
+				batch = append(batch, node)
+			}
+		}
+		if !due {
+			continue
+		}
+
+		r.inFlight[origin] = true
+		r.lastFetch[origin] = now
+		wg.Add(1)
+		go func(origin string, batch []*OriginNode) {
+			defer wg.Done()
+			p.updater.Refresh(ctx, batch)
+
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			now := time.Now()
+			for _, node := range batch {
+				if _, ok := r.schedule[node]; !ok {
+					continue
+				}
+				if node.DataPoint().Validate() == nil && node.IsFresh() {
+					delete(r.failures, node)
+					r.schedule[node] = r.refreshTime(node, now)
+					continue
+				}
+				r.failures[node]++
+				r.schedule[node] = now.Add(r.backoff(r.failures[node]))
+			}
+			r.inFlight[origin] = false
+		}(origin, batch)
+	}
+}
+
+// refreshTime returns the time at which the given node should be refreshed.
+// If the data point of the node is already stale, the node is refreshed
+// after the freshness threshold instead of immediately.
+func (r *Refresher) refreshTime(node *OriginNode, now time.Time) time.Time {
+	updatedAt := node.updatedAt()
+	if updatedAt.IsZero() {
+		// The node has never been updated.
+		return now
+	}
+	at := updatedAt.Add(node.freshnessThreshold)
+	if !at.After(now) {
+		at = now.Add(node.freshnessThreshold)
+	}
+	at = at.Add(-r.lead)
+	if r.jitter > 0 {
+		at = at.Add(-time.Duration(r.rand.Int63n(int64(r.jitter))))
+	}
+	return at
+}
+
+// backoff returns the delay before the refresh of a node that was not
+// updated after the given number of consecutive attempts is retried, e.g.
+// because the origin returned an error. The delay starts at the check
+// interval and doubles with every failure, up to maxRefresherBackoff.
+func (r *Refresher) backoff(failures int) time.Duration {
+	d := r.interval
+	for i := 1; i < failures && d < maxRefresherBackoff; i++ {
+		d *= 2
+	}
+	if d > maxRefresherBackoff {
+		d = maxRefresherBackoff
+	}
+	return d
+}
+
+func (r *Refresher) refreshRoutine() {
+	wg := &sync.WaitGroup{}
+	defer func() {
+		wg.Wait()
+		r.log.Debug("Stopped")
+		close(r.waitCh)
+	}()
+	t := time.NewTicker(r.interval)
+	defer t.Stop()
+	r.refresh(r.ctx, wg)
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-t.C:
+			r.refresh(r.ctx, wg)
+		}
+	}
+}
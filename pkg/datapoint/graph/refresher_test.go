@@ -0,0 +1,177 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/origin"
+)
+
+func countingOrigin(count *int32) *mockOrigin {
+	return &mockOrigin{
+		fetchDataPoints: func(_ context.Context, query []any) (map[any]datapoint.Point, error) {
+			atomic.AddInt32(count, 1)
+			points := make(map[any]datapoint.Point, len(query))
+			for _, q := range query {
+				points[q] = datapoint.Point{
+					Value: stringValue(q.(string)),
+					Time:  time.Now(),
+				}
+			}
+			return points, nil
+		},
+	}
+}
+
+func TestRefresher(t *testing.T) {
+	t.Run("refresh before freshness lapses", func(t *testing.T) {
+		var count int32
+		node := NewOriginNode("origin", "query", 200*time.Millisecond, time.Second)
+		p := NewBackgroundProvider(
+			map[string]Node{"model": node},
+			NewUpdater(map[string]origin.Origin{"origin": countingOrigin(&count)}, nil),
+			nil,
+		)
+
+		// The first read initializes the node.
+		point, err := p.DataPoint(context.Background(), "model")
+		require.NoError(t, err)
+		require.NoError(t, point.Validate())
+		assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+
+		// Subsequent reads must not fetch data, even if the data point is
+		// no longer fresh.
+		time.Sleep(250 * time.Millisecond)
+		_, err = p.DataPoint(context.Background(), "model")
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+		assert.False(t, node.IsFresh())
+
+		ctx, ctxCancel := context.WithCancel(context.Background())
+		defer ctxCancel()
+		r, err := NewRefresher(RefresherConfig{
+			Provider: func() Provider { return p },
+			Lead:     100 * time.Millisecond,
+			Interval: 10 * time.Millisecond,
+		})
+		require.NoError(t, err)
+		require.NoError(t, r.Start(ctx))
+
+		// Stale node must be refreshed immediately.
+		require.Eventually(t, node.IsFresh, time.Second, 5*time.Millisecond)
+
+		// Then, the node must be refreshed before it stops being fresh.
+		for i := 0; i < 50; i++ {
+			assert.True(t, node.IsFresh())
+			time.Sleep(10 * time.Millisecond)
+		}
+		assert.Greater(t, atomic.LoadInt32(&count), int32(3))
+
+		ctxCancel()
+		<-r.Wait()
+	})
+	t.Run("rate limit", func(t *testing.T) {
+		var count int32
+		p := NewBackgroundProvider(
+			map[string]Node{
+				"a": NewOriginNode("origin", "query_a", 20*time.Millisecond, time.Second),
+				"b": NewOriginNode("origin", "query_b", 20*time.Millisecond, time.Second),
+			},
+			NewUpdater(map[string]origin.Origin{"origin": countingOrigin(&count)}, nil),
+			map[string]time.Duration{"origin": time.Hour},
+		)
+
+		ctx, ctxCancel := context.WithCancel(context.Background())
+		defer ctxCancel()
+		r, err := NewRefresher(RefresherConfig{
+			Provider: func() Provider { return p },
+			Interval: 5 * time.Millisecond,
+		})
+		require.NoError(t, err)
+		require.NoError(t, r.Start(ctx))
+
+		time.Sleep(200 * time.Millisecond)
+		ctxCancel()
+		<-r.Wait()
+
+		// Both nodes must be fetched in a single request and not refreshed
+		// again until the rate limit allows it.
+		assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+		points, err := p.DataPoints(context.Background(), "a", "b")
+		require.NoError(t, err)
+		assert.Equal(t, "query_a", points["a"].Value.Print())
+		assert.Equal(t, "query_b", points["b"].Value.Print())
+	})
+	t.Run("backoff after failures", func(t *testing.T) {
+		var count int32
+		failing := &mockOrigin{
+			fetchDataPoints: func(_ context.Context, query []any) (map[any]datapoint.Point, error) {
+				atomic.AddInt32(&count, 1)
+				return nil, errors.New("origin error")
+			},
+		}
+		p := NewBackgroundProvider(
+			map[string]Node{"model": NewOriginNode("origin", "query", time.Second, time.Minute)},
+			NewUpdater(map[string]origin.Origin{"origin": failing}, nil),
+			nil,
+		)
+
+		ctx, ctxCancel := context.WithCancel(context.Background())
+		defer ctxCancel()
+		r, err := NewRefresher(RefresherConfig{
+			Provider: func() Provider { return p },
+			Interval: 5 * time.Millisecond,
+		})
+		require.NoError(t, err)
+		require.NoError(t, r.Start(ctx))
+
+		time.Sleep(200 * time.Millisecond)
+		ctxCancel()
+		<-r.Wait()
+
+		// Retries are delayed by 5, 10, 20, 40, 80ms, so the origin must
+		// not be queried on every check.
+		assert.GreaterOrEqual(t, atomic.LoadInt32(&count), int32(3))
+		assert.LessOrEqual(t, atomic.LoadInt32(&count), int32(8))
+	})
+	t.Run("replaced provider", func(t *testing.T) {
+		var count int32
+		updater := NewUpdater(map[string]origin.Origin{"origin": countingOrigin(&count)}, nil)
+		nodeA := NewOriginNode("origin", "query_a", time.Minute, time.Minute)
+		nodeB := NewOriginNode("origin", "query_b", time.Minute, time.Minute)
+		reloadable := NewReloadableProvider(NewBackgroundProvider(map[string]Node{"a": nodeA}, updater, nil))
+
+		ctx, ctxCancel := context.WithCancel(context.Background())
+		defer ctxCancel()
+		r, err := NewRefresher(RefresherConfig{
+			Provider: reloadable.Provider,
+			Interval: 5 * time.Millisecond,
+		})
+		require.NoError(t, err)
+		require.NoError(t, r.Start(ctx))
+
+		require.Eventually(t, nodeA.IsFresh, time.Second, 5*time.Millisecond)
+		reloadable.Swap(NewBackgroundProvider(map[string]Node{"b": nodeB}, updater, nil))
+		require.Eventually(t, nodeB.IsFresh, time.Second, 5*time.Millisecond)
+	})
+	t.Run("start errors", func(t *testing.T) {
+		r, err := NewRefresher(RefresherConfig{Provider: func() Provider { return Provider{} }})
+		require.NoError(t, err)
+		assert.Error(t, r.Start(nil)) //nolint:staticcheck
+		require.NoError(t, r.Start(context.Background()))
+		assert.Error(t, r.Start(context.Background()))
+	})
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := NewRefresher(RefresherConfig{})
+		assert.Error(t, err)
+		_, err = NewRefresher(RefresherConfig{Provider: func() Provider { return Provider{} }, Jitter: -1})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,58 @@
+package graph
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+)
+
+// ReloadableProvider is a data provider which underlying Provider can be
+// replaced while it is in use, e.g. after the configuration is reloaded.
+type ReloadableProvider struct {
+	p atomic.Pointer[Provider]
+}
+
+// NewReloadableProvider creates a new reloadable provider that initially
+// uses the given provider.
+func NewReloadableProvider(p Provider) *ReloadableProvider {
+	r := &ReloadableProvider{}
+	r.p.Store(&p)
+	return r
+}
+
+// Swap atomically replaces the underlying provider. Calls that are in
+// progress are completed using the previous provider.
+func (r *ReloadableProvider) Swap(p Provider) {
+	r.p.Store(&p)
+}
+
+// Provider returns the current underlying provider.
+func (r *ReloadableProvider) Provider() Provider {
+	return *r.p.Load()
+}
+
+// ModelNames implements the data.Provider interface.
+func (r *ReloadableProvider) ModelNames(ctx context.Context) []string {
+	return r.Provider().ModelNames(ctx)
+}
+
+// DataPoint implements the data.Provider interface.
+func (r *ReloadableProvider) DataPoint(ctx context.Context, model string) (datapoint.Point, error) {
+	return r.Provider().DataPoint(ctx, model)
+}
+
+// DataPoints implements the data.Provider interface.
+func (r *ReloadableProvider) DataPoints(ctx context.Context, models ...string) (map[string]datapoint.Point, error) {
+	return r.Provider().DataPoints(ctx, models...)
+}
+
+// Model implements the data.Provider interface.
+func (r *ReloadableProvider) Model(ctx context.Context, model string) (datapoint.Model, error) {
+	return r.Provider().Model(ctx, model)
+}
+
+// Models implements the data.Provider interface.
+func (r *ReloadableProvider) Models(ctx context.Context, models ...string) (map[string]datapoint.Model, error) {
+	return r.Provider().Models(ctx, models...)
+}
@@ -0,0 +1,26 @@
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadableProvider(t *testing.T) {
+	ctx := context.Background()
+	prov := NewReloadableProvider(NewProvider(nil, nil))
+	assert.Empty(t, prov.ModelNames(ctx))
+	_, err := prov.DataPoint(ctx, "model_a")
+	assert.Error(t, err)
+
+	prov.Swap(newTestProvider())
+	assert.Equal(t, []string{"model_a", "model_b"}, prov.ModelNames(ctx))
+	point, err := prov.DataPoint(ctx, "model_a")
+	require.NoError(t, err)
+	assert.Equal(t, stringValue("query_a"), point.Value)
+	model, err := prov.Model(ctx, "model_b")
+	require.NoError(t, err)
+	assert.Equal(t, "origin", model.Meta["type"])
+}
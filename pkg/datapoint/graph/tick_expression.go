@@ -0,0 +1,151 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/sliceutil"
+)
+
+// TickExpressionNode is a node that calculates a price using an arithmetic
+// expression over the values of its nodes, e.g. "a * b / c", "min(a, b)"
+// or "1 / a".
+//
+// Each node is assigned a variable name, in the order the nodes are added.
+// Nodes must return data points with value.Tick or value.StaticValue values.
+// The price of a tick or the static value is used as the variable value.
+type TickExpressionNode struct {
+	pair    value.Pair
+	formula string
+	expr    expr
+	names   []string
+	nodes   []Node
+}
+
+// NewTickExpressionNode creates a new TickExpressionNode instance.
+//
+// The pair argument is the pair of the calculated tick. The formula is an
+// arithmetic expression that may use the +, -, *, / operators, parentheses,
+// decimal numbers, the min, max and abs functions and the variables listed
+// in the names argument.
+func NewTickExpressionNode(pair value.Pair, formula string, names []string) (*TickExpressionNode, error) {
+	e, err := parseExpr(formula)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range exprVars(e) {
+		if !sliceutil.Contains(names, v) {
+			return nil, fmt.Errorf("unknown variable %q in expression %q", v, formula)
+		}
+	}
+	for i, name := range names {
+		if sliceutil.Contains(names[:i], name) {
+			return nil, fmt.Errorf("duplicated variable %q", name)
+		}
+	}
+	return &TickExpressionNode{
+		pair:    pair,
+		formula: formula,
+		expr:    e,
+		names:   names,
+	}, nil
+}
+
+// AddNodes implements the Node interface.
+//
+// The number of nodes must not exceed the number of variable names.
+func (n *TickExpressionNode) AddNodes(nodes ...Node) error {
+	if len(n.nodes)+len(nodes) > len(n.names) {
+		return fmt.Errorf("expression node accepts at most %d nodes", len(n.names))
+	}
+	n.nodes = append(n.nodes, nodes...)
+	return nil
+}
+
+// Nodes implements the Node interface.
+func (n *TickExpressionNode) Nodes() []Node {
+	return n.nodes
+}
+
+// DataPoint implements the Node interface.
+func (n *TickExpressionNode) DataPoint() datapoint.Point {
+	if len(n.nodes) != len(n.names) {
+		return datapoint.Point{
+			Time:  time.Now(),
+			Meta:  n.Meta(),
+			Error: fmt.Errorf("expected %d nodes, got %d", len(n.names), len(n.nodes)),
+		}
+	}
+	var (
+		tm     time.Time
+		points = make([]datapoint.Point, len(n.nodes))
+		vars   = make(map[string]*bn.DecFloatPointNumber, len(n.nodes))
+	)
+	for i, node := range n.nodes {
+		point := node.DataPoint()
+		points[i] = point
+		if tm.IsZero() || point.Time.Before(tm) {
+			tm = point.Time
+		}
+		if err := point.Validate(); err != nil {
+			return datapoint.Point{
+				Time:      time.Now(),
+				SubPoints: points[:i+1],
+				Meta:      n.Meta(),
+				Error:     fmt.Errorf("invalid data point for variable %s: %w", n.names[i], err),
+			}
+		}
+		var x *bn.DecFloatPointNumber
+		switch v := point.Value.(type) {
+		case value.Tick:
+			x = v.Price
+		case value.StaticValue:
+			x = v.Value
+		}
+		if x == nil {
+			return datapoint.Point{
+				Time:      time.Now(),
+				SubPoints: points[:i+1],
+				Meta:      n.Meta(),
+				Error:     fmt.Errorf("invalid data point value type for variable %s: %T", n.names[i], point.Value),
+			}
+		}
+		vars[n.names[i]] = x
+	}
+	meta := n.Meta()
+	values := make([]string, len(n.names))
+	for i, name := range n.names {
+		values[i] = fmt.Sprintf("%s=%s", name, vars[name].String())
+	}
+	meta["values"] = strings.Join(values, ", ")
+	price, err := n.expr.eval(vars)
+	if err != nil {
+		return datapoint.Point{
+			Time:      time.Now(),
+			SubPoints: points,
+			Meta:      meta,
+			Error:     err,
+		}
+	}
+	return datapoint.Point{
+		Value:     value.NewTick(n.pair, price, 0),
+		Time:      tm,
+		SubPoints: points,
+		Meta:      meta,
+	}
+}
+
+// Meta implements the Node interface.
+func (n *TickExpressionNode) Meta() map[string]any {
+	return map[string]any{
+		"type":      "expression",
+		"pair":      n.pair,
+		"formula":   n.formula,
+		"variables": strings.Join(n.names, ", "),
+	}
+}
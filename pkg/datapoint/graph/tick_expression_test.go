@@ -0,0 +1,123 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+func TestParseExpr(t *testing.T) {
+	vars := map[string]*bn.DecFloatPointNumber{
+		"a": bn.DecFloatPoint(2),
+		"b": bn.DecFloatPoint(3),
+		"c": bn.DecFloatPoint(4),
+	}
+	tests := []struct {
+		formula string
+		want    string
+		wantErr bool
+	}{
+		{formula: "a * b / c", want: "1.5"},
+		{formula: "a + b * c", want: "14"},
+		{formula: "(a + b) * c", want: "20"},
+		{formula: "1/a", want: "0.5"},
+		{formula: "-a + 1.5", want: "-0.5"},
+		{formula: "min(a, b, c)", want: "2"},
+		{formula: "max(a,b)", want: "3"},
+		{formula: "abs(a - c)", want: "2"},
+		{formula: "a / (b - 3)", wantErr: true},
+		{formula: "a +", wantErr: true},
+		{formula: "(a + b", wantErr: true},
+		{formula: "pow(a, b)", wantErr: true},
+		{formula: "abs(a, b)", wantErr: true},
+		{formula: "a $ b", wantErr: true},
+		{formula: "1.2.3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.formula, func(t *testing.T) {
+			e, err := parseExpr(tt.formula)
+			if err == nil {
+				var x *bn.DecFloatPointNumber
+				x, err = e.eval(vars)
+				if err == nil {
+					assert.Equal(t, tt.want, x.String())
+				}
+			}
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTickExpressionNode(t *testing.T) {
+	pair := value.Pair{Base: "WSTETH", Quote: "USD"}
+	t1 := time.Now()
+	t2 := t1.Add(-time.Minute)
+
+	n1 := new(mockNode)
+	n1.On("DataPoint").Return(datapoint.Point{
+		Value: value.NewTick(value.Pair{Base: "WSTETH", Quote: "STETH"}, 1.1, 0),
+		Time:  t1,
+	})
+	n2 := new(mockNode)
+	n2.On("DataPoint").Return(datapoint.Point{
+		Value: value.StaticValue{Value: bn.DecFloatPoint(2000)},
+		Time:  t2,
+	})
+	n3 := new(mockNode)
+	n3.On("DataPoint").Return(datapoint.Point{
+		Time:  t1,
+		Error: errors.New("origin error"),
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		n, err := NewTickExpressionNode(pair, "a * b", []string{"a", "b"})
+		require.NoError(t, err)
+		require.NoError(t, n.AddNodes(n1, n2))
+
+		point := n.DataPoint()
+		require.NoError(t, point.Validate())
+		assert.Equal(t, pair, point.Value.(value.Tick).Pair)
+		assert.Equal(t, "2200", point.Value.(value.Tick).Price.String())
+		assert.Equal(t, t2, point.Time)
+		assert.Len(t, point.SubPoints, 2)
+		assert.Equal(t, "a * b", point.Meta["formula"])
+
+		trace, err := point.MarshalTrace()
+		require.NoError(t, err)
+		assert.Contains(t, string(trace), "a * b")
+	})
+	t.Run("sub point error", func(t *testing.T) {
+		n, err := NewTickExpressionNode(pair, "a * b", []string{"a", "b"})
+		require.NoError(t, err)
+		require.NoError(t, n.AddNodes(n1, n3))
+
+		point := n.DataPoint()
+		assert.ErrorContains(t, point.Validate(), "origin error")
+	})
+	t.Run("missing node", func(t *testing.T) {
+		n, err := NewTickExpressionNode(pair, "a * b", []string{"a", "b"})
+		require.NoError(t, err)
+		require.NoError(t, n.AddNodes(n1))
+		assert.Error(t, n.DataPoint().Validate())
+		assert.Error(t, n.AddNodes(n2, n3))
+	})
+	t.Run("unknown variable", func(t *testing.T) {
+		_, err := NewTickExpressionNode(pair, "a * c", []string{"a", "b"})
+		assert.Error(t, err)
+	})
+	t.Run("duplicated variable", func(t *testing.T) {
+		_, err := NewTickExpressionNode(pair, "a", []string{"a", "a"})
+		assert.Error(t, err)
+	})
+}
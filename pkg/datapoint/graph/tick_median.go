@@ -98,12 +98,16 @@ func (n *TickMedianNode) DataPoint() datapoint.Point {
 		prices = append(prices, tick.Price)
 	}
 
+	// Attach dispersion statistics of the values to the meta.
+	meta := n.Meta()
+	meta[datapoint.DispersionMetaKey] = tickDispersion(points)
+
 	// Verify that we have enough valid values to calculate median.
 	if len(ticks) < n.min {
 		return datapoint.Point{
 			Time:      time.Now(),
 			SubPoints: points,
-			Meta:      n.Meta(),
+			Meta:      meta,
 			Error:     fmt.Errorf("not enough values to calculate median, want %d, got %d", n.min, len(ticks)),
 		}
 	}
@@ -113,7 +117,7 @@ func (n *TickMedianNode) DataPoint() datapoint.Point {
 		Value:     value.NewTick(ticks[0].Pair, median(prices), 0),
 		Time:      tm,
 		SubPoints: points,
-		Meta:      n.Meta(),
+		Meta:      meta,
 	}
 }
 
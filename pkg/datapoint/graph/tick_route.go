@@ -0,0 +1,169 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+)
+
+// RouteEdge is a pair provided by an origin that can be used as a single
+// hop of a route.
+type RouteEdge struct {
+	Origin string
+	Pair   value.Pair
+}
+
+// String returns a string representation of the edge.
+func (e RouteEdge) String() string {
+	return fmt.Sprintf("%s:%s", e.Origin, e.Pair)
+}
+
+// FindRoutes returns all routes from the base to the quote asset of the
+// given pair that consist of at most maxHops edges.
+//
+// Edges may be used in either direction, but no asset is visited more than
+// once in a single route. Routes are sorted by the number of hops and then
+// by the order of edges, so the result is deterministic.
+func FindRoutes(pair value.Pair, edges []RouteEdge, maxHops int) [][]RouteEdge {
+	edges = append([]RouteEdge(nil), edges...)
+	sort.SliceStable(edges, func(i, j int) bool {
+		return edges[i].String() < edges[j].String()
+	})
+
+	// Index edges by asset.
+	byAsset := make(map[string][]RouteEdge)
+	for _, e := range edges {
+		byAsset[e.Pair.Base] = append(byAsset[e.Pair.Base], e)
+		if e.Pair.Quote != e.Pair.Base {
+			byAsset[e.Pair.Quote] = append(byAsset[e.Pair.Quote], e)
+		}
+	}
+
+	var (
+		routes  [][]RouteEdge
+		route   []RouteEdge
+		visited = map[string]bool{pair.Base: true}
+	)
+	var find func(asset string)
+	find = func(asset string) {
+		if asset == pair.Quote {
+			routes = append(routes, append([]RouteEdge(nil), route...))
+			return
+		}
+		if len(route) == maxHops {
+			return
+		}
+		for _, e := range byAsset[asset] {
+			next := e.Pair.Quote
+			if next == asset {
+				next = e.Pair.Base
+			}
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+			route = append(route, e)
+			find(next)
+			route = route[:len(route)-1]
+			visited[next] = false
+		}
+	}
+	if pair.Base != pair.Quote {
+		find(pair.Base)
+	}
+
+	sort.SliceStable(routes, func(i, j int) bool {
+		return len(routes[i]) < len(routes[j])
+	})
+	return routes
+}
+
+// TickRouteNode is a node that calculates the median price of a pair from
+// multiple routes. Each connected node represents a single route, usually
+// a TickIndirectNode that calculates a cross rate along the route.
+//
+// It expects that all nodes return data points with value.Tick values for
+// the pair of the node.
+type TickRouteNode struct {
+	pair  value.Pair
+	min   int
+	nodes []Node
+}
+
+// NewTickRouteNode creates a new TickRouteNode instance.
+//
+// The min argument is a minimum number of valid prices obtained from
+// routes required to calculate median price.
+func NewTickRouteNode(pair value.Pair, min int) *TickRouteNode {
+	return &TickRouteNode{
+		pair: pair,
+		min:  min,
+	}
+}
+
+// AddNodes implements the Node interface.
+func (n *TickRouteNode) AddNodes(nodes ...Node) error {
+	n.nodes = append(n.nodes, nodes...)
+	return nil
+}
+
+// Nodes implements the Node interface.
+func (n *TickRouteNode) Nodes() []Node {
+	return n.nodes
+}
+
+// DataPoint implements the Node interface.
+func (n *TickRouteNode) DataPoint() datapoint.Point {
+	point := (&TickMedianNode{min: n.min, nodes: n.nodes}).DataPoint()
+	meta := n.Meta()
+	meta[datapoint.DispersionMetaKey] = point.Meta[datapoint.DispersionMetaKey]
+	point.Meta = meta
+	if point.Validate() != nil {
+		return point
+	}
+	if tick, ok := point.Value.(value.Tick); ok && !tick.Pair.Equal(n.pair) {
+		return datapoint.Point{
+			Time:      time.Now(),
+			SubPoints: point.SubPoints,
+			Meta:      meta,
+			Error:     fmt.Errorf("invalid data point value, expected value.Tick for pair %s", n.pair),
+		}
+	}
+	return point
+}
+
+// Meta implements the Node interface.
+func (n *TickRouteNode) Meta() map[string]any {
+	paths := make([]string, len(n.nodes))
+	for i, node := range n.nodes {
+		paths[i] = routePath(node)
+	}
+	return map[string]any{
+		"type":       "route",
+		"pair":       n.pair,
+		"min_values": n.min,
+		"paths":      paths,
+	}
+}
+
+// routePath returns a string representation of a route as a list of origin
+// queries used by the route.
+func routePath(node Node) string {
+	var hops []string
+	var collect func(Node)
+	collect = func(node Node) {
+		if o, ok := node.(*OriginNode); ok {
+			hops = append(hops, fmt.Sprintf("%s:%v", o.Origin(), o.Query()))
+			return
+		}
+		for _, n := range node.Nodes() {
+			collect(n)
+		}
+	}
+	collect(node)
+	return strings.Join(hops, " -> ")
+}
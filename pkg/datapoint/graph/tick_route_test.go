@@ -0,0 +1,122 @@
+package graph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+)
+
+func TestFindRoutes(t *testing.T) {
+	pair := func(s string) value.Pair {
+		p, err := value.PairFromString(s)
+		require.NoError(t, err)
+		return p
+	}
+	edges := []RouteEdge{
+		{Origin: "a", Pair: pair("X/ETH")},
+		{Origin: "b", Pair: pair("ETH/USD")},
+		{Origin: "a", Pair: pair("USD/X")},
+		{Origin: "c", Pair: pair("ETH/BTC")},
+		{Origin: "c", Pair: pair("BTC/USD")},
+		{Origin: "c", Pair: pair("DAI/USD")},
+	}
+	tests := []struct {
+		name    string
+		pair    value.Pair
+		maxHops int
+		want    []string
+	}{
+		{
+			name:    "single hop",
+			pair:    pair("X/USD"),
+			maxHops: 1,
+			want:    []string{"a:USD/X"},
+		},
+		{
+			name:    "two hops",
+			pair:    pair("X/USD"),
+			maxHops: 2,
+			want:    []string{"a:USD/X", "a:X/ETH b:ETH/USD"},
+		},
+		{
+			name:    "three hops",
+			pair:    pair("X/USD"),
+			maxHops: 3,
+			want:    []string{"a:USD/X", "a:X/ETH b:ETH/USD", "a:X/ETH c:ETH/BTC c:BTC/USD"},
+		},
+		{
+			name:    "inverted",
+			pair:    pair("USD/ETH"),
+			maxHops: 2,
+			want:    []string{"b:ETH/USD", "a:USD/X a:X/ETH", "c:BTC/USD c:ETH/BTC"},
+		},
+		{
+			name:    "no routes",
+			pair:    pair("X/EUR"),
+			maxHops: 3,
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, route := range FindRoutes(tt.pair, edges, tt.maxHops) {
+				var s string
+				for i, e := range route {
+					if i > 0 {
+						s += " "
+					}
+					s += e.String()
+				}
+				got = append(got, s)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTickRouteNode(t *testing.T) {
+	setPoint := func(n *OriginNode, p value.Pair, price float64) {
+		require.NoError(t, n.SetDataPoint(datapoint.Point{
+			Value: value.NewTick(p, price, 0),
+			Time:  time.Now(),
+		}))
+	}
+
+	direct := NewOriginNode("a", value.Pair{Base: "X", Quote: "USD"}, time.Minute, time.Minute)
+	hop1 := NewOriginNode("a", value.Pair{Base: "X", Quote: "ETH"}, time.Minute, time.Minute)
+	hop2 := NewOriginNode("b", value.Pair{Base: "ETH", Quote: "USD"}, time.Minute, time.Minute)
+	setPoint(direct, value.Pair{Base: "X", Quote: "USD"}, 10)
+	setPoint(hop1, value.Pair{Base: "X", Quote: "ETH"}, 0.01)
+	setPoint(hop2, value.Pair{Base: "ETH", Quote: "USD"}, 1200)
+
+	indirect := NewTickIndirectNode()
+	require.NoError(t, indirect.AddNodes(hop1, hop2))
+
+	t.Run("median of routes", func(t *testing.T) {
+		n := NewTickRouteNode(value.Pair{Base: "X", Quote: "USD"}, 2)
+		require.NoError(t, n.AddNodes(direct, indirect))
+
+		point := n.DataPoint()
+		require.NoError(t, point.Validate())
+		assert.Equal(t, "11", point.Value.(value.Tick).Price.String())
+		assert.Len(t, point.SubPoints, 2)
+		assert.Equal(t, "route", point.Meta["type"])
+		assert.Equal(t, []string{"a:X/USD", "a:X/ETH -> b:ETH/USD"}, point.Meta["paths"])
+	})
+	t.Run("not enough values", func(t *testing.T) {
+		n := NewTickRouteNode(value.Pair{Base: "X", Quote: "USD"}, 3)
+		require.NoError(t, n.AddNodes(direct, indirect))
+		assert.Error(t, n.DataPoint().Validate())
+	})
+	t.Run("pair mismatch", func(t *testing.T) {
+		n := NewTickRouteNode(value.Pair{Base: "USD", Quote: "X"}, 1)
+		require.NoError(t, n.AddNodes(direct))
+		assert.Error(t, n.DataPoint().Validate())
+	})
+}
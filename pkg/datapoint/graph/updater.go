@@ -40,18 +40,40 @@ func NewUpdater(origins map[string]origin.Origin, logger log.Logger) *Updater {
 //
 // Only origin nodes that are not fresh will be updated.
 func (u *Updater) Update(ctx context.Context, graphs []Node) {
-	nodes, queries := u.identifyNodesToUpdate(graphs)
+	nodes, queries := u.identifyNodesToUpdate(graphs, (*OriginNode).IsFresh)
 	u.updateNodesWithDataPoints(nodes, u.fetchDataPoints(ctx, queries))
 }
 
+// updateUninitialized updates the origin nodes in the given graphs that
+// have never been updated.
+func (u *Updater) updateUninitialized(ctx context.Context, graphs []Node) {
+	nodes, queries := u.identifyNodesToUpdate(graphs, func(n *OriginNode) bool {
+		return !n.updatedAt().IsZero()
+	})
+	u.updateNodesWithDataPoints(nodes, u.fetchDataPoints(ctx, queries))
+}
+
+// Refresh updates the given origin nodes regardless of whether they are
+// fresh or not.
+func (u *Updater) Refresh(ctx context.Context, nodes []*OriginNode) {
+	nodesToUpdate := make(nodesMap)
+	queries := make(queryMap)
+	for _, node := range nodes {
+		nodesToUpdate.add(node)
+		queries.add(node)
+	}
+	u.updateNodesWithDataPoints(nodesToUpdate, u.fetchDataPoints(ctx, queries))
+}
+
 // identifyNodesToUpdate returns the nodes that need to be updated along
-// with the pairs needed to fetch the points for those nodes.
-func (u *Updater) identifyNodesToUpdate(graphs []Node) (nodesMap, queryMap) {
+// with the pairs needed to fetch the points for those nodes. Nodes for which
+// the skip function returns true are omitted.
+func (u *Updater) identifyNodesToUpdate(graphs []Node, skip func(*OriginNode) bool) (nodesMap, queryMap) {
 	nodes := make(nodesMap)
 	queries := make(queryMap)
 	Walk(func(n Node) {
 		if originNode, ok := n.(*OriginNode); ok {
-			if originNode.IsFresh() {
+			if skip(originNode) {
 				return
 			}
 			nodes.add(originNode)
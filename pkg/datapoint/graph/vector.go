@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+// VectorNode is a node that combines numeric values of its nodes into
+// a single vector, e.g. rates for different terms.
+//
+// It expects that all nodes return data points with value.NumericValue
+// values. The order of values in the vector is the order of the nodes.
+type VectorNode struct {
+	labels []string
+	nodes  []Node
+}
+
+// NewVectorNode creates a new VectorNode instance.
+//
+// The labels argument is an optional list of labels of the values. If set,
+// the number of labels must match the number of nodes.
+func NewVectorNode(labels []string) *VectorNode {
+	return &VectorNode{labels: labels}
+}
+
+// AddNodes implements the Node interface.
+func (n *VectorNode) AddNodes(nodes ...Node) error {
+	n.nodes = append(n.nodes, nodes...)
+	return nil
+}
+
+// Nodes implements the Node interface.
+func (n *VectorNode) Nodes() []Node {
+	return n.nodes
+}
+
+// DataPoint implements the Node interface.
+func (n *VectorNode) DataPoint() datapoint.Point {
+	if len(n.nodes) == 0 {
+		return datapoint.Point{
+			Time:  time.Now(),
+			Meta:  n.Meta(),
+			Error: fmt.Errorf("no nodes"),
+		}
+	}
+	if len(n.labels) > 0 && len(n.labels) != len(n.nodes) {
+		return datapoint.Point{
+			Time:  time.Now(),
+			Meta:  n.Meta(),
+			Error: fmt.Errorf("number of labels does not match number of nodes"),
+		}
+	}
+	var (
+		tm     time.Time
+		points = make([]datapoint.Point, len(n.nodes))
+		values = make([]*bn.DecFloatPointNumber, len(n.nodes))
+	)
+	for i, node := range n.nodes {
+		points[i] = node.DataPoint()
+	}
+
+	// All values are required, so the vector is invalid if any of the
+	// data points is invalid.
+	for i, point := range points {
+		if err := point.Validate(); err != nil {
+			return datapoint.Point{
+				Time:      time.Now(),
+				SubPoints: points,
+				Meta:      n.Meta(),
+				Error:     fmt.Errorf("invalid data point %d: %w", i, err),
+			}
+		}
+		num, ok := point.Value.(value.NumericValue)
+		if !ok {
+			return datapoint.Point{
+				Time:      time.Now(),
+				SubPoints: points,
+				Meta:      n.Meta(),
+				Error:     fmt.Errorf("invalid data point value, expected value.NumericValue"),
+			}
+		}
+		if tm.IsZero() || point.Time.Before(tm) {
+			tm = point.Time
+		}
+		values[i] = bn.DecFloatPoint(num.Number())
+	}
+	return datapoint.Point{
+		Value:     value.Vector{Values: values, Labels: n.labels},
+		Time:      tm,
+		SubPoints: points,
+		Meta:      n.Meta(),
+	}
+}
+
+// Meta implements the Node interface.
+func (n *VectorNode) Meta() map[string]any {
+	return map[string]any{
+		"type":   "vector",
+		"labels": n.labels,
+	}
+}
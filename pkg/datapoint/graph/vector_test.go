@@ -0,0 +1,87 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+func TestVectorNode_DataPoint(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		labels  []string
+		points  []datapoint.Point
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "two values",
+			labels: []string{"1d", "7d"},
+			points: []datapoint.Point{
+				{Value: value.StaticValue{Value: bn.DecFloatPoint(1)}, Time: now},
+				{Value: value.NewRate(-2, time.Second), Time: now.Add(-time.Second)},
+			},
+			want: []string{"1", "-2"},
+		},
+		{
+			name: "without labels",
+			points: []datapoint.Point{
+				{Value: value.StaticValue{Value: bn.DecFloatPoint(1)}, Time: now},
+			},
+			want: []string{"1"},
+		},
+		{
+			name:   "labels mismatch",
+			labels: []string{"1d", "7d"},
+			points: []datapoint.Point{
+				{Value: value.StaticValue{Value: bn.DecFloatPoint(1)}, Time: now},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid data point",
+			points: []datapoint.Point{
+				{Value: value.StaticValue{Value: bn.DecFloatPoint(1)}, Time: now},
+				{Error: errors.New("error")},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "no nodes",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := NewVectorNode(tt.labels)
+			for _, point := range tt.points {
+				mockNode := new(mockNode)
+				mockNode.On("DataPoint").Return(point)
+				require.NoError(t, node.AddNodes(mockNode))
+			}
+			point := node.DataPoint()
+			if tt.wantErr {
+				assert.Error(t, point.Validate())
+				return
+			}
+			require.NoError(t, point.Validate())
+			vector := point.Value.(value.Vector)
+			require.Len(t, vector.Values, len(tt.want))
+			for i, want := range tt.want {
+				assert.Equal(t, want, vector.Values[i].String())
+			}
+			assert.Equal(t, tt.labels, vector.Labels)
+			assert.Len(t, point.SubPoints, len(tt.points))
+			assert.Equal(t, tt.points[len(tt.points)-1].Time, point.Time)
+		})
+	}
+}
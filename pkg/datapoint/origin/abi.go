@@ -5,6 +5,19 @@ import "github.com/defiweb/go-eth/abi"
 // [Balancer V2]
 var getLatest = abi.MustParseMethod("getLatest(uint8)(uint256)")
 var getPriceRateCache = abi.MustParseMethod("getPriceRateCache(address)(uint256,uint256,uint256)")
+var getPoolID = abi.MustParseMethod("getPoolId()(bytes32)")
+var getVault = abi.MustParseMethod("getVault()(address)")
+var getPoolTokens = abi.MustParseMethod(
+	"getPoolTokens(bytes32 poolId)(address[] tokens,uint256[] balances,uint256 lastChangeBlock)",
+)
+var queryBatchSwap = abi.MustParseMethod(
+	"queryBatchSwap(" +
+		"uint8 kind," +
+		"(bytes32 poolId,uint256 assetInIndex,uint256 assetOutIndex,uint256 amount,bytes userData)[] swaps," +
+		"address[] assets," +
+		"(address sender,bool fromInternalBalance,address recipient,bool toInternalBalance) funds" +
+		")(int256[] assetDeltas)",
+)
 
 // [Curve]
 // Since curve has `stableswap` pool and `cryptoswap` pool, and their smart contracts have pretty similar interface
@@ -13,6 +26,7 @@ var getPriceRateCache = abi.MustParseMethod("getPriceRateCache(address)(uint256,
 var getDy1 = abi.MustParseMethod("get_dy(int128,int128,uint256)(uint256)")
 var getDy2 = abi.MustParseMethod("get_dy(uint256,uint256,uint256)(uint256)")
 var coins = abi.MustParseMethod("coins(uint256)(address)")
+var balances = abi.MustParseMethod("balances(uint256)(uint256)")
 
 // [dsr]
 var dsr = abi.MustParseMethod("dsr()(uint256)")
@@ -30,6 +44,11 @@ var token1Abi = abi.MustParseMethod("token1()(address)")
 
 // [Uniswap v3]
 var slot0 = abi.MustParseMethod("slot0()(uint160,int24,uint16,uint16,uint16,uint8,bool)")
+var feeAbi = abi.MustParseMethod("fee()(uint24)")
+var quoteExactInputSingle = abi.MustParseMethod(
+	"quoteExactInputSingle((address tokenIn,address tokenOut,uint256 amountIn,uint24 fee,uint160 sqrtPriceLimitX96))" +
+		"(uint256 amountOut,uint160 sqrtPriceX96After,uint32 initializedTicksCrossed,uint256 gasEstimate)",
+)
 
 // var token0Abi = abi.MustParseMethod("token0()(address)")
 // var token1Abi = abi.MustParseMethod("token1()(address)")
@@ -22,8 +22,10 @@ import (
 	"sort"
 	"time"
 
+	"github.com/defiweb/go-eth/abi"
 	"github.com/defiweb/go-eth/rpc"
 	"github.com/defiweb/go-eth/types"
+	"golang.org/x/exp/maps"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
@@ -40,14 +42,20 @@ type BalancerV2Config struct {
 	ReferenceAddresses ContractAddresses
 	Logger             log.Logger
 	Blocks             []int64
+
+	// Liquidity configures the origin to quote execution prices for
+	// a given trade size instead of marginal prices.
+	Liquidity LiquidityConfig
 }
 
 type BalancerV2 struct {
 	client             rpc.RPC
 	contractAddresses  ContractAddresses
 	referenceAddresses ContractAddresses
+	erc20              *ERC20
 	variable           byte
 	blocks             []int64
+	liquidity          LiquidityConfig
 	logger             log.Logger
 }
 
@@ -59,12 +67,19 @@ func NewBalancerV2(config BalancerV2Config) (*BalancerV2, error) {
 		config.Logger = null.New()
 	}
 
+	erc20, err := NewERC20(config.Client)
+	if err != nil {
+		return nil, err
+	}
+
 	return &BalancerV2{
 		client:             config.Client,
 		contractAddresses:  config.ContractAddresses,
 		referenceAddresses: config.ReferenceAddresses,
+		erc20:              erc20,
 		variable:           0, // PAIR_PRICE
 		blocks:             config.Blocks,
+		liquidity:          config.Liquidity,
 		logger:             config.Logger.WithField("balancerV2", BalancerV2LoggerTag),
 	}, nil
 }
@@ -186,5 +201,283 @@ func (b *BalancerV2) FetchDataPoints(ctx context.Context, query []any) (map[any]
 		}
 	}
 
+	// Replace marginal prices with execution prices for pairs with
+	// a configured trade size.
+	if b.liquidity.enabled() {
+		var liquidityPairs []value.Pair
+		for _, pair := range pairs {
+			if points[pair].Error == nil {
+				liquidityPairs = append(liquidityPairs, pair)
+			}
+		}
+		if len(liquidityPairs) > 0 {
+			quotedPoints, err := b.quoteDataPoints(ctx, liquidityPairs, block)
+			if err != nil {
+				return nil, err
+			}
+			for pair, point := range quotedPoints {
+				points[pair] = point
+			}
+		}
+	}
+
+	return points, nil
+}
+
+// balancerPool is a Balancer V2 pool used to quote execution prices.
+type balancerPool struct {
+	id     types.Hash
+	vault  types.Address
+	tokens []types.Address
+}
+
+// quoteDataPoints returns data points with execution prices for the given
+// pairs, simulated using the `queryBatchSwap` function of the vault. Only
+// pairs with a configured trade size are returned. The depth of a pool is
+// reported as the volume, which is the balance of the base token in the
+// pool.
+//
+//nolint:funlen,gocyclo
+func (b *BalancerV2) quoteDataPoints(
+	ctx context.Context,
+	pairs []value.Pair,
+	block *big.Int,
+) (
+	map[value.Pair]datapoint.Point,
+	error,
+) {
+
+	type batchSwapStep struct {
+		PoolID        types.Hash `abi:"poolId"`
+		AssetInIndex  *big.Int   `abi:"assetInIndex"`
+		AssetOutIndex *big.Int   `abi:"assetOutIndex"`
+		Amount        *big.Int   `abi:"amount"`
+		UserData      []byte     `abi:"userData"`
+	}
+	type fundManagement struct {
+		Sender              types.Address `abi:"sender"`
+		FromInternalBalance bool          `abi:"fromInternalBalance"`
+		Recipient           types.Address `abi:"recipient"`
+		ToInternalBalance   bool          `abi:"toInternalBalance"`
+	}
+
+	// Get the pool ID and the vault address of every pool.
+	var calls []types.Call
+	for _, pair := range pairs {
+		contract, _, _, err := b.contractAddresses.ByPair(pair)
+		if err != nil {
+			return nil, err
+		}
+		for _, method := range []*abi.Method{getPoolID, getVault} {
+			callData, err := method.EncodeArgs()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pool details for pair: %s: %w", pair.String(), err)
+			}
+			calls = append(calls, types.Call{
+				To:    &contract,
+				Input: callData,
+			})
+		}
+	}
+	resp, err := ethereum.MultiCall(ctx, b.client, calls, types.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	pools := make([]*balancerPool, len(pairs))
+	for i := range pairs {
+		pools[i] = &balancerPool{}
+		if err := getPoolID.DecodeValues(resp[i*2], &pools[i].id); err != nil {
+			return nil, fmt.Errorf("failed decoding pool id: %w", err)
+		}
+		if err := getVault.DecodeValues(resp[i*2+1], &pools[i].vault); err != nil {
+			return nil, fmt.Errorf("failed decoding vault address: %w", err)
+		}
+	}
+
+	// Get tokens in every pool.
+	poolTokensCalls := make([]types.Call, len(pairs))
+	for i, pool := range pools {
+		callData, err := getPoolTokens.EncodeArgs(pool.id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pool tokens for pair: %s: %w", pairs[i].String(), err)
+		}
+		poolTokensCalls[i] = types.Call{
+			To:    &pools[i].vault,
+			Input: callData,
+		}
+	}
+	resp, err = ethereum.MultiCall(ctx, b.client, poolTokensCalls, types.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	tokensMap := make(map[types.Address]struct{})
+	for i, pool := range pools {
+		var balances []*big.Int
+		var lastChangeBlock *big.Int
+		if err := getPoolTokens.DecodeValues(resp[i], &pool.tokens, &balances, &lastChangeBlock); err != nil {
+			return nil, fmt.Errorf("failed decoding tokens in the pool: %w", err)
+		}
+		for _, token := range pool.tokens {
+			tokensMap[token] = struct{}{}
+		}
+	}
+	tokenDetails, err := b.erc20.GetSymbolAndDecimals(ctx, maps.Keys(tokensMap))
+	if err != nil {
+		return nil, fmt.Errorf("failed getting symbol & decimals for tokens of pool: %w", err)
+	}
+
+	// Find the pairs with a configured trade size.
+	var (
+		swaps       []*swap
+		swapPairs   []value.Pair
+		swapPools   []*balancerPool
+		swapIndexes [][2]int // Indexes of the base and quote tokens in the pool.
+		swapCalls   []types.Call
+	)
+	for i, pair := range pairs {
+		baseToken, ok := tokenDetails[pair.Base]
+		if !ok {
+			continue
+		}
+		quoteToken, ok := tokenDetails[pair.Quote]
+		if !ok {
+			continue
+		}
+		swp, ok := b.liquidity.newSwap(baseToken, quoteToken)
+		if !ok {
+			continue
+		}
+		indexes := [2]int{-1, -1}
+		for n, token := range pools[i].tokens {
+			switch token {
+			case baseToken.address:
+				indexes[0] = n
+			case quoteToken.address:
+				indexes[1] = n
+			}
+		}
+		if indexes[0] < 0 || indexes[1] < 0 {
+			continue
+		}
+		swaps = append(swaps, swp)
+		swapPairs = append(swapPairs, pair)
+		swapPools = append(swapPools, pools[i])
+		swapIndexes = append(swapIndexes, indexes)
+		swapCalls = append(swapCalls, poolTokensCalls[i])
+	}
+	if len(swaps) == 0 {
+		return nil, nil
+	}
+
+	totals := make([]*big.Float, len(swaps))
+	depths := make([]*big.Float, len(swaps))
+	errs := make([]error, len(swaps))
+	for i := range swaps {
+		totals[i] = new(big.Float)
+		depths[i] = new(big.Float)
+	}
+
+	for _, blockDelta := range b.blocks {
+		blockNumber := types.BlockNumberFromUint64(uint64(block.Int64() - blockDelta))
+
+		blockSwaps := make([]*swap, len(swaps))
+		for i, s := range swaps {
+			blockSwaps[i] = &swap{
+				base:     s.base,
+				quote:    s.quote,
+				sellBase: s.sellBase,
+				amountIn: s.amountIn,
+			}
+		}
+
+		// Swaps are quoted in the same order as blockSwaps, both in the
+		// first and the reverse direction.
+		prices, priceErrs, err := b.liquidity.quoteSwaps(blockSwaps, func(quoted []*swap) error {
+			var calls []types.Call
+			for i, s := range quoted {
+				inIndex, outIndex := swapIndexes[i][1], swapIndexes[i][0]
+				if s.sellBase {
+					inIndex, outIndex = swapIndexes[i][0], swapIndexes[i][1]
+				}
+				callData, err := queryBatchSwap.EncodeArgs(
+					uint8(0), // GIVEN_IN
+					[]batchSwapStep{{
+						PoolID:        swapPools[i].id,
+						AssetInIndex:  big.NewInt(int64(inIndex)),
+						AssetOutIndex: big.NewInt(int64(outIndex)),
+						Amount:        s.amountIn,
+						UserData:      []byte{},
+					}},
+					swapPools[i].tokens,
+					fundManagement{},
+				)
+				if err != nil {
+					return fmt.Errorf("failed to get contract args for queryBatchSwap: %w", err)
+				}
+				calls = append(calls, types.Call{
+					To:    &swapPools[i].vault,
+					Input: callData,
+				})
+			}
+			resp, err := ethereum.MultiCall(ctx, b.client, calls, blockNumber)
+			if err != nil {
+				return err
+			}
+			for i, s := range quoted {
+				var deltas []*big.Int
+				if err := queryBatchSwap.DecodeValues(resp[i], &deltas); err != nil {
+					return fmt.Errorf("failed decoding queryBatchSwap result: %w", err)
+				}
+				outIndex := swapIndexes[i][1]
+				if !s.sellBase {
+					outIndex = swapIndexes[i][0]
+				}
+				// Assets sent by the vault are represented as negative deltas.
+				s.amountOut = new(big.Int).Neg(deltas[outIndex])
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := ethereum.MultiCall(ctx, b.client, swapCalls, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, s := range swaps {
+			if priceErrs[i] != nil {
+				errs[i] = priceErrs[i]
+				continue
+			}
+			var tokens []types.Address
+			var balances []*big.Int
+			var lastChangeBlock *big.Int
+			if err := getPoolTokens.DecodeValues(resp[i], &tokens, &balances, &lastChangeBlock); err != nil {
+				return nil, fmt.Errorf("failed decoding tokens in the pool: %w", err)
+			}
+			totals[i] = totals[i].Add(totals[i], prices[i])
+			depths[i] = depths[i].Add(depths[i], tokenAmount(balances[swapIndexes[i][0]], s.base.decimals))
+		}
+	}
+
+	points := make(map[value.Pair]datapoint.Point)
+	blocks := new(big.Float).SetUint64(uint64(len(b.blocks)))
+	for i, pair := range swapPairs {
+		if errs[i] != nil {
+			points[pair] = datapoint.Point{Error: errs[i]}
+			continue
+		}
+		tick := value.NewTick(
+			pair,
+			new(big.Float).Quo(totals[i], blocks),
+			new(big.Float).Quo(depths[i], blocks),
+		)
+		points[pair] = datapoint.Point{
+			Value: tick,
+			Time:  time.Now(),
+		}
+	}
 	return points, nil
 }
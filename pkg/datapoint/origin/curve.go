@@ -42,6 +42,10 @@ type CurveConfig struct {
 	CryptoSwapContractAddresses ContractAddresses
 	Logger                      log.Logger
 	Blocks                      []int64
+
+	// Liquidity configures the origin to quote execution prices for
+	// a given trade size instead of marginal prices.
+	Liquidity LiquidityConfig
 }
 
 type Curve struct {
@@ -50,6 +54,7 @@ type Curve struct {
 	cryptoSwapContract2Addresses ContractAddresses
 	erc20                        *ERC20
 	blocks                       []int64
+	liquidity                    LiquidityConfig
 	logger                       log.Logger
 }
 
@@ -72,6 +77,7 @@ func NewCurve(config CurveConfig) (*Curve, error) {
 		cryptoSwapContract2Addresses: config.CryptoSwapContractAddresses,
 		erc20:                        erc20,
 		blocks:                       config.Blocks,
+		liquidity:                    config.Liquidity,
 		logger:                       config.Logger.WithField("curve", CurveLoggerTag),
 	}, nil
 }
@@ -143,6 +149,7 @@ func (c *Curve) fetchDataPoints(
 	}
 
 	totals := make([]*big.Float, len(pairs))
+	swaps := make(map[value.Pair]*swap)
 	var calls []types.Call
 	n := 0
 	for _, pair := range pairs {
@@ -163,6 +170,12 @@ func (c *Curve) fetchDataPoints(
 			continue
 		}
 
+		// Pairs with a configured trade size are quoted separately.
+		if swp, ok := c.liquidity.newSwap(baseToken, quoteToken); ok {
+			swaps[pair] = swp
+			continue
+		}
+
 		// `get_dy` function requires to pass the token index in first two parameters in ascending order
 		// and the third parameter is the token amount scaled up by first token's decimals
 		// The return value is the token amount scaled up by second token's decimals
@@ -209,6 +222,9 @@ func (c *Curve) fetchDataPoints(
 				if points[pair].Error != nil {
 					continue
 				}
+				if _, ok := swaps[pair]; ok {
+					continue
+				}
 				_, baseIndex, quoteIndex, _ := contractAddresses.ByPair(pair)
 				baseToken := tokenDetails[pair.Base]
 				quoteToken := tokenDetails[pair.Quote]
@@ -239,6 +255,9 @@ func (c *Curve) fetchDataPoints(
 		if points[pair].Error != nil {
 			continue
 		}
+		if _, ok := swaps[pair]; ok {
+			continue
+		}
 		avgPrice := new(big.Float).Quo(totals[n], new(big.Float).SetUint64(uint64(len(c.blocks))))
 		n++
 
@@ -254,6 +273,146 @@ func (c *Curve) fetchDataPoints(
 			Time:  time.Now(),
 		}
 	}
+
+	if len(swaps) > 0 {
+		quotedPoints, err := c.quoteDataPoints(ctx, contractAddresses, getDy, swaps, block)
+		if err != nil {
+			return nil, err
+		}
+		for pair, point := range quotedPoints {
+			points[pair] = point
+		}
+	}
+	return points, nil
+}
+
+// quoteDataPoints returns data points with execution prices of the given
+// swaps, simulated using the `get_dy` function of the pools. The depth of
+// a pool is reported as the volume, which is the balance of the base token
+// in the pool.
+//
+//nolint:funlen
+func (c *Curve) quoteDataPoints(
+	ctx context.Context,
+	contractAddresses ContractAddresses,
+	getDy *abi.Method,
+	swaps map[value.Pair]*swap,
+	block *big.Int,
+) (
+	map[value.Pair]datapoint.Point,
+	error,
+) {
+
+	pairs := maps.Keys(swaps)
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].String() < pairs[j].String()
+	})
+
+	totals := make([]*big.Float, len(pairs))
+	depths := make([]*big.Float, len(pairs))
+	errs := make([]error, len(pairs))
+	for i := range pairs {
+		totals[i] = new(big.Float)
+		depths[i] = new(big.Float)
+	}
+
+	// Calls for the balance of the base token in every pool.
+	var balanceCalls []types.Call
+	for _, pair := range pairs {
+		pool, baseIndex, _, _ := contractAddresses.ByPair(pair)
+		callData, err := balances.EncodeArgs(baseIndex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get contract args for pair: %s: %w", pair.String(), err)
+		}
+		balanceCalls = append(balanceCalls, types.Call{
+			To:    &pool,
+			Input: callData,
+		})
+	}
+
+	for _, blockDelta := range c.blocks {
+		blockNumber := types.BlockNumberFromUint64(uint64(block.Int64() - blockDelta))
+
+		blockSwaps := make([]*swap, len(pairs))
+		for i, pair := range pairs {
+			blockSwaps[i] = &swap{
+				base:     swaps[pair].base,
+				quote:    swaps[pair].quote,
+				sellBase: swaps[pair].sellBase,
+				amountIn: swaps[pair].amountIn,
+			}
+		}
+		prices, priceErrs, err := c.liquidity.quoteSwaps(blockSwaps, func(quoted []*swap) error {
+			var calls []types.Call
+			for _, s := range quoted {
+				pool, baseIndex, quoteIndex, err := contractAddresses.ByPair(value.Pair{
+					Base:  s.base.symbol,
+					Quote: s.quote.symbol,
+				})
+				if err != nil {
+					return err
+				}
+				inIndex, outIndex := quoteIndex, baseIndex
+				if s.sellBase {
+					inIndex, outIndex = baseIndex, quoteIndex
+				}
+				callData, err := getDy.EncodeArgs(inIndex, outIndex, s.amountIn)
+				if err != nil {
+					return fmt.Errorf("failed to get contract args for get_dy: %w", err)
+				}
+				calls = append(calls, types.Call{
+					To:    &pool,
+					Input: callData,
+				})
+			}
+			resp, err := ethereum.MultiCall(ctx, c.client, calls, blockNumber)
+			if err != nil {
+				return err
+			}
+			for i, s := range quoted {
+				s.amountOut = new(big.Int).SetBytes(resp[i][0:32])
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := ethereum.MultiCall(ctx, c.client, balanceCalls, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, pair := range pairs {
+			if priceErrs[i] != nil {
+				errs[i] = priceErrs[i]
+				continue
+			}
+			totals[i] = totals[i].Add(totals[i], prices[i])
+			depths[i] = depths[i].Add(
+				depths[i],
+				tokenAmount(new(big.Int).SetBytes(resp[i][0:32]), swaps[pair].base.decimals),
+			)
+		}
+	}
+
+	points := make(map[value.Pair]datapoint.Point)
+	blocks := new(big.Float).SetUint64(uint64(len(c.blocks)))
+	for i, pair := range pairs {
+		if errs[i] != nil {
+			points[pair] = datapoint.Point{Error: errs[i]}
+			continue
+		}
+		tick := value.NewTick(
+			pair,
+			new(big.Float).Quo(totals[i], blocks),
+			new(big.Float).Quo(depths[i], blocks),
+		)
+		points[pair] = datapoint.Point{
+			Value: tick,
+			Time:  time.Now(),
+		}
+	}
 	return points, nil
 }
 
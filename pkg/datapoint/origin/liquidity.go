@@ -0,0 +1,212 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origin
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// LiquidityConfig configures DEX origins to quote an execution price for
+// a given trade size instead of the marginal price. Execution prices take
+// the depth of a pool into account, so they are much more expensive to
+// manipulate in thin pools.
+type LiquidityConfig struct {
+	// TradeSize is the amount of tokens sold in a simulated swap, keyed by
+	// the token symbol. For a pair, the size of the base token is used if
+	// set, otherwise the size of the quote token. If neither is set, the
+	// marginal price is returned.
+	TradeSize map[string]float64
+
+	// BothSides enables quoting swaps in both directions. The reverse swap
+	// sells the amount of tokens received from the first swap, and the
+	// average of both execution prices is returned.
+	BothSides bool
+}
+
+// enabled returns true if execution prices should be quoted.
+func (l LiquidityConfig) enabled() bool {
+	return len(l.TradeSize) > 0
+}
+
+// tradeSize returns the trade size of the given token.
+func (l LiquidityConfig) tradeSize(symbol string) (float64, bool) {
+	for s, size := range l.TradeSize {
+		if strings.EqualFold(s, symbol) {
+			return size, true
+		}
+	}
+	return 0, false
+}
+
+// swap is a simulated swap used to quote an execution price of a pair.
+type swap struct {
+	base, quote ERC20Details
+
+	// sellBase is true if the base token is sold for the quote token.
+	sellBase bool
+
+	// amountIn and amountOut are amounts of sold and received tokens,
+	// scaled by token decimals.
+	amountIn  *big.Int
+	amountOut *big.Int
+}
+
+// newSwap returns a swap for the given pair using the configured trade
+// size. It returns false if there is no trade size for any of the tokens.
+func (l LiquidityConfig) newSwap(base, quote ERC20Details) (*swap, bool) {
+	if size, ok := l.tradeSize(base.symbol); ok {
+		return &swap{base: base, quote: quote, sellBase: true, amountIn: scaleAmount(size, base.decimals)}, true
+	}
+	if size, ok := l.tradeSize(quote.symbol); ok {
+		return &swap{base: base, quote: quote, sellBase: false, amountIn: scaleAmount(size, quote.decimals)}, true
+	}
+	return nil, false
+}
+
+// tokenIn returns the sold token.
+func (s *swap) tokenIn() ERC20Details {
+	if s.sellBase {
+		return s.base
+	}
+	return s.quote
+}
+
+// tokenOut returns the received token.
+func (s *swap) tokenOut() ERC20Details {
+	if s.sellBase {
+		return s.quote
+	}
+	return s.base
+}
+
+// reverse returns a swap in the opposite direction that sells the amount
+// of tokens received in this swap.
+func (s *swap) reverse() *swap {
+	return &swap{base: s.base, quote: s.quote, sellBase: !s.sellBase, amountIn: s.amountOut}
+}
+
+// price returns the execution price of the base token in the quote token.
+func (s *swap) price() (*big.Float, error) {
+	if s.amountOut == nil || s.amountOut.Sign() <= 0 {
+		return nil, fmt.Errorf("insufficient liquidity to swap %s for %s", s.tokenIn().symbol, s.tokenOut().symbol)
+	}
+	in := tokenAmount(s.amountIn, s.tokenIn().decimals)
+	out := tokenAmount(s.amountOut, s.tokenOut().decimals)
+	if s.sellBase {
+		return new(big.Float).Quo(out, in), nil
+	}
+	return new(big.Float).Quo(in, out), nil
+}
+
+// quoteSwaps simulates the given swaps using the quote function, which must
+// set the amountOut field of every swap. If both sides are enabled, reverse
+// swaps are simulated as well.
+//
+// It returns execution prices for every swap, averaged over both sides if
+// enabled. If a price cannot be calculated, the corresponding error is set.
+func (l LiquidityConfig) quoteSwaps(swaps []*swap, quote func([]*swap) error) ([]*big.Float, []error, error) {
+	prices := make([]*big.Float, len(swaps))
+	errs := make([]error, len(swaps))
+	if err := quote(swaps); err != nil {
+		return nil, nil, err
+	}
+	for i, s := range swaps {
+		prices[i], errs[i] = s.price()
+	}
+	if !l.BothSides {
+		return prices, errs, nil
+	}
+	var reverse []*swap
+	var indices []int
+	for i, s := range swaps {
+		if errs[i] == nil {
+			reverse = append(reverse, s.reverse())
+			indices = append(indices, i)
+		}
+	}
+	if len(reverse) == 0 {
+		return prices, errs, nil
+	}
+	if err := quote(reverse); err != nil {
+		return nil, nil, err
+	}
+	for n, s := range reverse {
+		i := indices[n]
+		price, err := s.price()
+		if err != nil {
+			prices[i], errs[i] = nil, err
+			continue
+		}
+		prices[i] = new(big.Float).Quo(new(big.Float).Add(prices[i], price), big.NewFloat(2))
+	}
+	return prices, errs, nil
+}
+
+// getAmountOut returns the amount of tokens received for the given amount
+// of sold tokens from a pool with the given reserves, using the constant
+// product formula of Uniswap V2 pools with the 0.3% fee.
+//
+// Reference: https://github.com/Uniswap/v2-periphery/blob/master/contracts/libraries/UniswapV2Library.sol#L43
+func getAmountOut(amountIn, reserveIn, reserveOut *big.Int) *big.Int {
+	if amountIn.Sign() <= 0 || reserveIn.Sign() <= 0 || reserveOut.Sign() <= 0 {
+		return big.NewInt(0)
+	}
+	amountInWithFee := new(big.Int).Mul(amountIn, big.NewInt(997))
+	numerator := new(big.Int).Mul(amountInWithFee, reserveOut)
+	denominator := new(big.Int).Add(new(big.Int).Mul(reserveIn, big.NewInt(1000)), amountInWithFee)
+	return numerator.Div(numerator, denominator)
+}
+
+// scaleAmount returns the amount scaled by the given number of decimals.
+func scaleAmount(amount float64, decimals int) *big.Int {
+	x := new(big.Float).Mul(
+		big.NewFloat(amount),
+		new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)),
+	)
+	i, _ := x.Int(nil)
+	return i
+}
+
+// tokenAmount returns the amount of tokens scaled down by the given number
+// of decimals.
+func tokenAmount(amount *big.Int, decimals int) *big.Float {
+	return new(big.Float).Quo(
+		new(big.Float).SetInt(amount),
+		new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)),
+	)
+}
+
+// quoteConstantProduct returns the execution price of the swap in a pool
+// with the given reserves that uses the constant product formula, such as
+// Uniswap V2 or Sushiswap pools.
+func (l LiquidityConfig) quoteConstantProduct(s *swap, baseReserve, quoteReserve *big.Int) (*big.Float, error) {
+	prices, errs, err := l.quoteSwaps([]*swap{s}, func(swaps []*swap) error {
+		for _, s := range swaps {
+			if s.sellBase {
+				s.amountOut = getAmountOut(s.amountIn, baseReserve, quoteReserve)
+			} else {
+				s.amountOut = getAmountOut(s.amountIn, quoteReserve, baseReserve)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return prices[0], errs[0]
+}
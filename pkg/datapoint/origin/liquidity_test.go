@@ -0,0 +1,102 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origin
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testMKR = ERC20Details{symbol: "MKR", decimals: 18}
+	testDAI = ERC20Details{symbol: "DAI", decimals: 18}
+)
+
+func TestGetAmountOut(t *testing.T) {
+	assert.Equal(t, "987158034397061298", getAmountOut(
+		scaleAmount(1000, 18),
+		scaleAmount(100000, 18),
+		scaleAmount(100, 18),
+	).String())
+	assert.Equal(t, "0", getAmountOut(big.NewInt(1), big.NewInt(0), big.NewInt(1)).String())
+	assert.Equal(t, "0", getAmountOut(big.NewInt(0), big.NewInt(1), big.NewInt(1)).String())
+}
+
+func TestLiquidityConfig_NewSwap(t *testing.T) {
+	l := LiquidityConfig{TradeSize: map[string]float64{"dai": 1000}}
+
+	// The quote token size is used if the base token size is not set.
+	s, ok := l.newSwap(testMKR, testDAI)
+	require.True(t, ok)
+	assert.False(t, s.sellBase)
+	assert.Equal(t, testDAI, s.tokenIn())
+	assert.Equal(t, testMKR, s.tokenOut())
+	assert.Equal(t, scaleAmount(1000, 18), s.amountIn)
+
+	// The base token size is preferred.
+	s, ok = l.newSwap(testDAI, testMKR)
+	require.True(t, ok)
+	assert.True(t, s.sellBase)
+
+	_, ok = LiquidityConfig{}.newSwap(testMKR, testDAI)
+	assert.False(t, ok)
+}
+
+func TestLiquidityConfig_QuoteConstantProduct(t *testing.T) {
+	tests := []struct {
+		name      string
+		bothSides bool
+		want      float64
+	}{
+		{name: "one-side", bothSides: false, want: 1013.0090270812437},
+		{name: "both-sides", bothSides: true, want: 1000.1461099287898},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := LiquidityConfig{TradeSize: map[string]float64{"DAI": 1000}, BothSides: tt.bothSides}
+			s, ok := l.newSwap(testMKR, testDAI)
+			require.True(t, ok)
+			price, err := l.quoteConstantProduct(s, scaleAmount(100, 18), scaleAmount(100000, 18))
+			require.NoError(t, err)
+			f, _ := price.Float64()
+			assert.InDelta(t, tt.want, f, 1e-9)
+		})
+	}
+}
+
+func TestLiquidityConfig_QuoteSwaps(t *testing.T) {
+	l := LiquidityConfig{TradeSize: map[string]float64{"DAI": 1000}, BothSides: true}
+	s, _ := l.newSwap(testMKR, testDAI)
+
+	// Swaps without liquidity return an error.
+	prices, errs, err := l.quoteSwaps([]*swap{s}, func(swaps []*swap) error {
+		swaps[0].amountOut = big.NewInt(0)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Nil(t, prices[0])
+	assert.EqualError(t, errs[0], "insufficient liquidity to swap DAI for MKR")
+
+	// Errors returned by the quote function are returned as is.
+	_, _, err = l.quoteSwaps([]*swap{s}, func(swaps []*swap) error {
+		return errors.New("foo")
+	})
+	assert.EqualError(t, err, "foo")
+}
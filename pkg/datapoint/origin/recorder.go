@@ -0,0 +1,261 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+)
+
+const RecorderLoggerTag = "ORIGIN_RECORDER"
+
+// Capture is a single call to the Origin.FetchDataPoints method captured by
+// the Recorder.
+type Capture struct {
+	// Origin is the name of the origin.
+	Origin string `json:"origin"`
+
+	// Time is the time at which the origin returned data points.
+	Time time.Time `json:"time"`
+
+	// Queries is a list of queries passed to the origin, formatted using
+	// the fmt.Sprint function.
+	Queries []string `json:"queries"`
+
+	// Points are data points returned by the origin, keyed by the query.
+	Points map[string]CapturedPoint `json:"points,omitempty"`
+
+	// Error is an error returned by the origin, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// CapturedPoint is a data point returned by an origin in a serializable
+// form. Only value.Tick and value.StaticValue values are supported, other
+// values are captured as errors.
+type CapturedPoint struct {
+	Tick   *value.Tick        `json:"tick,omitempty"`
+	Static *value.StaticValue `json:"static,omitempty"`
+	Time   time.Time          `json:"time"`
+	Error  string             `json:"error,omitempty"`
+}
+
+// Recorder records calls to origins as JSON lines, one capture per line.
+//
+// Recorded captures may be replayed using the Replay origin.
+type Recorder struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	logger log.Logger
+}
+
+// NewRecorder creates a new Recorder instance that writes captures to
+// the given writer. Captures that cannot be written are logged using the
+// given logger. If the logger is nil, null logger is used.
+func NewRecorder(w io.Writer, logger log.Logger) *Recorder {
+	if logger == nil {
+		logger = null.New()
+	}
+	return &Recorder{
+		enc:    json.NewEncoder(w),
+		logger: logger.WithField("tag", RecorderLoggerTag),
+	}
+}
+
+// Wrap returns an origin that records calls to the given origin under the
+// given name.
+func (r *Recorder) Wrap(name string, o Origin) Origin {
+	return &recordingOrigin{name: name, origin: o, recorder: r}
+}
+
+func (r *Recorder) record(c Capture) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.enc.Encode(c)
+}
+
+type recordingOrigin struct {
+	name     string
+	origin   Origin
+	recorder *Recorder
+}
+
+// FetchDataPoints implements the Origin interface.
+func (o *recordingOrigin) FetchDataPoints(ctx context.Context, query []any) (map[any]datapoint.Point, error) {
+	points, err := o.origin.FetchDataPoints(ctx, query)
+	c := Capture{
+		Origin:  o.name,
+		Time:    time.Now(),
+		Queries: make([]string, len(query)),
+	}
+	for i, q := range query {
+		c.Queries[i] = fmt.Sprint(q)
+	}
+	if err != nil {
+		c.Error = err.Error()
+	} else {
+		c.Points = make(map[string]CapturedPoint, len(points))
+		for q, p := range points {
+			c.Points[fmt.Sprint(q)] = capturePoint(p)
+		}
+	}
+	// A failed capture must not affect data points returned by the origin.
+	if recErr := o.recorder.record(c); recErr != nil {
+		o.recorder.logger.
+			WithField("origin", o.name).
+			WithError(recErr).
+			Warn("Unable to record data points")
+	}
+	return points, err
+}
+
+func capturePoint(p datapoint.Point) CapturedPoint {
+	c := CapturedPoint{Time: p.Time}
+	switch v := p.Value.(type) {
+	case value.Tick:
+		c.Tick = &v
+	case value.StaticValue:
+		c.Static = &v
+	}
+	switch {
+	case p.Error != nil:
+		c.Error = p.Error.Error()
+	case c.Tick == nil && c.Static == nil:
+		c.Error = fmt.Sprintf("unsupported value type: %T", p.Value)
+	}
+	return c
+}
+
+func (c CapturedPoint) point() datapoint.Point {
+	p := datapoint.Point{Time: c.Time}
+	switch {
+	case c.Tick != nil:
+		p.Value = *c.Tick
+	case c.Static != nil:
+		p.Value = *c.Static
+	}
+	if c.Error != "" {
+		p.Error = errors.New(c.Error)
+	}
+	return p
+}
+
+// ReadCaptures reads captures written by the Recorder. Captures are sorted
+// by time.
+func ReadCaptures(r io.Reader) ([]Capture, error) {
+	var captures []Capture
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 64*1024*1024)
+	for n := 1; s.Scan(); n++ {
+		if len(s.Bytes()) == 0 {
+			continue
+		}
+		var c Capture
+		if err := json.Unmarshal(s.Bytes(), &c); err != nil {
+			return nil, fmt.Errorf("unable to read capture at line %d: %w", n, err)
+		}
+		captures = append(captures, c)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(captures, func(i, j int) bool {
+		return captures[i].Time.Before(captures[j].Time)
+	})
+	return captures, nil
+}
+
+// Replay is an origin that returns data points captured by the Recorder.
+//
+// For every query, the data point from the most recent capture made at or
+// before the time returned by the clock function is returned. The time of
+// the returned data point is shifted, so that its age relative to the
+// current time is the same as its age relative to the clock time. This
+// allows to replay captures using a simulated clock without affecting
+// freshness and expiry checks of data models.
+type Replay struct {
+	captures []Capture
+	clock    func() time.Time
+}
+
+// NewReplay creates a new Replay origin instance that replays captures of
+// the origin with the given name.
+func NewReplay(name string, captures []Capture, clock func() time.Time) *Replay {
+	var filtered []Capture
+	for _, c := range captures {
+		if c.Origin == name {
+			filtered = append(filtered, c)
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Time.Before(filtered[j].Time)
+	})
+	return &Replay{captures: filtered, clock: clock}
+}
+
+// FetchDataPoints implements the Origin interface.
+func (r *Replay) FetchDataPoints(_ context.Context, query []any) (map[any]datapoint.Point, error) {
+	var (
+		now    = time.Now()
+		clock  = r.clock()
+		points = make(map[any]datapoint.Point, len(query))
+		last   = sort.Search(len(r.captures), func(i int) bool {
+			return r.captures[i].Time.After(clock)
+		})
+	)
+	for _, q := range query {
+		points[q] = datapoint.Point{
+			Time:  now,
+			Error: fmt.Errorf("no capture for query %v at %s", q, clock.Format(time.RFC3339)),
+		}
+		if p, ok := r.find(fmt.Sprint(q), last); ok {
+			p.Time = now.Add(p.Time.Sub(clock))
+			points[q] = p
+		}
+	}
+	return points, nil
+}
+
+// find returns the data point for the given query from the most recent
+// capture before the given index.
+func (r *Replay) find(query string, last int) (datapoint.Point, bool) {
+	for i := last - 1; i >= 0; i-- {
+		c := r.captures[i]
+		if p, ok := c.Points[query]; ok {
+			return p.point(), true
+		}
+		if c.Error == "" {
+			continue
+		}
+		for _, q := range c.Queries {
+			if q == query {
+				return datapoint.Point{Time: c.Time, Error: errors.New(c.Error)}, true
+			}
+		}
+	}
+	return datapoint.Point{}, false
+}
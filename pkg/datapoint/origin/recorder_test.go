@@ -0,0 +1,131 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+)
+
+type fakeOrigin struct {
+	points map[any]datapoint.Point
+	err    error
+}
+
+func (f *fakeOrigin) FetchDataPoints(_ context.Context, query []any) (map[any]datapoint.Point, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	points := make(map[any]datapoint.Point, len(query))
+	for _, q := range query {
+		points[q] = f.points[q]
+	}
+	return points, nil
+}
+
+func TestRecorderReplay(t *testing.T) {
+	pair := value.Pair{Base: "ETH", Quote: "USD"}
+	o := &fakeOrigin{}
+	buf := &bytes.Buffer{}
+	rec := NewRecorder(buf, nil).Wrap("ex", o)
+
+	// Record three calls, the second one fails.
+	var times []time.Time
+	for _, price := range []float64{1000, 0, 1100} {
+		if price == 0 {
+			o.err = errors.New("failure")
+		} else {
+			o.err = nil
+			o.points = map[any]datapoint.Point{
+				pair: {Value: value.NewTick(pair, price, 1), Time: time.Now()},
+			}
+		}
+		_, err := rec.FetchDataPoints(context.Background(), []any{pair})
+		assert.Equal(t, o.err, err)
+		times = append(times, time.Now())
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	captures, err := ReadCaptures(buf)
+	require.NoError(t, err)
+	require.Len(t, captures, 3)
+	assert.Equal(t, "ex", captures[0].Origin)
+	assert.Equal(t, []string{"ETH/USD"}, captures[0].Queries)
+	assert.Equal(t, "failure", captures[1].Error)
+
+	var clock time.Time
+	replay := NewReplay("ex", captures, func() time.Time { return clock })
+	fetch := func(at time.Time) datapoint.Point {
+		clock = at
+		points, err := replay.FetchDataPoints(context.Background(), []any{pair})
+		require.NoError(t, err)
+		return points[pair]
+	}
+
+	// Before the first capture.
+	assert.Error(t, fetch(captures[0].Time.Add(-time.Second)).Validate())
+
+	// After the first capture.
+	p := fetch(times[0])
+	require.NoError(t, p.Validate())
+	assert.Equal(t, "1000", p.Value.(value.Tick).Price.String())
+	assert.WithinDuration(t, time.Now(), p.Time, time.Second)
+
+	// After the failed capture.
+	p = fetch(times[1])
+	assert.EqualError(t, p.Error, "failure")
+
+	// After the last capture, the age of the point is preserved.
+	p = fetch(times[2].Add(time.Minute))
+	require.NoError(t, p.Validate())
+	assert.Equal(t, "1100", p.Value.(value.Tick).Price.String())
+	assert.WithinDuration(t, time.Now().Add(-time.Minute), p.Time, time.Second)
+
+	// Captures of other origins are ignored.
+	other := NewReplay("other", captures, func() time.Time { return clock })
+	points, err := other.FetchDataPoints(context.Background(), []any{pair})
+	require.NoError(t, err)
+	assert.Error(t, points[pair].Validate())
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestRecorder_WriteError(t *testing.T) {
+	pair := value.Pair{Base: "ETH", Quote: "USD"}
+	o := &fakeOrigin{points: map[any]datapoint.Point{
+		pair: {Value: value.NewTick(pair, 1000, 1), Time: time.Now()},
+	}}
+	rec := NewRecorder(failingWriter{}, nil).Wrap("ex", o)
+
+	// Data points must be returned even if the capture cannot be written.
+	points, err := rec.FetchDataPoints(context.Background(), []any{pair})
+	require.NoError(t, err)
+	require.NoError(t, points[pair].Validate())
+	assert.Equal(t, "1000", points[pair].Value.(value.Tick).Price.String())
+}
@@ -40,6 +40,10 @@ type SushiswapConfig struct {
 	ContractAddresses ContractAddresses
 	Logger            log.Logger
 	Blocks            []int64
+
+	// Liquidity configures the origin to quote execution prices for
+	// a given trade size instead of marginal prices.
+	Liquidity LiquidityConfig
 }
 
 type Sushiswap struct {
@@ -47,6 +51,7 @@ type Sushiswap struct {
 	contractAddresses ContractAddresses
 	erc20             *ERC20
 	blocks            []int64
+	liquidity         LiquidityConfig
 	logger            log.Logger
 }
 
@@ -68,6 +73,7 @@ func NewSushiswap(config SushiswapConfig) (*Sushiswap, error) {
 		contractAddresses: config.ContractAddresses,
 		erc20:             erc20,
 		blocks:            config.Blocks,
+		liquidity:         config.Liquidity,
 		logger:            config.Logger.WithField("sushiswap", SushiswapLoggerTag),
 	}, nil
 }
@@ -92,6 +98,8 @@ func (s *Sushiswap) FetchDataPoints(ctx context.Context, query []any) (map[any]d
 	}
 
 	totals := make([]*big.Float, len(pairs))
+	depths := make([]*big.Float, len(pairs))
+	errs := make([]error, len(pairs))
 	var calls []types.Call
 	var callsToken []types.Call
 	for i, pair := range pairs {
@@ -231,6 +239,27 @@ func (s *Sushiswap) FetchDataPoints(ctx context.Context, query []any) (map[any]d
 					)
 				}
 
+				// If a trade size is configured, use the execution price
+				// of a simulated swap instead of the marginal price.
+				if swp, ok := s.liquidity.newSwap(baseToken, quoteToken); ok {
+					baseReserve, quoteReserve := reserve0, reserve1
+					if baseToken != token0 {
+						baseReserve, quoteReserve = reserve1, reserve0
+					}
+					price, err := s.liquidity.quoteConstantProduct(swp, baseReserve, quoteReserve)
+					if err != nil {
+						errs[i] = err
+					} else {
+						totals[i] = totals[i].Add(totals[i], price)
+					}
+					if depths[i] == nil {
+						depths[i] = new(big.Float)
+					}
+					depths[i] = depths[i].Add(depths[i], tokenAmount(baseReserve, baseToken.decimals))
+					n++
+					continue
+				}
+
 				if baseToken == token0 {
 					totals[i] = totals[i].Add(totals[i], token1Price)
 				} else { // base token == token1
@@ -245,9 +274,20 @@ func (s *Sushiswap) FetchDataPoints(ctx context.Context, query []any) (map[any]d
 		if points[pair].Error != nil {
 			continue
 		}
+		if errs[i] != nil {
+			points[pair] = datapoint.Point{Error: errs[i]}
+			continue
+		}
 		avgPrice := new(big.Float).Quo(totals[i], new(big.Float).SetUint64(uint64(len(s.blocks))))
 
-		tick := value.NewTick(pair, avgPrice, nil)
+		// The pool depth is reported as the volume if the execution price
+		// is quoted.
+		var volume any
+		if depths[i] != nil {
+			volume = new(big.Float).Quo(depths[i], new(big.Float).SetUint64(uint64(len(s.blocks))))
+		}
+
+		tick := value.NewTick(pair, avgPrice, volume)
 		points[pair] = datapoint.Point{
 			Value: tick,
 			Time:  time.Now(),
@@ -40,6 +40,10 @@ type UniswapV2Config struct {
 	ContractAddresses ContractAddresses
 	Logger            log.Logger
 	Blocks            []int64
+
+	// Liquidity configures the origin to quote execution prices for
+	// a given trade size instead of marginal prices.
+	Liquidity LiquidityConfig
 }
 
 type UniswapV2 struct {
@@ -47,6 +51,7 @@ type UniswapV2 struct {
 	contractAddresses ContractAddresses
 	erc20             *ERC20
 	blocks            []int64
+	liquidity         LiquidityConfig
 	logger            log.Logger
 }
 
@@ -68,6 +73,7 @@ func NewUniswapV2(config UniswapV2Config) (*UniswapV2, error) {
 		contractAddresses: config.ContractAddresses,
 		erc20:             erc20,
 		blocks:            config.Blocks,
+		liquidity:         config.Liquidity,
 		logger:            config.Logger.WithField("uniswapV2", UniswapV2LoggerTag),
 	}, nil
 }
@@ -92,6 +98,8 @@ func (u *UniswapV2) FetchDataPoints(ctx context.Context, query []any) (map[any]d
 	}
 
 	totals := make([]*big.Float, len(pairs))
+	depths := make([]*big.Float, len(pairs))
+	errs := make([]error, len(pairs))
 	var calls []types.Call
 	var callsToken []types.Call
 	// Get the reserves and token0/token1 per each pair
@@ -233,6 +241,27 @@ func (u *UniswapV2) FetchDataPoints(ctx context.Context, query []any) (map[any]d
 					)
 				}
 
+				// If a trade size is configured, use the execution price
+				// of a simulated swap instead of the marginal price.
+				if swp, ok := u.liquidity.newSwap(baseToken, quoteToken); ok {
+					baseReserve, quoteReserve := reserve0, reserve1
+					if baseToken != token0 {
+						baseReserve, quoteReserve = reserve1, reserve0
+					}
+					price, err := u.liquidity.quoteConstantProduct(swp, baseReserve, quoteReserve)
+					if err != nil {
+						errs[i] = err
+					} else {
+						totals[i] = totals[i].Add(totals[i], price)
+					}
+					if depths[i] == nil {
+						depths[i] = new(big.Float)
+					}
+					depths[i] = depths[i].Add(depths[i], tokenAmount(baseReserve, baseToken.decimals))
+					n++
+					continue
+				}
+
 				if baseToken == token0 {
 					totals[i] = totals[i].Add(totals[i], token1Price)
 				} else { // base token == token1
@@ -247,9 +276,20 @@ func (u *UniswapV2) FetchDataPoints(ctx context.Context, query []any) (map[any]d
 		if points[pair].Error != nil {
 			continue
 		}
+		if errs[i] != nil {
+			points[pair] = datapoint.Point{Error: errs[i]}
+			continue
+		}
 		avgPrice := new(big.Float).Quo(totals[i], new(big.Float).SetUint64(uint64(len(u.blocks))))
 
-		tick := value.NewTick(pair, avgPrice, nil)
+		// The pool depth is reported as the volume if the execution price
+		// is quoted.
+		var volume any
+		if depths[i] != nil {
+			volume = new(big.Float).Quo(depths[i], new(big.Float).SetUint64(uint64(len(u.blocks))))
+		}
+
+		tick := value.NewTick(pair, avgPrice, volume)
 		points[pair] = datapoint.Point{
 			Value: tick,
 			Time:  time.Now(),
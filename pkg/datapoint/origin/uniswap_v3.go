@@ -40,6 +40,14 @@ type UniswapV3Config struct {
 	ContractAddresses ContractAddresses
 	Logger            log.Logger
 	Blocks            []int64
+
+	// QuoterAddress is the address of the QuoterV2 contract used to quote
+	// execution prices. It is required if Liquidity is enabled.
+	QuoterAddress types.Address
+
+	// Liquidity configures the origin to quote execution prices for
+	// a given trade size instead of marginal prices.
+	Liquidity LiquidityConfig
 }
 
 type UniswapV3 struct {
@@ -47,6 +55,8 @@ type UniswapV3 struct {
 	contractAddresses ContractAddresses
 	erc20             *ERC20
 	blocks            []int64
+	quoterAddress     types.Address
+	liquidity         LiquidityConfig
 	logger            log.Logger
 }
 
@@ -57,6 +67,9 @@ func NewUniswapV3(config UniswapV3Config) (*UniswapV3, error) {
 	if config.Logger == nil {
 		config.Logger = null.New()
 	}
+	if config.Liquidity.enabled() && config.QuoterAddress == types.ZeroAddress {
+		return nil, fmt.Errorf("quoter address not set")
+	}
 
 	erc20, err := NewERC20(config.Client)
 	if err != nil {
@@ -68,6 +81,8 @@ func NewUniswapV3(config UniswapV3Config) (*UniswapV3, error) {
 		contractAddresses: config.ContractAddresses,
 		erc20:             erc20,
 		blocks:            config.Blocks,
+		quoterAddress:     config.QuoterAddress,
+		liquidity:         config.Liquidity,
 		logger:            config.Logger.WithField("uniswapV3", UniswapV3LoggerTag),
 	}, nil
 }
@@ -230,8 +245,181 @@ func (u *UniswapV3) FetchDataPoints(ctx context.Context, query []any) (map[any]d
 		}
 	}
 
+	// Replace marginal prices with execution prices for pairs with
+	// a configured trade size.
+	swaps := make(map[value.Pair]*swap)
+	for _, pair := range pairs {
+		if points[pair].Error != nil {
+			continue
+		}
+		if swp, ok := u.liquidity.newSwap(tokenDetails[pair.Base], tokenDetails[pair.Quote]); ok {
+			swaps[pair] = swp
+		}
+	}
+	if len(swaps) > 0 {
+		quotedPoints, err := u.quoteDataPoints(ctx, swaps, block)
+		if err != nil {
+			return nil, err
+		}
+		for pair, point := range quotedPoints {
+			points[pair] = point
+		}
+	}
+
 	if len(pairs) == 1 && points[pairs[0]].Error != nil {
 		return points, points[pairs[0]].Error
 	}
 	return points, nil
 }
+
+// quoteDataPoints returns data points with execution prices of the given
+// swaps, simulated using the QuoterV2 contract. The depth of a pool is
+// reported as the volume, which is the balance of the base token in the
+// pool.
+//
+//nolint:funlen
+func (u *UniswapV3) quoteDataPoints(
+	ctx context.Context,
+	swaps map[value.Pair]*swap,
+	block *big.Int,
+) (
+	map[value.Pair]datapoint.Point,
+	error,
+) {
+
+	type quoteParams struct {
+		TokenIn           types.Address `abi:"tokenIn"`
+		TokenOut          types.Address `abi:"tokenOut"`
+		AmountIn          *big.Int      `abi:"amountIn"`
+		Fee               *big.Int      `abi:"fee"`
+		SqrtPriceLimitX96 *big.Int      `abi:"sqrtPriceLimitX96"`
+	}
+
+	pairs := maps.Keys(swaps)
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].String() < pairs[j].String()
+	})
+
+	// Get the fee tier of every pool, which is required by the quoter.
+	var feeCalls []types.Call
+	var balanceCalls []types.Call
+	for _, pair := range pairs {
+		pool, _, _, err := u.contractAddresses.ByPair(pair)
+		if err != nil {
+			return nil, err
+		}
+		callData, err := feeAbi.EncodeArgs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get fee for pair: %s: %w", pair.String(), err)
+		}
+		feeCalls = append(feeCalls, types.Call{
+			To:    &pool,
+			Input: callData,
+		})
+		callData, err = u.erc20.abi.Methods["balanceOf"].EncodeArgs(pool)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get balance for pair: %s: %w", pair.String(), err)
+		}
+		baseToken := swaps[pair].base.address
+		balanceCalls = append(balanceCalls, types.Call{
+			To:    &baseToken,
+			Input: callData,
+		})
+	}
+	resp, err := ethereum.MultiCall(ctx, u.client, feeCalls, types.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	fees := make(map[value.Pair]*big.Int)
+	for i, pair := range pairs {
+		fees[pair] = new(big.Int).SetBytes(resp[i][0:32])
+	}
+
+	totals := make([]*big.Float, len(pairs))
+	depths := make([]*big.Float, len(pairs))
+	errs := make([]error, len(pairs))
+	for i := range pairs {
+		totals[i] = new(big.Float)
+		depths[i] = new(big.Float)
+	}
+
+	for _, blockDelta := range u.blocks {
+		blockNumber := types.BlockNumberFromUint64(uint64(block.Int64() - blockDelta))
+
+		blockSwaps := make([]*swap, len(pairs))
+		for i, pair := range pairs {
+			blockSwaps[i] = &swap{
+				base:     swaps[pair].base,
+				quote:    swaps[pair].quote,
+				sellBase: swaps[pair].sellBase,
+				amountIn: swaps[pair].amountIn,
+			}
+		}
+		prices, priceErrs, err := u.liquidity.quoteSwaps(blockSwaps, func(quoted []*swap) error {
+			var calls []types.Call
+			for _, s := range quoted {
+				callData, err := quoteExactInputSingle.EncodeArgs(quoteParams{
+					TokenIn:           s.tokenIn().address,
+					TokenOut:          s.tokenOut().address,
+					AmountIn:          s.amountIn,
+					Fee:               fees[value.Pair{Base: s.base.symbol, Quote: s.quote.symbol}],
+					SqrtPriceLimitX96: big.NewInt(0),
+				})
+				if err != nil {
+					return fmt.Errorf("failed to get contract args for quoteExactInputSingle: %w", err)
+				}
+				calls = append(calls, types.Call{
+					To:    &u.quoterAddress,
+					Input: callData,
+				})
+			}
+			resp, err := ethereum.MultiCall(ctx, u.client, calls, blockNumber)
+			if err != nil {
+				return err
+			}
+			for i, s := range quoted {
+				s.amountOut = new(big.Int).SetBytes(resp[i][0:32])
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := ethereum.MultiCall(ctx, u.client, balanceCalls, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, pair := range pairs {
+			if priceErrs[i] != nil {
+				errs[i] = priceErrs[i]
+				continue
+			}
+			totals[i] = totals[i].Add(totals[i], prices[i])
+			depths[i] = depths[i].Add(
+				depths[i],
+				tokenAmount(new(big.Int).SetBytes(resp[i][0:32]), swaps[pair].base.decimals),
+			)
+		}
+	}
+
+	points := make(map[value.Pair]datapoint.Point)
+	blocks := new(big.Float).SetUint64(uint64(len(u.blocks)))
+	for i, pair := range pairs {
+		if errs[i] != nil {
+			points[pair] = datapoint.Point{Error: errs[i]}
+			continue
+		}
+		tick := value.NewTick(
+			pair,
+			new(big.Float).Quo(totals[i], blocks),
+			new(big.Float).Quo(depths[i], blocks),
+		)
+		points[pair] = datapoint.Point{
+			Value: tick,
+			Time:  time.Now(),
+		}
+	}
+	return points, nil
+}
@@ -0,0 +1,118 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/defiweb/go-eth/abi"
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
+	"github.com/defiweb/go-eth/wallet"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+)
+
+// barMessageType is the ABI type of the message signed for bar data points.
+// The message is a Keccak256 hash of the ABI encoded tuple.
+var barMessageType = abi.MustParseType(
+	"(bytes32 typ,bytes32 wat,uint256 age,uint256 interval,uint256 open,uint256 high,uint256 low,uint256 close,uint256 volume)",
+)
+
+// BarSigner signs bar data points.
+type BarSigner struct {
+	signer wallet.Key
+}
+
+// NewBarSigner creates a new BarSigner instance.
+func NewBarSigner(signer wallet.Key) *BarSigner {
+	return &BarSigner{signer: signer}
+}
+
+// Supports implements the Signer interface.
+func (b *BarSigner) Supports(_ context.Context, data datapoint.Point) bool {
+	_, ok := data.Value.(value.Bar)
+	return ok
+}
+
+// Sign implements the Signer interface.
+func (b *BarSigner) Sign(_ context.Context, model string, data datapoint.Point) (*types.Signature, error) {
+	msg, err := constructBarMessage(model, data)
+	if err != nil {
+		return nil, err
+	}
+	return b.signer.SignMessage(msg)
+}
+
+// BarRecoverer recovers the signer address from a bar data point and a
+// signature.
+type BarRecoverer struct {
+	recoverer crypto.Recoverer
+}
+
+// NewBarRecoverer creates a new BarRecoverer instance.
+func NewBarRecoverer(recoverer crypto.Recoverer) *BarRecoverer {
+	return &BarRecoverer{recoverer: recoverer}
+}
+
+// Supports implements the Recoverer interface.
+func (b *BarRecoverer) Supports(_ context.Context, data datapoint.Point) bool {
+	_, ok := data.Value.(value.Bar)
+	return ok
+}
+
+// Recover implements the Recoverer interface.
+func (b *BarRecoverer) Recover(
+	_ context.Context,
+	model string,
+	data datapoint.Point,
+	signature types.Signature,
+) (*types.Address, error) {
+	msg, err := constructBarMessage(model, data)
+	if err != nil {
+		return nil, err
+	}
+	return b.recoverer.RecoverMessage(msg, signature)
+}
+
+func constructBarMessage(model string, data datapoint.Point) ([]byte, error) {
+	bar := data.Value.(value.Bar)
+	if err := bar.Validate(); err != nil {
+		return nil, err
+	}
+	volume := big.NewInt(0)
+	if bar.Volume != nil {
+		volume = decimalToBigInt(bar.Volume)
+	}
+	enc, err := abi.EncodeValue(barMessageType, map[string]any{
+		"typ":      stringToBytes32("bar/v1"),
+		"wat":      stringToBytes32(model),
+		"age":      big.NewInt(data.Time.Unix()),
+		"interval": big.NewInt(int64(bar.Interval.Seconds())),
+		"open":     decimalToBigInt(bar.Open),
+		"high":     decimalToBigInt(bar.High),
+		"low":      decimalToBigInt(bar.Low),
+		"close":    decimalToBigInt(bar.Close),
+		"volume":   volume,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode bar message: %w", err)
+	}
+	return crypto.Keccak256(enc).Bytes(), nil
+}
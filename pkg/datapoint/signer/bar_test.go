@@ -0,0 +1,71 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+func testBarPoint(closePrice float64) datapoint.Point {
+	return datapoint.Point{
+		Value: value.Bar{
+			Pair:     value.Pair{Base: "AAA", Quote: "BBB"},
+			Open:     bn.DecFloatPoint(40),
+			High:     bn.DecFloatPoint(45),
+			Low:      bn.DecFloatPoint(39),
+			Close:    bn.DecFloatPoint(closePrice),
+			Interval: time.Hour,
+		},
+		Time: time.Unix(1605371361, 0),
+	}
+}
+
+func TestBar_Supports(t *testing.T) {
+	s := NewBarSigner(privKey)
+	assert.True(t, s.Supports(context.Background(), datapoint.Point{Value: value.Bar{}}))
+	assert.False(t, s.Supports(context.Background(), datapoint.Point{Value: value.Tick{}}))
+}
+
+func TestBar_SignAndRecover(t *testing.T) {
+	signer := NewBarSigner(privKey)
+	recoverer := NewBarRecoverer(crypto.ECRecoverer)
+
+	signature, err := signer.Sign(context.Background(), "AAABBB", testBarPoint(42))
+	require.NoError(t, err)
+
+	address, err := recoverer.Recover(context.Background(), "AAABBB", testBarPoint(42), *signature)
+	require.NoError(t, err)
+	assert.Equal(t, privKey.Address(), *address)
+
+	// A signature must not be valid for a different bar.
+	address, err = recoverer.Recover(context.Background(), "AAABBB", testBarPoint(43), *signature)
+	require.NoError(t, err)
+	assert.NotEqual(t, privKey.Address(), *address)
+
+	// Invalid bars cannot be signed.
+	_, err = signer.Sign(context.Background(), "AAABBB", testBarPoint(50))
+	assert.Error(t, err)
+}
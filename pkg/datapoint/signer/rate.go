@@ -0,0 +1,96 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"context"
+
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
+	"github.com/defiweb/go-eth/wallet"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/contract"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+)
+
+// RateSigner signs rate data points.
+//
+// The period is a part of the signed message, so a rate cannot be presented
+// as a rate for a different period. Rates are signed using the message
+// accepted by the Rate contract, not the Median contract.
+type RateSigner struct {
+	signer wallet.Key
+}
+
+// NewRateSigner creates a new RateSigner instance.
+func NewRateSigner(signer wallet.Key) *RateSigner {
+	return &RateSigner{signer: signer}
+}
+
+// Supports implements the Signer interface.
+func (r *RateSigner) Supports(_ context.Context, data datapoint.Point) bool {
+	_, ok := data.Value.(value.Rate)
+	return ok
+}
+
+// Sign implements the Signer interface.
+func (r *RateSigner) Sign(_ context.Context, model string, data datapoint.Point) (*types.Signature, error) {
+	msg, err := constructRateMessage(model, data)
+	if err != nil {
+		return nil, err
+	}
+	return r.signer.SignMessage(msg)
+}
+
+// RateRecoverer recovers the signer address from a rate data point and a
+// signature.
+type RateRecoverer struct {
+	recoverer crypto.Recoverer
+}
+
+// NewRateRecoverer creates a new RateRecoverer instance.
+func NewRateRecoverer(recoverer crypto.Recoverer) *RateRecoverer {
+	return &RateRecoverer{recoverer: recoverer}
+}
+
+// Supports implements the Recoverer interface.
+func (r *RateRecoverer) Supports(_ context.Context, data datapoint.Point) bool {
+	_, ok := data.Value.(value.Rate)
+	return ok
+}
+
+// Recover implements the Recoverer interface.
+func (r *RateRecoverer) Recover(
+	_ context.Context,
+	model string,
+	data datapoint.Point,
+	signature types.Signature,
+) (*types.Address, error) {
+	msg, err := constructRateMessage(model, data)
+	if err != nil {
+		return nil, err
+	}
+	return r.recoverer.RecoverMessage(msg, signature)
+}
+
+func constructRateMessage(model string, data datapoint.Point) ([]byte, error) {
+	rate := data.Value.(value.Rate)
+	if err := rate.Validate(); err != nil {
+		return nil, err
+	}
+	return contract.ConstructRatePokeMessage(model, rate.Rate, rate.Period, data.Time)
+}
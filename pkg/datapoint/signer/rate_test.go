@@ -0,0 +1,74 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+)
+
+func TestRate_Supports(t *testing.T) {
+	s := NewRateSigner(privKey)
+	assert.True(t, s.Supports(context.Background(), datapoint.Point{Value: value.Rate{}}))
+	assert.False(t, s.Supports(context.Background(), datapoint.Point{Value: value.Tick{}}))
+}
+
+func TestRate_SignAndRecover(t *testing.T) {
+	signer := NewRateSigner(privKey)
+	recoverer := NewRateRecoverer(crypto.ECRecoverer)
+	point := func(rate float64, period time.Duration) datapoint.Point {
+		return datapoint.Point{
+			Value: value.NewRate(rate, period),
+			Time:  time.Unix(1605371361, 0),
+		}
+	}
+
+	signature, err := signer.Sign(context.Background(), "AAABBB", point(42, time.Second))
+	require.NoError(t, err)
+
+	address, err := recoverer.Recover(context.Background(), "AAABBB", point(42, time.Second), *signature)
+	require.NoError(t, err)
+	assert.Equal(t, privKey.Address(), *address)
+
+	// A signature must not be valid for a different period.
+	address, err = recoverer.Recover(context.Background(), "AAABBB", point(42, time.Hour), *signature)
+	require.NoError(t, err)
+	assert.NotEqual(t, privKey.Address(), *address)
+
+	// A signature must not be valid for a different rate.
+	address, err = recoverer.Recover(context.Background(), "AAABBB", point(43, time.Second), *signature)
+	require.NoError(t, err)
+	assert.NotEqual(t, privKey.Address(), *address)
+
+	// Negative rates can be signed.
+	signature, err = signer.Sign(context.Background(), "AAABBB", point(-0.5, time.Second))
+	require.NoError(t, err)
+	address, err = recoverer.Recover(context.Background(), "AAABBB", point(-0.5, time.Second), *signature)
+	require.NoError(t, err)
+	assert.Equal(t, privKey.Address(), *address)
+
+	// Invalid rates cannot be signed.
+	_, err = signer.Sign(context.Background(), "AAABBB", point(42, -time.Second))
+	assert.Error(t, err)
+}
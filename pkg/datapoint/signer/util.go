@@ -0,0 +1,37 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"math/big"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/contract"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+// decimalToBigInt converts a number to a fixed point integer with the same
+// precision as used by the Median contracts.
+func decimalToBigInt(n *bn.DecFloatPointNumber) *big.Int {
+	return n.DecFixedPoint(contract.MedianPricePrecision).RawBigInt()
+}
+
+// stringToBytes32 converts a string to bytes32, truncating it if it is
+// longer than 32 bytes.
+func stringToBytes32(s string) [32]byte {
+	var b [32]byte
+	copy(b[:], s)
+	return b
+}
@@ -0,0 +1,118 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/defiweb/go-eth/abi"
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
+	"github.com/defiweb/go-eth/wallet"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+)
+
+// vectorMessageType is the ABI type of the message signed for vector data
+// points. The message is a Keccak256 hash of the ABI encoded tuple.
+var vectorMessageType = abi.MustParseType(
+	"(bytes32 typ,bytes32 wat,uint256 age,int256[] values,string[] labels)",
+)
+
+// VectorSigner signs vector data points.
+type VectorSigner struct {
+	signer wallet.Key
+}
+
+// NewVectorSigner creates a new VectorSigner instance.
+func NewVectorSigner(signer wallet.Key) *VectorSigner {
+	return &VectorSigner{signer: signer}
+}
+
+// Supports implements the Signer interface.
+func (v *VectorSigner) Supports(_ context.Context, data datapoint.Point) bool {
+	_, ok := data.Value.(value.Vector)
+	return ok
+}
+
+// Sign implements the Signer interface.
+func (v *VectorSigner) Sign(_ context.Context, model string, data datapoint.Point) (*types.Signature, error) {
+	msg, err := constructVectorMessage(model, data)
+	if err != nil {
+		return nil, err
+	}
+	return v.signer.SignMessage(msg)
+}
+
+// VectorRecoverer recovers the signer address from a vector data point and
+// a signature.
+type VectorRecoverer struct {
+	recoverer crypto.Recoverer
+}
+
+// NewVectorRecoverer creates a new VectorRecoverer instance.
+func NewVectorRecoverer(recoverer crypto.Recoverer) *VectorRecoverer {
+	return &VectorRecoverer{recoverer: recoverer}
+}
+
+// Supports implements the Recoverer interface.
+func (v *VectorRecoverer) Supports(_ context.Context, data datapoint.Point) bool {
+	_, ok := data.Value.(value.Vector)
+	return ok
+}
+
+// Recover implements the Recoverer interface.
+func (v *VectorRecoverer) Recover(
+	_ context.Context,
+	model string,
+	data datapoint.Point,
+	signature types.Signature,
+) (*types.Address, error) {
+	msg, err := constructVectorMessage(model, data)
+	if err != nil {
+		return nil, err
+	}
+	return v.recoverer.RecoverMessage(msg, signature)
+}
+
+func constructVectorMessage(model string, data datapoint.Point) ([]byte, error) {
+	vector := data.Value.(value.Vector)
+	if err := vector.Validate(); err != nil {
+		return nil, err
+	}
+	values := make([]*big.Int, len(vector.Values))
+	for i, n := range vector.Values {
+		values[i] = decimalToBigInt(n)
+	}
+	labels := vector.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	enc, err := abi.EncodeValue(vectorMessageType, map[string]any{
+		"typ":    stringToBytes32("vector/v1"),
+		"wat":    stringToBytes32(model),
+		"age":    big.NewInt(data.Time.Unix()),
+		"values": values,
+		"labels": labels,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode vector message: %w", err)
+	}
+	return crypto.Keccak256(enc).Bytes(), nil
+}
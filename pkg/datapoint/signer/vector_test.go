@@ -0,0 +1,63 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+func testVectorPoint(labels ...string) datapoint.Point {
+	return datapoint.Point{
+		Value: value.Vector{
+			Values: []*bn.DecFloatPointNumber{bn.DecFloatPoint(0.05), bn.DecFloatPoint(-0.01)},
+			Labels: labels,
+		},
+		Time: time.Unix(1605371361, 0),
+	}
+}
+
+func TestVector_Supports(t *testing.T) {
+	s := NewVectorSigner(privKey)
+	assert.True(t, s.Supports(context.Background(), datapoint.Point{Value: value.Vector{}}))
+	assert.False(t, s.Supports(context.Background(), datapoint.Point{Value: value.Tick{}}))
+}
+
+func TestVector_SignAndRecover(t *testing.T) {
+	signer := NewVectorSigner(privKey)
+	recoverer := NewVectorRecoverer(crypto.ECRecoverer)
+
+	signature, err := signer.Sign(context.Background(), "AAABBB", testVectorPoint("1m", "3m"))
+	require.NoError(t, err)
+
+	address, err := recoverer.Recover(context.Background(), "AAABBB", testVectorPoint("1m", "3m"), *signature)
+	require.NoError(t, err)
+	assert.Equal(t, privKey.Address(), *address)
+
+	// Labels are part of the signed message.
+	address, err = recoverer.Recover(context.Background(), "AAABBB", testVectorPoint("1m", "6m"), *signature)
+	require.NoError(t, err)
+	assert.NotEqual(t, privKey.Address(), *address)
+}
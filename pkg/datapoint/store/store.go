@@ -20,6 +20,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/defiweb/go-eth/types"
@@ -31,7 +33,9 @@ import (
 	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/negotiator"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/chanutil"
 )
 
 const LoggerTag = "DATA_POINT_STORE"
@@ -92,8 +96,12 @@ type Store struct {
 
 	storage    Storage
 	transport  transport.Service
+	negotiator *negotiator.Negotiator
 	models     []string
 	recoverers []datapoint.Recoverer
+
+	mu          sync.RWMutex
+	modelHashes map[string]map[types.Address]string // model hashes reported by feeds
 }
 
 // Config is the configuration for Storage.
@@ -104,6 +112,11 @@ type Config struct {
 	// Transport is an implementation of transport used to fetch prices from feeds.
 	Transport transport.Service
 
+	// Negotiator is an optional message version negotiator. If set, data
+	// points are received on all versions of the message supported by the
+	// node, otherwise only on the data_point/v1 topic.
+	Negotiator *negotiator.Negotiator
+
 	// Models is the list of models which are supported by the store.
 	Models []string
 
@@ -132,8 +145,11 @@ func New(cfg Config) (*Store, error) {
 		log:        cfg.Logger.WithField("tag", LoggerTag),
 		storage:    cfg.Storage,
 		transport:  cfg.Transport,
+		negotiator: cfg.Negotiator,
 		models:     cfg.Models,
 		recoverers: cfg.Recoverers,
+
+		modelHashes: make(map[string]map[types.Address]string),
 	}
 	return s, nil
 }
@@ -169,6 +185,58 @@ func (p *Store) Latest(ctx context.Context, model string) (map[types.Address]Sto
 	return p.storage.Latest(ctx, model)
 }
 
+// ModelHashes returns the model hashes most recently reported by feeds for
+// the given model, keyed by the feed address. Feeds that do not report model
+// hashes are not included.
+func (p *Store) ModelHashes(model string) map[types.Address]string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	hashes := make(map[types.Address]string, len(p.modelHashes[model]))
+	for from, hash := range p.modelHashes[model] {
+		hashes[from] = hash
+	}
+	return hashes
+}
+
+// trackModelHash stores the model hash reported by a feed and warns if feeds
+// disagree on the model definition. To avoid flooding the logs, the check is
+// performed only when a feed reports a new hash.
+func (p *Store) trackModelHash(sdp StoredDataPoint) {
+	hash, ok := sdp.DataPoint.Meta["model_hash"].(string)
+	if !ok {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	hashes, ok := p.modelHashes[sdp.Model]
+	if !ok {
+		hashes = make(map[types.Address]string)
+		p.modelHashes[sdp.Model] = hashes
+	}
+	if hashes[sdp.From] == hash {
+		return
+	}
+	hashes[sdp.From] = hash
+	var feeds []string
+	for from, h := range hashes {
+		if h != hash {
+			feeds = append(feeds, from.String())
+		}
+	}
+	if len(feeds) > 0 {
+		sort.Strings(feeds)
+		p.log.
+			WithFields(log.Fields{
+				"model":      sdp.Model,
+				"from":       sdp.From.String(),
+				"modelHash":  hash,
+				"otherFeeds": feeds,
+			}).
+			WithAdvice("Feeds probably use different configuration versions; check the data model and origin configs").
+			Warn("Feeds disagree on the data model definition")
+	}
+}
+
 func (p *Store) collectDataPoint(point *messages.DataPoint) {
 	for _, recoverer := range p.recoverers {
 		if recoverer.Supports(p.ctx, point.Point) {
@@ -198,6 +266,7 @@ func (p *Store) collectDataPoint(point *messages.DataPoint) {
 					Error("Unable to add data point to the storage")
 				return
 			}
+			p.trackModelHash(sdp)
 			p.log.
 				WithFields(StoredDataPointLogFields(sdp)).
 				Debug("Data point collected")
@@ -337,13 +406,16 @@ func (p *Store) logDataPointsRoutine() {
 }
 
 func (p *Store) dataPointCollectorRoutine() {
-	dataPointCh := p.transport.Messages(messages.DataPointV1MessageName)
+	dataPointCh := chanutil.NewFanIn[transport.ReceivedMessage]()
+	for _, topic := range p.topics() {
+		_ = dataPointCh.Add(p.transport.Messages(topic))
+	}
 	priceCh := p.transport.Messages(messages.PriceV0MessageName) //nolint:staticcheck
 	for {
 		select {
 		case <-p.ctx.Done():
 			return
-		case msg := <-dataPointCh:
+		case msg := <-dataPointCh.Chan():
 			p.handlePointMessage(msg)
 		case msg := <-priceCh:
 			p.handleLegacyPriceMessage(msg)
@@ -351,6 +423,17 @@ func (p *Store) dataPointCollectorRoutine() {
 	}
 }
 
+// topics returns a list of topics on which data points are received.
+func (p *Store) topics() []string {
+	if p.negotiator == nil {
+		return []string{messages.DataPointV1MessageName}
+	}
+	if topics := p.negotiator.Subscriptions(messages.DataPointMessageName); len(topics) > 0 {
+		return topics
+	}
+	return []string{messages.DataPointV1MessageName}
+}
+
 // contextCancelHandler handles context cancellation.
 func (p *Store) contextCancelHandler() {
 	defer func() { close(p.waitCh) }()
@@ -8,10 +8,12 @@ import (
 
 	"github.com/defiweb/go-eth/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	logMocks "github.com/chronicleprotocol/oracle-suite/pkg/log/mocks"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/local"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
@@ -121,3 +123,46 @@ func TestStore(t *testing.T) {
 	assert.Equal(t, "3", b[types.MustAddressFromHex("0x1111111111111111111111111111111111111111")].DataPoint.Value.Print())
 	assert.Equal(t, "4", b[types.MustAddressFromHex("0x2222222222222222222222222222222222222222")].DataPoint.Value.Print())
 }
+
+func TestStore_ModelHashes(t *testing.T) {
+	logger := logMocks.New()
+	logger.Mock().On("WithField", "tag", LoggerTag).Return(logger)
+
+	store, err := New(Config{
+		Storage:   NewMemoryStorage(),
+		Transport: local.New([]byte("test"), 0, nil),
+		Models:    []string{"AAABBB"},
+		Logger:    logger,
+	})
+	require.NoError(t, err)
+
+	addr1 := types.MustAddressFromHex("0x1111111111111111111111111111111111111111")
+	addr2 := types.MustAddressFromHex("0x2222222222222222222222222222222222222222")
+	sdp := func(from types.Address, hash string) StoredDataPoint {
+		return StoredDataPoint{
+			Model: "AAABBB",
+			From:  from,
+			DataPoint: datapoint.Point{
+				Value: value.StaticValue{Value: bn.DecFloatPoint(1)},
+				Time:  time.Unix(1234567890, 0),
+				Meta:  map[string]any{"model_hash": hash},
+			},
+		}
+	}
+
+	// Feeds agree on the model definition.
+	store.trackModelHash(sdp(addr1, "0x01"))
+	store.trackModelHash(sdp(addr2, "0x01"))
+	assert.Equal(t, map[types.Address]string{addr1: "0x01", addr2: "0x01"}, store.ModelHashes("AAABBB"))
+	logger.Mock().AssertNotCalled(t, "Warn", mock.Anything)
+
+	// One of the feeds changes the model definition, the warning must be
+	// logged only once.
+	logger.Mock().On("WithFields", mock.Anything).Return(logger)
+	logger.Mock().On("WithAdvice", mock.Anything).Return(logger)
+	logger.Mock().On("Warn", mock.Anything).Return()
+	store.trackModelHash(sdp(addr2, "0x02"))
+	store.trackModelHash(sdp(addr2, "0x02"))
+	assert.Equal(t, map[types.Address]string{addr1: "0x01", addr2: "0x02"}, store.ModelHashes("AAABBB"))
+	logger.Mock().AssertNumberOfCalls(t, "Warn", 1)
+}
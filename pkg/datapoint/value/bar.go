@@ -0,0 +1,161 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package value
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+// Bar contains open, high, low and close prices (OHLC) and a volume for
+// a given asset pair over a time interval.
+//
+// Before using this data, you should check if it is valid by calling
+// Bar.Validate() method.
+type Bar struct {
+	// Pair is an asset pair for which this bar is calculated.
+	Pair Pair
+
+	// Open, High, Low and Close are prices for the given asset pair
+	// during the interval.
+	//
+	// Prices are always non-nil if there is no error.
+	Open  *bn.DecFloatPointNumber
+	High  *bn.DecFloatPointNumber
+	Low   *bn.DecFloatPointNumber
+	Close *bn.DecFloatPointNumber
+
+	// Volume is a volume during the interval presented in the base
+	// currency.
+	//
+	// May be nil if the provider does not provide volume.
+	Volume *bn.DecFloatPointNumber
+
+	// Interval is the duration of the bar. The bar ends at the time of the
+	// data point.
+	Interval time.Duration
+}
+
+// Number implements the NumericValue interface. It returns the close price.
+func (b Bar) Number() *bn.FloatNumber {
+	if b.Close == nil {
+		return nil
+	}
+	return b.Close.Float()
+}
+
+// Print implements the Value interface.
+func (b Bar) Print() string {
+	return fmt.Sprintf(
+		"Pair=%s, Open=%s, High=%s, Low=%s, Close=%s, Volume=%s, Interval=%s",
+		b.Pair,
+		printDecFloatPoint(b.Open),
+		printDecFloatPoint(b.High),
+		printDecFloatPoint(b.Low),
+		printDecFloatPoint(b.Close),
+		printDecFloatPoint(b.Volume),
+		b.Interval,
+	)
+}
+
+// Validate returns an error if the bar is invalid.
+func (b Bar) Validate() error {
+	if b.Pair.Empty() {
+		return fmt.Errorf("pair is not set")
+	}
+	if b.Open == nil || b.High == nil || b.Low == nil || b.Close == nil {
+		return fmt.Errorf("price is nil")
+	}
+	if b.Low.Sign() <= 0 {
+		return fmt.Errorf("price is zero or negative")
+	}
+	if b.High.Cmp(b.Low) < 0 ||
+		b.Open.Cmp(b.Low) < 0 || b.Open.Cmp(b.High) > 0 ||
+		b.Close.Cmp(b.Low) < 0 || b.Close.Cmp(b.High) > 0 {
+		return fmt.Errorf("prices are not within the high and low range")
+	}
+	if b.Volume != nil && b.Volume.Sign() < 0 {
+		return fmt.Errorf("volume is negative")
+	}
+	if b.Interval < 0 {
+		return fmt.Errorf("interval is negative")
+	}
+	return nil
+}
+
+func (b Bar) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"pair":     b.Pair.String(),
+		"open":     marshalDecFloatPoint(b.Open),
+		"high":     marshalDecFloatPoint(b.High),
+		"low":      marshalDecFloatPoint(b.Low),
+		"close":    marshalDecFloatPoint(b.Close),
+		"volume":   marshalDecFloatPoint(b.Volume),
+		"interval": b.Interval.String(),
+	})
+}
+
+func (b *Bar) UnmarshalJSON(data []byte) error {
+	var result struct {
+		Pair     string `json:"pair"`
+		Open     string `json:"open"`
+		High     string `json:"high"`
+		Low      string `json:"low"`
+		Close    string `json:"close"`
+		Volume   string `json:"volume"`
+		Interval string `json:"interval"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	pair, err := PairFromString(result.Pair)
+	if err != nil {
+		return err
+	}
+	interval, err := time.ParseDuration(result.Interval)
+	if err != nil {
+		return err
+	}
+	b.Pair = pair
+	b.Open = bn.DecFloatPoint(result.Open)
+	b.High = bn.DecFloatPoint(result.High)
+	b.Low = bn.DecFloatPoint(result.Low)
+	b.Close = bn.DecFloatPoint(result.Close)
+	b.Volume = bn.DecFloatPoint(result.Volume)
+	b.Interval = interval
+	return nil
+}
+
+// printDecFloatPoint returns a human-readable representation of a number
+// or "<nil>" if the number is nil.
+func printDecFloatPoint(n *bn.DecFloatPointNumber) string {
+	if n == nil {
+		return "<nil>"
+	}
+	return n.Text('g', 10)
+}
+
+// marshalDecFloatPoint returns a string representation of a number used in
+// JSON or an empty string if the number is nil.
+func marshalDecFloatPoint(n *bn.DecFloatPointNumber) string {
+	if n == nil {
+		return ""
+	}
+	return n.String()
+}
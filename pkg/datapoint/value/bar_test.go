@@ -0,0 +1,129 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package value
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+func TestBar_Validate(t *testing.T) {
+	validBar := func() Bar {
+		return Bar{
+			Pair:     Pair{Base: "BTC", Quote: "USD"},
+			Open:     bn.DecFloatPoint(100),
+			High:     bn.DecFloatPoint(120),
+			Low:      bn.DecFloatPoint(90),
+			Close:    bn.DecFloatPoint(110),
+			Volume:   bn.DecFloatPoint(10),
+			Interval: time.Hour,
+		}
+	}
+	testCases := []struct {
+		name          string
+		modify        func(b *Bar)
+		errorContains string
+	}{
+		{
+			name:   "valid bar",
+			modify: func(b *Bar) {},
+		},
+		{
+			name:   "volume is nil",
+			modify: func(b *Bar) { b.Volume = nil },
+		},
+		{
+			name:          "pair is not set",
+			modify:        func(b *Bar) { b.Pair = Pair{} },
+			errorContains: "pair is not set",
+		},
+		{
+			name:          "price is nil",
+			modify:        func(b *Bar) { b.Close = nil },
+			errorContains: "price is nil",
+		},
+		{
+			name:          "price is zero",
+			modify:        func(b *Bar) { b.Low = bn.DecFloatPoint(0) },
+			errorContains: "price is zero or negative",
+		},
+		{
+			name:          "close above high",
+			modify:        func(b *Bar) { b.Close = bn.DecFloatPoint(130) },
+			errorContains: "prices are not within the high and low range",
+		},
+		{
+			name:          "open below low",
+			modify:        func(b *Bar) { b.Open = bn.DecFloatPoint(80) },
+			errorContains: "prices are not within the high and low range",
+		},
+		{
+			name:          "volume is negative",
+			modify:        func(b *Bar) { b.Volume = bn.DecFloatPoint(-1) },
+			errorContains: "volume is negative",
+		},
+		{
+			name:          "interval is negative",
+			modify:        func(b *Bar) { b.Interval = -time.Hour },
+			errorContains: "interval is negative",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := validBar()
+			tc.modify(&b)
+			err := b.Validate()
+			if tc.errorContains != "" {
+				assert.ErrorContains(t, err, tc.errorContains)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBar_JSON(t *testing.T) {
+	bar := Bar{
+		Pair:     Pair{Base: "BTC", Quote: "USD"},
+		Open:     bn.DecFloatPoint(100),
+		High:     bn.DecFloatPoint(120),
+		Low:      bn.DecFloatPoint(90),
+		Close:    bn.DecFloatPoint(110.5),
+		Interval: time.Hour,
+	}
+	bts, err := json.Marshal(bar)
+	require.NoError(t, err)
+	assert.JSONEq(
+		t,
+		`{"pair":"BTC/USD","open":"100","high":"120","low":"90","close":"110.5","volume":"","interval":"1h0m0s"}`,
+		string(bts),
+	)
+
+	var decoded Bar
+	require.NoError(t, json.Unmarshal(bts, &decoded))
+	require.NoError(t, decoded.Validate())
+	assert.Equal(t, bar.Pair, decoded.Pair)
+	assert.Equal(t, "110.5", decoded.Close.String())
+	assert.Nil(t, decoded.Volume)
+	assert.Equal(t, time.Hour, decoded.Interval)
+	assert.Equal(t, "110.5", decoded.Number().String())
+}
@@ -0,0 +1,104 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package value
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+// Rate contains an interest rate or a yield, such as the DAI Savings Rate.
+//
+// Before using this data, you should check if it is valid by calling
+// Rate.Validate() method.
+type Rate struct {
+	// Rate is the rate value. Its interpretation depends on the data model,
+	// e.g. it may be a per-second accumulator or an annual percentage yield.
+	//
+	// Rate is always non-nil if there is no error.
+	Rate *bn.DecFloatPointNumber
+
+	// Period is the period over which the rate applies, e.g. one second for
+	// a per-second rate. Zero if the period is not specified.
+	Period time.Duration
+}
+
+// NewRate returns a new Rate for the given rate value and period.
+func NewRate(rate any, period time.Duration) Rate {
+	return Rate{
+		Rate:   bn.DecFloatPoint(rate),
+		Period: period,
+	}
+}
+
+// Number implements the NumericValue interface.
+func (r Rate) Number() *bn.FloatNumber {
+	if r.Rate == nil {
+		return nil
+	}
+	return r.Rate.Float()
+}
+
+// Print implements the Value interface.
+func (r Rate) Print() string {
+	rate := "<nil>"
+	if r.Rate != nil {
+		rate = r.Rate.Text('g', 10)
+	}
+	return fmt.Sprintf("Rate=%s, Period=%s", rate, r.Period)
+}
+
+// Validate returns an error if the rate is invalid.
+func (r Rate) Validate() error {
+	if r.Rate == nil {
+		return fmt.Errorf("rate is nil")
+	}
+	if r.Period < 0 {
+		return fmt.Errorf("period is negative")
+	}
+	return nil
+}
+
+func (r Rate) MarshalJSON() ([]byte, error) {
+	var rate string
+	if r.Rate != nil {
+		rate = r.Rate.String()
+	}
+	return json.Marshal(map[string]any{
+		"rate":   rate,
+		"period": r.Period.String(),
+	})
+}
+
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	var result struct {
+		Rate   string `json:"rate"`
+		Period string `json:"period"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	period, err := time.ParseDuration(result.Period)
+	if err != nil {
+		return err
+	}
+	r.Rate = bn.DecFloatPoint(result.Rate)
+	r.Period = period
+	return nil
+}
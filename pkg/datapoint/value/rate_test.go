@@ -0,0 +1,46 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package value
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRate_Validate(t *testing.T) {
+	assert.NoError(t, NewRate(0.05, 365*24*time.Hour).Validate())
+	assert.NoError(t, NewRate(-0.01, 0).Validate())
+	assert.EqualError(t, Rate{Period: time.Second}.Validate(), "rate is nil")
+	assert.EqualError(t, NewRate(1, -time.Second).Validate(), "period is negative")
+}
+
+func TestRate_JSON(t *testing.T) {
+	rate := NewRate("1.0000000018476949574", time.Second)
+	bts, err := json.Marshal(rate)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"rate":"1.0000000018476949574","period":"1s"}`, string(bts))
+
+	var decoded Rate
+	require.NoError(t, json.Unmarshal(bts, &decoded))
+	assert.Equal(t, rate.Rate.String(), decoded.Rate.String())
+	assert.Equal(t, rate.Period, decoded.Period)
+	assert.Equal(t, "Rate=1.000000002, Period=1s", decoded.Print())
+	assert.Equal(t, rate.Rate.Float().String(), decoded.Number().String())
+}
@@ -0,0 +1,95 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package value
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+// Vector contains multiple values that are published together, such as
+// rates for different terms.
+//
+// Before using this data, you should check if it is valid by calling
+// Vector.Validate() method.
+type Vector struct {
+	// Values is a list of values.
+	Values []*bn.DecFloatPointNumber
+
+	// Labels is an optional list of labels of the values. If set, it must
+	// have the same length as Values.
+	Labels []string
+}
+
+// Print implements the Value interface.
+func (v Vector) Print() string {
+	s := make([]string, len(v.Values))
+	for i, n := range v.Values {
+		if len(v.Labels) == len(v.Values) {
+			s[i] = fmt.Sprintf("%s=%s", v.Labels[i], printDecFloatPoint(n))
+			continue
+		}
+		s[i] = printDecFloatPoint(n)
+	}
+	return fmt.Sprintf("Values=[%s]", strings.Join(s, ", "))
+}
+
+// Validate returns an error if the vector is invalid.
+func (v Vector) Validate() error {
+	if len(v.Values) == 0 {
+		return fmt.Errorf("vector is empty")
+	}
+	for i, n := range v.Values {
+		if n == nil {
+			return fmt.Errorf("value %d is nil", i)
+		}
+	}
+	if len(v.Labels) > 0 && len(v.Labels) != len(v.Values) {
+		return fmt.Errorf("number of labels does not match number of values")
+	}
+	return nil
+}
+
+func (v Vector) MarshalJSON() ([]byte, error) {
+	values := make([]string, len(v.Values))
+	for i, n := range v.Values {
+		values[i] = marshalDecFloatPoint(n)
+	}
+	data := map[string]any{"values": values}
+	if len(v.Labels) > 0 {
+		data["labels"] = v.Labels
+	}
+	return json.Marshal(data)
+}
+
+func (v *Vector) UnmarshalJSON(data []byte) error {
+	var result struct {
+		Values []string `json:"values"`
+		Labels []string `json:"labels"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	v.Values = make([]*bn.DecFloatPointNumber, len(result.Values))
+	for i, s := range result.Values {
+		v.Values[i] = bn.DecFloatPoint(s)
+	}
+	v.Labels = result.Labels
+	return nil
+}
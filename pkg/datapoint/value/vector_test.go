@@ -0,0 +1,55 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package value
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+func TestVector_Validate(t *testing.T) {
+	values := []*bn.DecFloatPointNumber{bn.DecFloatPoint(1), bn.DecFloatPoint(-2)}
+	assert.NoError(t, Vector{Values: values}.Validate())
+	assert.NoError(t, Vector{Values: values, Labels: []string{"a", "b"}}.Validate())
+	assert.EqualError(t, Vector{}.Validate(), "vector is empty")
+	assert.EqualError(t, Vector{Values: []*bn.DecFloatPointNumber{nil}}.Validate(), "value 0 is nil")
+	assert.EqualError(
+		t,
+		Vector{Values: values, Labels: []string{"a"}}.Validate(),
+		"number of labels does not match number of values",
+	)
+}
+
+func TestVector_JSON(t *testing.T) {
+	vector := Vector{
+		Values: []*bn.DecFloatPointNumber{bn.DecFloatPoint(1.5), bn.DecFloatPoint(2)},
+		Labels: []string{"1m", "3m"},
+	}
+	bts, err := json.Marshal(vector)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"values":["1.5","2"],"labels":["1m","3m"]}`, string(bts))
+
+	var decoded Vector
+	require.NoError(t, json.Unmarshal(bts, &decoded))
+	require.NoError(t, decoded.Validate())
+	assert.Equal(t, vector.Labels, decoded.Labels)
+	assert.Equal(t, "Values=[1m=1.5, 3m=2]", decoded.Print())
+}
@@ -0,0 +1,166 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mocks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+	"github.com/defiweb/go-eth/wallet"
+)
+
+// RemoteSigner is a mock of a remote signer that implements the EIP-3030
+// JSON-RPC methods using local keys.
+type RemoteSigner struct {
+	*httptest.Server
+
+	mu    sync.Mutex
+	keys  map[types.Address]wallet.Key
+	delay time.Duration
+}
+
+// NewRemoteSigner starts a new mock remote signer that signs using the
+// given keys. The signer must be closed using the Close method.
+func NewRemoteSigner(keys ...wallet.Key) *RemoteSigner {
+	s := &RemoteSigner{keys: make(map[types.Address]wallet.Key)}
+	for _, k := range keys {
+		s.keys[k.Address()] = k
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// SetDelay sets the delay before every response.
+func (s *RemoteSigner) SetDelay(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delay = d
+}
+
+type remoteSignerRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type remoteSignerError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type remoteSignerResponse struct {
+	JSONRPC string             `json:"jsonrpc"`
+	ID      json.RawMessage    `json:"id"`
+	Result  any                `json:"result,omitempty"`
+	Error   *remoteSignerError `json:"error,omitempty"`
+}
+
+func (s *RemoteSigner) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	delay := s.delay
+	s.mu.Unlock()
+	time.Sleep(delay)
+
+	var req remoteSignerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	res := remoteSignerResponse{JSONRPC: "2.0", ID: req.ID}
+	result, err := s.call(req.Method, req.Params)
+	if err != nil {
+		res.Error = &remoteSignerError{Code: -32000, Message: err.Error()}
+	} else {
+		res.Result = result
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func (s *RemoteSigner) call(method string, params []json.RawMessage) (any, error) {
+	switch method {
+	case "eth_accounts":
+		accounts := make([]types.Address, 0, len(s.keys))
+		for addr := range s.keys {
+			accounts = append(accounts, addr)
+		}
+		return accounts, nil
+	case "eth_sign":
+		var (
+			addr types.Address
+			data types.Bytes
+		)
+		if len(params) != 2 {
+			return nil, fmt.Errorf("invalid params")
+		}
+		if err := json.Unmarshal(params[0], &addr); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(params[1], &data); err != nil {
+			return nil, err
+		}
+		key, ok := s.keys[addr]
+		if !ok {
+			return nil, fmt.Errorf("unknown account %s", addr)
+		}
+		return key.SignMessage(data)
+	case "eth_signTransaction":
+		var (
+			tx     types.Transaction
+			fields struct {
+				Type    *types.Number `json:"type"`
+				ChainID *types.Number `json:"chainId"`
+			}
+		)
+		if len(params) != 1 {
+			return nil, fmt.Errorf("invalid params")
+		}
+		if err := json.Unmarshal(params[0], &tx); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(params[0], &fields); err != nil {
+			return nil, err
+		}
+		if fields.Type != nil {
+			tx.Type = types.TransactionType(fields.Type.Big().Uint64())
+		}
+		if fields.ChainID != nil {
+			tx.SetChainID(fields.ChainID.Big().Uint64())
+		}
+		if tx.From == nil {
+			return nil, fmt.Errorf("missing from field")
+		}
+		key, ok := s.keys[*tx.From]
+		if !ok {
+			return nil, fmt.Errorf("unknown account %s", tx.From)
+		}
+		if err := key.SignTransaction(&tx); err != nil {
+			return nil, err
+		}
+		raw, err := tx.Raw()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"raw": types.Bytes(raw), "tx": tx}, nil
+	default:
+		return nil, fmt.Errorf("method %s not supported", method)
+	}
+}
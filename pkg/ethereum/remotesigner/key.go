@@ -0,0 +1,225 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package remotesigner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/rpc/transport"
+	"github.com/defiweb/go-eth/types"
+	"github.com/defiweb/go-eth/wallet"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// ErrSignHashNotSupported is returned by Key.SignHash because remote signers
+// do not allow to sign arbitrary hashes.
+var ErrSignHashNotSupported = errors.New("remote signer does not support signing hashes")
+
+// Config is the configuration for the Key.
+type Config struct {
+	// URL is the JSON-RPC endpoint of the remote signer.
+	URL string
+
+	// Address is the address of the key managed by the remote signer.
+	Address types.Address
+
+	// Headers are sent with every request to the remote signer.
+	Headers http.Header
+
+	// Timeout is the timeout for a single signing request. If zero, the
+	// default timeout of 10 seconds is used.
+	Timeout time.Duration
+
+	// HTTPClient is the HTTP client used to connect to the remote signer.
+	// If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+}
+
+// Key is a wallet.Key that delegates signing to a remote signer, such as
+// web3signer, using the EIP-3030 JSON-RPC methods. The private key never
+// leaves the remote signer.
+//
+// Signatures returned by the remote signer are verified before they are
+// used, so a misconfigured signer cannot sign data with a different key.
+type Key struct {
+	address   types.Address
+	transport transport.Transport
+	timeout   time.Duration
+}
+
+// New creates a new Key.
+func New(cfg Config) (*Key, error) {
+	if cfg.Address == types.ZeroAddress {
+		return nil, errors.New("address must not be empty")
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	t, err := transport.NewHTTP(transport.HTTPOptions{
+		URL:        cfg.URL,
+		HTTPClient: cfg.HTTPClient,
+		HTTPHeader: cfg.Headers,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Key{
+		address:   cfg.Address,
+		transport: t,
+		timeout:   cfg.Timeout,
+	}, nil
+}
+
+// Verify checks whether the remote signer manages the key.
+func (k *Key) Verify(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, k.timeout)
+	defer cancel()
+	var accounts []types.Address
+	if err := k.transport.Call(ctx, &accounts, "eth_accounts"); err != nil {
+		return fmt.Errorf("remote signer: unable to list accounts: %w", err)
+	}
+	for _, a := range accounts {
+		if a == k.address {
+			return nil
+		}
+	}
+	return fmt.Errorf("remote signer: key %s is not available", k.address)
+}
+
+// Address implements the wallet.Key interface.
+func (k *Key) Address() types.Address {
+	return k.address
+}
+
+// SignHash implements the wallet.Key interface. Remote signers do not
+// support signing hashes, so it always returns ErrSignHashNotSupported.
+func (k *Key) SignHash(_ types.Hash) (*types.Signature, error) {
+	return nil, ErrSignHashNotSupported
+}
+
+// SignMessage implements the wallet.Key interface.
+func (k *Key) SignMessage(data []byte) (*types.Signature, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), k.timeout)
+	defer cancel()
+	var sig types.Signature
+	if err := k.transport.Call(ctx, &sig, "eth_sign", k.address, types.Bytes(data)); err != nil {
+		return nil, fmt.Errorf("remote signer: unable to sign message: %w", err)
+	}
+	if !k.VerifyMessage(data, sig) {
+		return nil, errors.New("remote signer: invalid message signature")
+	}
+	return &sig, nil
+}
+
+// SignTransaction implements the wallet.Key interface.
+func (k *Key) SignTransaction(tx *types.Transaction) error {
+	ctx, cancel := context.WithTimeout(context.Background(), k.timeout)
+	defer cancel()
+	req := tx.Copy()
+	req.From = &k.address
+	var res signTransactionResult
+	if err := k.transport.Call(ctx, &res, "eth_signTransaction", signTransactionRequest{req}); err != nil {
+		return fmt.Errorf("remote signer: unable to sign transaction: %w", err)
+	}
+	var signed types.Transaction
+	if _, err := signed.DecodeRLP(res.Raw); err != nil {
+		return fmt.Errorf("remote signer: invalid signed transaction: %w", err)
+	}
+	if signed.Signature == nil {
+		return errors.New("remote signer: missing transaction signature")
+	}
+	// Only the signature is taken from the response, so the remote signer
+	// cannot modify the transaction. If the signature does not match the
+	// transaction, a different address is recovered.
+	req.Signature = signed.Signature
+	addr, err := crypto.ECRecoverer.RecoverTransaction(req)
+	if err != nil || *addr != k.address {
+		return errors.New("remote signer: invalid transaction signature")
+	}
+	tx.From = &k.address
+	tx.Signature = signed.Signature
+	return nil
+}
+
+// VerifyHash implements the wallet.Key interface.
+func (k *Key) VerifyHash(hash types.Hash, sig types.Signature) bool {
+	addr, err := crypto.ECRecoverer.RecoverHash(hash, sig)
+	if err != nil {
+		return false
+	}
+	return *addr == k.address
+}
+
+// VerifyMessage implements the wallet.Key interface.
+func (k *Key) VerifyMessage(data []byte, sig types.Signature) bool {
+	addr, err := crypto.ECRecoverer.RecoverMessage(data, sig)
+	if err != nil {
+		return false
+	}
+	return *addr == k.address
+}
+
+var _ wallet.Key = (*Key)(nil)
+
+// signTransactionRequest adds fields required by remote signers that are
+// not included in the JSON representation of types.Transaction.
+type signTransactionRequest struct {
+	tx *types.Transaction
+}
+
+func (r signTransactionRequest) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(r.tx)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	fields["type"] = types.NumberFromUint64(uint64(r.tx.Type))
+	if r.tx.ChainID != nil {
+		fields["chainId"] = types.NumberFromUint64(*r.tx.ChainID)
+	}
+	return json.Marshal(fields)
+}
+
+// signTransactionResult is the result of the eth_signTransaction method.
+// Signers return either the raw signed transaction or an object with the
+// "raw" field.
+type signTransactionResult struct {
+	Raw types.Bytes
+}
+
+func (r *signTransactionResult) UnmarshalJSON(input []byte) error {
+	if len(input) >= 2 && input[0] == '"' {
+		return json.Unmarshal(input, &r.Raw)
+	}
+	var res struct {
+		Raw types.Bytes `json:"raw"`
+	}
+	if err := json.Unmarshal(input, &res); err != nil {
+		return err
+	}
+	r.Raw = res.Raw
+	return nil
+}
@@ -0,0 +1,103 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package remotesigner
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+	"github.com/defiweb/go-eth/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
+)
+
+func TestKey_SignMessage(t *testing.T) {
+	local := wallet.NewRandomKey()
+	signer := mocks.NewRemoteSigner(local)
+	defer signer.Close()
+
+	key, err := New(Config{URL: signer.URL, Address: local.Address()})
+	require.NoError(t, err)
+	require.NoError(t, key.Verify(context.Background()))
+
+	sig, err := key.SignMessage([]byte("foo"))
+	require.NoError(t, err)
+	assert.True(t, local.VerifyMessage([]byte("foo"), *sig))
+	assert.True(t, key.VerifyMessage([]byte("foo"), *sig))
+	assert.False(t, key.VerifyMessage([]byte("bar"), *sig))
+}
+
+func TestKey_SignTransaction(t *testing.T) {
+	local := wallet.NewRandomKey()
+	signer := mocks.NewRemoteSigner(local)
+	defer signer.Close()
+
+	key, err := New(Config{URL: signer.URL, Address: local.Address()})
+	require.NoError(t, err)
+
+	to := types.MustAddressFromHex("0x2d800d93b065ce011af83f316cef9f0d005b0aa4")
+	tx := (&types.Transaction{}).
+		SetType(types.DynamicFeeTxType).
+		SetChainID(1).
+		SetNonce(1).
+		SetTo(to).
+		SetGasLimit(21000).
+		SetMaxFeePerGas(big.NewInt(2e9)).
+		SetMaxPriorityFeePerGas(big.NewInt(1e9)).
+		SetValue(big.NewInt(1))
+	require.NoError(t, key.SignTransaction(tx))
+	require.NotNil(t, tx.Signature)
+	assert.Equal(t, local.Address(), *tx.From)
+	assert.Equal(t, to, *tx.To)
+}
+
+func TestKey_UnknownKey(t *testing.T) {
+	signer := mocks.NewRemoteSigner(wallet.NewRandomKey())
+	defer signer.Close()
+
+	key, err := New(Config{URL: signer.URL, Address: wallet.NewRandomKey().Address()})
+	require.NoError(t, err)
+
+	assert.Error(t, key.Verify(context.Background()))
+	_, err = key.SignMessage([]byte("foo"))
+	assert.Error(t, err)
+}
+
+func TestKey_Timeout(t *testing.T) {
+	local := wallet.NewRandomKey()
+	signer := mocks.NewRemoteSigner(local)
+	defer signer.Close()
+	signer.SetDelay(100 * time.Millisecond)
+
+	key, err := New(Config{URL: signer.URL, Address: local.Address(), Timeout: 10 * time.Millisecond})
+	require.NoError(t, err)
+
+	_, err = key.SignMessage([]byte("foo"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestKey_SignHash(t *testing.T) {
+	key, err := New(Config{URL: "http://localhost", Address: wallet.NewRandomKey().Address()})
+	require.NoError(t, err)
+
+	_, err = key.SignHash(types.Hash{})
+	assert.ErrorIs(t, err, ErrSignHashNotSupported)
+}
@@ -19,10 +19,19 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/negotiator"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/sliceutil"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/timeutil"
 
@@ -44,7 +53,19 @@ type Feed struct {
 	signers      []datapoint.Signer
 	hooks        []Hook
 	transport    transport.Service
+	negotiator   *negotiator.Negotiator
 	interval     *timeutil.Ticker
+	models       map[string]ModelConfig
+	schedules    map[*timeutil.Ticker][]string
+
+	mu   sync.Mutex
+	last map[string]lastBroadcast // last broadcast of each data model
+}
+
+// lastBroadcast describes the last broadcast of a data model.
+type lastBroadcast struct {
+	time  time.Time
+	value *bn.FloatNumber
 }
 
 // Config is the configuration for the Feed.
@@ -69,14 +90,44 @@ type Config struct {
 	// the network.
 	Transport transport.Service
 
+	// Negotiator is used to select message versions on which data points
+	// are published. If nil, messages.DataPointV1MessageName is used.
+	Negotiator *negotiator.Negotiator
+
 	// Interval describes how often data points should be sent to the network.
+	// It is used for data models that do not have their own interval
+	// defined in Models.
 	Interval *timeutil.Ticker
 
+	// Models is an optional per-model update schedule. Data models not
+	// listed here are broadcast on every Interval tick.
+	Models map[string]ModelConfig
+
 	// Logger is a current logger interface used by the Feed.
 	// If nil, null logger will be used.
 	Logger log.Logger
 }
 
+// ModelConfig describes the update schedule of a single data model.
+type ModelConfig struct {
+	// Interval describes how often the data point is fetched. If nil,
+	// Config.Interval is used. Models that use the same ticker are fetched
+	// together.
+	Interval *timeutil.Ticker
+
+	// Deviation is the minimum change of the value since the last broadcast
+	// required to broadcast the data point again. A deviation is represented
+	// as a percentage point, e.g. 1 means 1%. If zero, the data point is
+	// broadcast on every tick.
+	Deviation float64
+
+	// Heartbeat is the maximum time between broadcasts. When exceeded, the
+	// data point is broadcast regardless of the deviation. It is used only
+	// if Deviation is set, in which case it is required, so that a stable
+	// value is still broadcast before it becomes stale.
+	Heartbeat time.Duration
+}
+
 type Hook interface {
 	BeforeSign(ctx context.Context, dp *datapoint.Point) error
 	BeforeBroadcast(ctx context.Context, dp *datapoint.Point) error
@@ -99,9 +150,31 @@ func New(cfg Config) (*Feed, error) {
 	if len(cfg.Signers) == 0 {
 		return nil, errors.New("at least one signer must be provided")
 	}
+	if cfg.Interval == nil {
+		return nil, errors.New("interval must not be nil")
+	}
+	for model, mc := range cfg.Models {
+		if mc.Deviation < 0 {
+			return nil, fmt.Errorf("deviation for the %s data model must not be negative", model)
+		}
+		if mc.Heartbeat < 0 {
+			return nil, fmt.Errorf("heartbeat for the %s data model must not be negative", model)
+		}
+		if mc.Deviation > 0 && mc.Heartbeat == 0 {
+			return nil, fmt.Errorf("heartbeat for the %s data model must be set together with deviation", model)
+		}
+	}
 	if cfg.Logger == nil {
 		cfg.Logger = null.New()
 	}
+	schedules := map[*timeutil.Ticker][]string{cfg.Interval: nil}
+	for _, model := range cfg.DataModels {
+		ticker := cfg.Interval
+		if mc, ok := cfg.Models[model]; ok && mc.Interval != nil {
+			ticker = mc.Interval
+		}
+		schedules[ticker] = append(schedules[ticker], model)
+	}
 	f := &Feed{
 		waitCh:       make(chan error),
 		log:          cfg.Logger.WithField("tag", LoggerTag),
@@ -110,7 +183,11 @@ func New(cfg Config) (*Feed, error) {
 		signers:      cfg.Signers,
 		hooks:        cfg.Hooks,
 		transport:    cfg.Transport,
+		negotiator:   cfg.Negotiator,
 		interval:     cfg.Interval,
+		models:       cfg.Models,
+		schedules:    schedules,
+		last:         make(map[string]lastBroadcast),
 	}
 	return f, nil
 }
@@ -130,8 +207,18 @@ func (f *Feed) Start(ctx context.Context) error {
 			"interval":   f.interval.Duration(),
 		}).
 		Debug("Starting")
-	f.interval.Start(f.ctx)
-	go f.broadcasterRoutine()
+	for ticker, models := range f.schedules {
+		if ticker != f.interval {
+			f.log.
+				WithFields(log.Fields{
+					"dataModels": models,
+					"interval":   ticker.Duration(),
+				}).
+				Debug("Using custom interval")
+		}
+		ticker.Start(f.ctx)
+		go f.broadcasterRoutine(ticker, models)
+	}
 	go f.contextCancelHandler()
 	return nil
 }
@@ -141,9 +228,11 @@ func (f *Feed) Wait() <-chan error {
 	return f.waitCh
 }
 
-// broadcast sends data point to the network.
-func (f *Feed) broadcast(model string, point datapoint.Point) {
+// broadcast sends data point to the network. It returns true if the data
+// point was broadcast on at least one topic.
+func (f *Feed) broadcast(model string, point datapoint.Point) bool {
 	found := false
+	sent := false
 	for _, signer := range f.signers {
 		if !signer.Supports(f.ctx, point) {
 			continue
@@ -153,13 +242,22 @@ func (f *Feed) broadcast(model string, point datapoint.Point) {
 		// BeforeSign hook.
 		for _, hook := range f.hooks {
 			if err := hook.BeforeSign(f.ctx, &point); err != nil {
+				if errors.Is(err, ErrRefused) {
+					f.log.
+						WithError(err).
+						WithField("model", model).
+						WithFields(datapoint.PointLogFields(point)).
+						WithAdvice("Sources disagree more than allowed; check the origins used by the data model").
+						Warn("Data point refused by the BeforeSign hook; data point will not be broadcasted")
+					return false
+				}
 				f.log.
 					WithError(err).
 					WithField("model", model).
 					WithFields(datapoint.PointLogFields(point)).
 					WithAdvice("This is a bug and must be investigated").
 					Error("BeforeSign hook failed; data point will not be broadcasted")
-				return
+				return false
 			}
 		}
 
@@ -172,6 +270,7 @@ func (f *Feed) broadcast(model string, point datapoint.Point) {
 				WithFields(datapoint.PointLogFields(point)).
 				WithAdvice("This is a bug and must be investigated").
 				Error("Failed to sign the data point; data point will not be broadcasted")
+			return false
 		}
 
 		// BeforeBroadcast hook.
@@ -183,7 +282,7 @@ func (f *Feed) broadcast(model string, point datapoint.Point) {
 					WithFields(datapoint.PointLogFields(point)).
 					WithAdvice("This is a bug and must be investigated").
 					Error("BeforeBroadcast hook failed; data point will not be broadcasted")
-				return
+				return false
 			}
 		}
 
@@ -193,16 +292,21 @@ func (f *Feed) broadcast(model string, point datapoint.Point) {
 			Point:          point,
 			ECDSASignature: *sig,
 		}
-		if err := f.transport.Broadcast(messages.DataPointV1MessageName, msg); err != nil {
-			f.log.
-				WithError(err).
-				WithFields(messages.DataPointMessageLogFields(*msg)).
-				WithAdvice("Ignore if it is related to temporary network issues").
-				Error("Failed to broadcast the data point")
-		} else {
-			f.log.
-				WithFields(messages.DataPointMessageLogFields(*msg)).
-				Info("Data point successfully broadcasted")
+		for _, topic := range f.topics() {
+			if err := f.transport.Broadcast(topic, msg); err != nil {
+				f.log.
+					WithError(err).
+					WithField("topic", topic).
+					WithFields(messages.DataPointMessageLogFields(*msg)).
+					WithAdvice("Ignore if it is related to temporary network issues").
+					Error("Failed to broadcast the data point")
+			} else {
+				sent = true
+				f.log.
+					WithField("topic", topic).
+					WithFields(messages.DataPointMessageLogFields(*msg)).
+					Info("Data point successfully broadcasted")
+			}
 		}
 	}
 	if !found {
@@ -212,18 +316,87 @@ func (f *Feed) broadcast(model string, point datapoint.Point) {
 			WithAdvice("This is a bug and must be investigated, probably caused by adding an invalid data model in the config").
 			Warn("No signer algorithm found for the data point")
 	}
+	return sent
 }
 
-func (f *Feed) broadcasterRoutine() {
+// topics returns a list of topics on which data points are published.
+func (f *Feed) topics() []string {
+	if f.negotiator == nil {
+		return []string{messages.DataPointV1MessageName}
+	}
+	if topics := f.negotiator.Topics(messages.DataPointMessageName); len(topics) > 0 {
+		return topics
+	}
+	return []string{messages.DataPointV1MessageName}
+}
+
+// shouldBroadcast checks if the data point should be broadcast at the
+// given time according to the model's deviation and heartbeat settings.
+func (f *Feed) shouldBroadcast(model string, at time.Time, point datapoint.Point) bool {
+	mc, ok := f.models[model]
+	if !ok || mc.Deviation == 0 {
+		return true
+	}
+	nv, ok := point.Value.(value.NumericValue)
+	if !ok {
+		return true
+	}
+	f.mu.Lock()
+	last, ok := f.last[model]
+	f.mu.Unlock()
+	if !ok {
+		return true
+	}
+	if mc.Heartbeat > 0 && at.Sub(last.time) >= mc.Heartbeat {
+		return true
+	}
+	return calculateDeviation(nv.Number(), last.value) >= mc.Deviation
+}
+
+// recordBroadcast stores the time and value of the last broadcast of the
+// data model.
+func (f *Feed) recordBroadcast(model string, at time.Time, point datapoint.Point) {
+	nv, ok := point.Value.(value.NumericValue)
+	if !ok {
+		return
+	}
+	f.mu.Lock()
+	f.last[model] = lastBroadcast{time: at, value: nv.Number()}
+	f.mu.Unlock()
+}
+
+// modelHashes returns hashes of the given data models. If hashes cannot be
+// fetched, nil is returned and data points are broadcast without them.
+func (f *Feed) modelHashes(models []string) map[string]types.Hash {
+	dataModels, err := f.dataProvider.Models(f.ctx, models...)
+	if err != nil {
+		f.log.
+			WithError(err).
+			WithField("models", models).
+			Warn("Failed to fetch data models from provider; data points will be broadcasted without model hashes")
+		return nil
+	}
+	hashes := make(map[string]types.Hash, len(dataModels))
+	for model, dataModel := range dataModels {
+		hashes[model] = dataModel.Hash
+	}
+	return hashes
+}
+
+func (f *Feed) broadcasterRoutine(ticker *timeutil.Ticker, dataModels []string) {
 	for {
 		select {
 		case <-f.ctx.Done():
 			return
-		case <-f.interval.TickCh():
+		case at := <-ticker.TickCh():
+			if len(dataModels) == 0 {
+				continue
+			}
+
 			// Fetch data points from the data provider.
 			models := sliceutil.Intersect(
 				f.dataProvider.ModelNames(f.ctx),
-				f.dataModels,
+				dataModels,
 			)
 			points, err := f.dataProvider.DataPoints(f.ctx, models...)
 			if err != nil {
@@ -234,6 +407,10 @@ func (f *Feed) broadcasterRoutine() {
 				continue
 			}
 
+			// Fetch model hashes, so others can verify that the data points
+			// are calculated using the same model definitions.
+			hashes := f.modelHashes(models)
+
 			// Send data points to the network.
 			for model, point := range points {
 				if err := point.Validate(); err != nil {
@@ -256,12 +433,37 @@ func (f *Feed) broadcasterRoutine() {
 					}
 					continue
 				}
-				f.broadcast(model, point)
+				if !f.shouldBroadcast(model, at, point) {
+					f.log.
+						WithField("model", model).
+						WithFields(datapoint.PointLogFields(point)).
+						Debug("Deviation is below the threshold; data point will not be broadcasted")
+					continue
+				}
+				if hash := hashes[model]; !hash.IsZero() {
+					if point.Meta == nil {
+						point.Meta = map[string]any{}
+					}
+					point.Meta["model_hash"] = hash.String()
+				}
+				if f.broadcast(model, point) {
+					f.recordBroadcast(model, at, point)
+				}
 			}
 		}
 	}
 }
 
+// calculateDeviation calculates the absolute change between two values as
+// a percentage point.
+func calculateDeviation(new, old *bn.FloatNumber) float64 {
+	if old.Sign() == 0 {
+		return math.Inf(1)
+	}
+	deviation, _ := new.Sub(old).Div(old).Mul(bn.Float(100)).Abs().BigFloat().Float64()
+	return deviation
+}
+
 func (f *Feed) contextCancelHandler() {
 	defer func() { close(f.waitCh) }()
 	defer f.log.Info("Stopped")
@@ -18,6 +18,7 @@ package feed
 import (
 	"context"
 	"errors"
+	"math"
 	"testing"
 	"time"
 
@@ -39,6 +40,7 @@ import (
 var (
 	testSignature = types.MustSignatureFromHex("00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff00")
 	testAddress   = types.MustAddressFromHex("0x00112233445566778899aabbccddeeff00112233")
+	testModelHash = types.MustHashFromHex("0x00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff", types.PadNone)
 )
 
 type mockSigner struct{}
@@ -82,6 +84,10 @@ func TestFeed_Broadcast(t *testing.T) {
 					map[string]datapoint.Point{"AAABBB": point},
 					nil,
 				)
+				p.On("Models", mock.Anything, []string{"AAABBB"}).Return(
+					map[string]datapoint.Model{"AAABBB": {Hash: testModelHash}},
+					nil,
+				)
 				p.On("DataPoint", mock.Anything, "AAABBB").Return(
 					point,
 					nil,
@@ -93,6 +99,7 @@ func TestFeed_Broadcast(t *testing.T) {
 				assert.Equal(t, "42", dataPoints[0].Point.Value.Print())
 				assert.Equal(t, time.Unix(100, 0), dataPoints[0].Point.Time)
 				assert.Equal(t, testSignature, dataPoints[0].ECDSASignature)
+				assert.Equal(t, testModelHash.String(), dataPoints[0].Point.Meta["model_hash"])
 			},
 			expectedMessages: 1,
 		},
@@ -111,6 +118,10 @@ func TestFeed_Broadcast(t *testing.T) {
 					map[string]datapoint.Point{"AAABBB": point},
 					nil,
 				)
+				p.On("Models", mock.Anything, []string{"AAABBB", "CCCDDD"}).Return(
+					map[string]datapoint.Model{},
+					errors.New("not found"),
+				)
 				p.On("DataPoint", mock.Anything, "AAABBB").Return(
 					point,
 					nil,
@@ -216,3 +227,112 @@ func TestFeed_Start(t *testing.T) {
 
 	ctxCancel()
 }
+
+func TestFeed_Models(t *testing.T) {
+	ctx, ctxCancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer ctxCancel()
+
+	// Setup test environment.
+	defaultTicker := timeutil.NewTicker(0)
+	modelTicker := timeutil.NewTicker(0)
+	dataProvider := &dataMocks.Provider{}
+	localTransport := local.New([]byte("test"), 4, map[string]transport.Message{
+		messages.DataPointV1MessageName: (*messages.DataPoint)(nil),
+	})
+
+	// Prepare mocks.
+	point := func(v float64) datapoint.Point {
+		return datapoint.Point{
+			Value: value.StaticValue{Value: bn.DecFloatPoint(v)},
+			Time:  time.Unix(100, 0),
+		}
+	}
+	dataProvider.On("ModelNames", mock.Anything).Return([]string{"AAABBB", "CCCDDD"})
+	dataProvider.On("DataPoints", mock.Anything, []string{"CCCDDD"}).Return(map[string]datapoint.Point{"CCCDDD": point(100)}, nil).Once()
+	dataProvider.On("DataPoints", mock.Anything, []string{"CCCDDD"}).Return(map[string]datapoint.Point{"CCCDDD": point(100.5)}, nil).Once()
+	dataProvider.On("DataPoints", mock.Anything, []string{"CCCDDD"}).Return(map[string]datapoint.Point{"CCCDDD": point(101.5)}, nil).Once()
+	dataProvider.On("DataPoints", mock.Anything, []string{"CCCDDD"}).Return(map[string]datapoint.Point{"CCCDDD": point(101.5)}, nil).Once()
+	dataProvider.On("DataPoints", mock.Anything, []string{"CCCDDD"}).Return(map[string]datapoint.Point{"CCCDDD": point(101)}, nil).Once()
+	dataProvider.On("DataPoints", mock.Anything, []string{"AAABBB"}).Return(map[string]datapoint.Point{"AAABBB": point(42)}, nil).Once()
+	dataProvider.On("Models", mock.Anything, mock.Anything).Return(map[string]datapoint.Model{}, nil)
+
+	// Start feed.
+	feed, err := New(Config{
+		DataModels:   []string{"AAABBB", "CCCDDD"},
+		DataProvider: dataProvider,
+		Signers:      []datapoint.Signer{mockSigner{}},
+		Transport:    localTransport,
+		Interval:     defaultTicker,
+		Models: map[string]ModelConfig{
+			"CCCDDD": {
+				Interval:  modelTicker,
+				Deviation: 1,
+				Heartbeat: time.Minute,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, localTransport.Start(ctx))
+	require.NoError(t, feed.Start(ctx))
+	defer func() {
+		ctxCancel()
+		<-feed.Wait()
+		<-localTransport.Wait()
+	}()
+
+	// Wait for services to start.
+	time.Sleep(time.Millisecond * 100)
+
+	msgCh := localTransport.Messages(messages.DataPointV1MessageName)
+
+	// The first data point is always broadcast.
+	modelTicker.TickAt(time.Unix(100, 0))
+	// Deviation is below 1%, the data point is skipped.
+	modelTicker.TickAt(time.Unix(110, 0))
+	// Deviation is above 1%, the data point is broadcast.
+	modelTicker.TickAt(time.Unix(120, 0))
+	// The value did not change, but the heartbeat has passed.
+	modelTicker.TickAt(time.Unix(180, 0))
+	// Deviation is below 1% and the heartbeat has not passed.
+	modelTicker.TickAt(time.Unix(190, 0))
+	// The model without a custom schedule uses the default interval.
+	defaultTicker.TickAt(time.Unix(200, 0))
+
+	// Get messages.
+	var dataPoints []*messages.DataPoint
+	for len(dataPoints) < 4 {
+		msg := <-msgCh
+		dataPoints = append(dataPoints, msg.Message.(*messages.DataPoint))
+	}
+
+	// Check that the broadcasted messages meet the expectations.
+	assert.Equal(t, "CCCDDD", dataPoints[0].Model)
+	assert.Equal(t, "100", dataPoints[0].Point.Value.Print())
+	assert.Equal(t, "CCCDDD", dataPoints[1].Model)
+	assert.Equal(t, "101.5", dataPoints[1].Point.Value.Print())
+	assert.Equal(t, "CCCDDD", dataPoints[2].Model)
+	assert.Equal(t, "101.5", dataPoints[2].Point.Value.Print())
+	assert.Equal(t, "AAABBB", dataPoints[3].Model)
+	assert.Equal(t, "42", dataPoints[3].Point.Value.Print())
+}
+
+func TestNew_DeviationWithoutHeartbeat(t *testing.T) {
+	_, err := New(Config{
+		DataModels:   []string{"AAABBB"},
+		DataProvider: &dataMocks.Provider{},
+		Signers:      []datapoint.Signer{mockSigner{}},
+		Transport:    local.New([]byte("test"), 0, nil),
+		Interval:     timeutil.NewTicker(time.Minute),
+		Models: map[string]ModelConfig{
+			"AAABBB": {Deviation: 1},
+		},
+	})
+	require.Error(t, err)
+}
+
+func TestCalculateDeviation(t *testing.T) {
+	assert.Equal(t, 1.0, calculateDeviation(bn.Float(101), bn.Float(100)))
+	assert.Equal(t, 1.0, calculateDeviation(bn.Float(99), bn.Float(100)))
+	assert.Equal(t, 0.0, calculateDeviation(bn.Float(100), bn.Float(100)))
+	assert.True(t, math.IsInf(calculateDeviation(bn.Float(1), bn.Float(0)), 1))
+}
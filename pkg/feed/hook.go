@@ -17,12 +17,17 @@ package feed
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
 )
 
+// ErrRefused is returned by hooks that refuse to sign a data point because
+// it does not meet the required quality.
+var ErrRefused = errors.New("data point refused")
+
 // TickPrecisionHook is a hook that limits the precision of the price and volume
 // of a tick.
 //
@@ -132,3 +137,89 @@ func buildTraceMap(dp datapoint.Point) map[string]string {
 
 	return trace
 }
+
+// DispersionLimits defines the bounds of the dispersion of sources used to
+// calculate a data point. Zero values disable the corresponding limit.
+type DispersionLimits struct {
+	// MaxStdDev is the maximum relative standard deviation.
+	MaxStdDev float64
+
+	// MaxMAD is the maximum relative median absolute deviation.
+	MaxMAD float64
+
+	// MaxSpread is the maximum relative difference between the largest and
+	// the smallest value.
+	MaxSpread float64
+
+	// MinValidSources is the minimum number of sources with valid values.
+	MinValidSources int
+
+	// MinVolumeCoverage is the minimum fraction of the volume covered by
+	// sources with valid values. It is ignored if sources do not report
+	// volumes.
+	MinVolumeCoverage float64
+}
+
+// DispersionHook is a hook that refuses to sign data points whose sources
+// disagree more than the configured limits allow.
+//
+// The limits are checked against every aggregated data point in the tree,
+// so an indirect price is refused if any of its legs exceeds them. Data
+// points without dispersion statistics are not checked.
+type DispersionHook struct {
+	limits DispersionLimits
+}
+
+// NewDispersionHook creates a new DispersionHook instance.
+func NewDispersionHook(limits DispersionLimits) *DispersionHook {
+	return &DispersionHook{limits: limits}
+}
+
+// BeforeSign implements the Hook interface.
+func (h *DispersionHook) BeforeSign(_ context.Context, dp *datapoint.Point) error {
+	for _, d := range findDispersions(*dp) {
+		if err := h.check(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// check returns an error if the dispersion exceeds the limits.
+func (h *DispersionHook) check(d datapoint.Dispersion) error {
+	l := h.limits
+	switch {
+	case l.MaxStdDev > 0 && d.StdDev > l.MaxStdDev:
+		return fmt.Errorf("%w: standard deviation %g exceeds %g", ErrRefused, d.StdDev, l.MaxStdDev)
+	case l.MaxMAD > 0 && d.MAD > l.MaxMAD:
+		return fmt.Errorf("%w: median absolute deviation %g exceeds %g", ErrRefused, d.MAD, l.MaxMAD)
+	case l.MaxSpread > 0 && d.Spread > l.MaxSpread:
+		return fmt.Errorf("%w: spread %g exceeds %g", ErrRefused, d.Spread, l.MaxSpread)
+	case l.MinValidSources > 0 && d.ValidSources < l.MinValidSources:
+		return fmt.Errorf("%w: %d valid sources, required %d", ErrRefused, d.ValidSources, l.MinValidSources)
+	case l.MinVolumeCoverage > 0 && d.VolumeCoverage != nil && *d.VolumeCoverage < l.MinVolumeCoverage:
+		return fmt.Errorf("%w: volume coverage %g is below %g", ErrRefused, *d.VolumeCoverage, l.MinVolumeCoverage)
+	}
+	return nil
+}
+
+// BeforeBroadcast implements the Hook interface.
+func (h *DispersionHook) BeforeBroadcast(_ context.Context, _ *datapoint.Point) error {
+	return nil
+}
+
+// findDispersions returns the dispersions of all data points in the given
+// data point tree, starting from the root.
+func findDispersions(dp datapoint.Point) []datapoint.Dispersion {
+	var dispersions []datapoint.Dispersion
+	queue := []datapoint.Point{dp}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if d, ok := datapoint.DispersionFromMeta(p); ok {
+			dispersions = append(dispersions, d)
+		}
+		queue = append(queue, p.SubPoints...)
+	}
+	return dispersions
+}
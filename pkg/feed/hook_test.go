@@ -97,3 +97,72 @@ func TestTickTraceHook_BeforeBroadcast(t *testing.T) {
 	}
 	assert.Equal(t, expectedTrace, dp.Meta["trace"])
 }
+
+func TestDispersionHook_BeforeSign(t *testing.T) {
+	coverage := 0.5
+	point := func(d any) *datapoint.Point {
+		return &datapoint.Point{
+			Meta: map[string]any{"type": "reference"},
+			SubPoints: []datapoint.Point{
+				{Meta: map[string]any{"type": "median", datapoint.DispersionMetaKey: d}},
+			},
+		}
+	}
+	dispersion := datapoint.Dispersion{
+		StdDev:         0.02,
+		MAD:            0.01,
+		Spread:         0.05,
+		ValidSources:   3,
+		TotalSources:   4,
+		VolumeCoverage: &coverage,
+	}
+	tests := []struct {
+		name    string
+		limits  DispersionLimits
+		point   *datapoint.Point
+		refused bool
+	}{
+		{name: "no limits", limits: DispersionLimits{}, point: point(dispersion)},
+		{name: "within limits", limits: DispersionLimits{MaxStdDev: 0.03, MaxSpread: 0.1, MinValidSources: 3}, point: point(dispersion)},
+		{name: "stddev", limits: DispersionLimits{MaxStdDev: 0.01}, point: point(dispersion), refused: true},
+		{name: "mad", limits: DispersionLimits{MaxMAD: 0.005}, point: point(dispersion), refused: true},
+		{name: "spread", limits: DispersionLimits{MaxSpread: 0.04}, point: point(dispersion), refused: true},
+		{name: "sources", limits: DispersionLimits{MinValidSources: 4}, point: point(dispersion), refused: true},
+		{name: "volume coverage", limits: DispersionLimits{MinVolumeCoverage: 0.6}, point: point(dispersion), refused: true},
+		{name: "no dispersion", limits: DispersionLimits{MaxStdDev: 0.01}, point: &datapoint.Point{}},
+		{
+			// Every leg of an indirect price must be checked, not only the
+			// one closest to the root.
+			name:   "nested",
+			limits: DispersionLimits{MaxStdDev: 0.03},
+			point: &datapoint.Point{
+				Meta: map[string]any{"type": "indirect"},
+				SubPoints: []datapoint.Point{
+					{Meta: map[string]any{"type": "median", datapoint.DispersionMetaKey: dispersion}},
+					{
+						Meta:      map[string]any{"type": "reference"},
+						SubPoints: []datapoint.Point{*point(datapoint.Dispersion{StdDev: 0.04})},
+					},
+				},
+			},
+			refused: true,
+		},
+		{
+			// Meta of data points received from other feeds is decoded from JSON.
+			name:    "decoded meta",
+			limits:  DispersionLimits{MaxStdDev: 0.01},
+			point:   point(map[string]any{"stddev": 0.02, "mad": 0.01}),
+			refused: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewDispersionHook(tt.limits).BeforeSign(context.Background(), tt.point)
+			if tt.refused {
+				assert.ErrorIs(t, err, ErrRefused)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// JSONError is the response body sent by JSONMethod handlers on errors.
+type JSONError struct {
+	Error string `json:"error"`
+}
+
+// JSONMethod wraps a handler function, so it responds only to the given
+// HTTP method and encodes the result as JSON. Errors returned by the
+// function are sent as JSONError with the 400 status.
+func JSONMethod(method string, fn func(r *http.Request) (any, error)) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		if r.Method != method {
+			rw.WriteHeader(http.StatusMethodNotAllowed)
+			_ = json.NewEncoder(rw).Encode(JSONError{Error: "method not allowed"})
+			return
+		}
+		res, err := fn(r)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(rw).Encode(JSONError{Error: err.Error()})
+			return
+		}
+		_ = json.NewEncoder(rw).Encode(res)
+	}
+}
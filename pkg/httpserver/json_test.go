@@ -0,0 +1,70 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpserver
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONMethod(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		err        error
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "success",
+			method:     http.MethodGet,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"foo":"bar"}` + "\n",
+		},
+		{
+			name:       "error",
+			method:     http.MethodGet,
+			err:        errors.New("invalid request"),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"invalid request"}` + "\n",
+		},
+		{
+			name:       "method not allowed",
+			method:     http.MethodPost,
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   `{"error":"method not allowed"}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := JSONMethod(http.MethodGet, func(r *http.Request) (any, error) {
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				return map[string]string{"foo": "bar"}, nil
+			})
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, "/", nil))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			assert.Equal(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
@@ -81,3 +81,24 @@ func TestLogger_DebugLevel(t *testing.T) {
 	assert.NotEmpty(t, recordedLogFields[0]["duration"])
 	assert.NotEmpty(t, recordedLogFields[0]["remoteAddr"])
 }
+
+func TestLogger_DebugLevel_EventStream(t *testing.T) {
+	var recordedLogFields []log.Fields
+	l := callback.New(log.Debug, func(level log.Level, fields log.Fields, msg string) {
+		recordedLogFields = append(recordedLogFields, fields)
+	})
+
+	r := httptest.NewRequest("GET", "/stream", nil)
+	w := httptest.NewRecorder()
+	h := (&Logger{Log: l}).Handle(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		writer.Write([]byte("data: foo\n\n"))
+		writer.(http.Flusher).Flush()
+	}))
+	h.ServeHTTP(w, r)
+
+	require.Len(t, recordedLogFields, 1)
+	assert.Equal(t, "", recordedLogFields[0]["response"])
+	assert.Equal(t, "data: foo\n\n", w.Body.String())
+	assert.True(t, w.Flushed)
+}
@@ -18,6 +18,7 @@ package middleware
 import (
 	"bytes"
 	"io"
+	"mime"
 	"net/http"
 )
 
@@ -45,7 +46,11 @@ func (r *recorder) Header() http.Header {
 }
 
 func (r *recorder) Write(buf []byte) (int, error) {
-	r.body.Write(buf)
+	// Event streams are not recorded because they may never end, so the
+	// buffer would grow for the whole lifetime of the connection.
+	if !isEventStream(r.rw.Header()) {
+		r.body.Write(buf)
+	}
 	return r.rw.Write(buf)
 }
 
@@ -54,6 +59,21 @@ func (r *recorder) WriteHeader(code int) {
 	r.rw.WriteHeader(code)
 }
 
+// Flush implements the http.Flusher interface, so streaming responses work
+// when the recorder is used.
+func (r *recorder) Flush() {
+	if f, ok := r.rw.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// isEventStream returns true if the headers describe a server-sent events
+// response.
+func isEventStream(h http.Header) bool {
+	mediaType, _, _ := mime.ParseMediaType(h.Get("Content-Type"))
+	return mediaType == "text/event-stream"
+}
+
 func readRequest(r *http.Request) []byte {
 	b, _ := io.ReadAll(r.Body)
 	r.Body = io.NopCloser(bytes.NewReader(b))
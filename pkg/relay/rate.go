@@ -0,0 +1,278 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/defiweb/go-eth/hexutil"
+	"github.com/defiweb/go-eth/types"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/contract"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/store"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/timeutil"
+)
+
+// rateWorker relays rates, e.g. the DAI Savings Rate, to the Rate contract.
+//
+// It works like the medianWorker, but it accepts only rate data points
+// signed for the configured period.
+type rateWorker struct {
+	log            log.Logger
+	dataPointStore store.DataPointProvider
+	feedAddresses  []types.Address
+	contract       RateContract
+	dataModel      string
+	period         time.Duration
+	spread         float64
+	expiration     time.Duration
+	ticker         *timeutil.Ticker
+}
+
+func (w *rateWorker) workerRoutine(ctx context.Context) {
+	w.ticker.Start(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.ticker.TickCh():
+			w.tryUpdate(ctx)
+		}
+	}
+}
+
+func (w *rateWorker) tryUpdate(ctx context.Context) {
+	// Current rate.
+	cur, err := w.contract.Read(ctx)
+	if err != nil {
+		w.log.
+			WithError(err).
+			WithFields(w.logFields()).
+			WithAdvice("Ignore if it is related to temporary network issues").
+			Error("Failed to get current rate from the Rate contract")
+		return
+	}
+
+	// Quorum.
+	bar, err := w.contract.Bar(ctx)
+	if err != nil {
+		w.log.
+			WithError(err).
+			WithFields(w.logFields()).
+			WithAdvice("Ignore if it is related to temporary network issues").
+			Error("Failed to get quorum from the Rate contract")
+		return
+	}
+
+	// Load data points from the store.
+	rates, ages, signatures, ok := w.findRates(ctx, cur.Age, bar)
+	if !ok {
+		return
+	}
+
+	median := calculateMedian(append([]*bn.DecFloatPointNumber{}, rates...))
+	spread := calculateSpread(median, cur.Val.DecFloatPoint())
+
+	// Check if the rate on the Rate contract needs to be updated.
+	// The rate needs to be updated if:
+	// - Rate is older than the interval specified in the expiration field.
+	// - Rate differs from the current rate by more than is specified in the
+	//   Spread field.
+	// - Rate on the contract applies to a different period.
+	isExpired := time.Since(cur.Age) >= w.expiration
+	isStale := math.IsInf(spread, 0) || spread >= w.spread || cur.Period != w.period
+
+	// Print logs.
+	w.log.
+		WithFields(w.logFields()).
+		WithFields(log.Fields{
+			"bar":              bar,
+			"age":              cur.Age,
+			"val":              cur.Val,
+			"currentPeriod":    cur.Period,
+			"expired":          isExpired,
+			"stale":            isStale,
+			"expiration":       w.expiration,
+			"spread":           w.spread,
+			"timeToExpiration": time.Since(cur.Age).String(),
+			"currentSpread":    spread,
+		}).
+		Debug("Rate worker")
+
+	// If rate is stale or expired, send update.
+	if isExpired || isStale {
+		vals := make([]contract.RateVal, len(rates))
+		for i := range rates {
+			vals[i] = contract.RateVal{
+				Val: rates[i].DecFixedPoint(contract.RatePrecision),
+				Age: ages[i],
+				V:   uint8(signatures[i].V.Uint64()),
+				R:   signatures[i].R,
+				S:   signatures[i].S,
+			}
+		}
+
+		// Send *actual* transaction.
+		txHash, tx, err := w.contract.Poke(ctx, w.period, vals)
+		if err != nil {
+			w.handlePokeErr(err)
+			return
+		}
+
+		w.log.
+			WithFields(w.logFields()).
+			WithFields(log.Fields{
+				"txHash":                 txHash,
+				"txType":                 tx.Type,
+				"txFrom":                 tx.From,
+				"txTo":                   tx.To,
+				"txChainId":              tx.ChainID,
+				"txNonce":                tx.Nonce,
+				"txGasPrice":             tx.GasPrice,
+				"txGasLimit":             tx.GasLimit,
+				"txMaxFeePerGas":         tx.MaxFeePerGas,
+				"txMaxPriorityFeePerGas": tx.MaxPriorityFeePerGas,
+				"txInput":                hexutil.BytesToHex(tx.Input),
+			}).
+			Info("Poke transaction sent to the Rate contract")
+	}
+}
+
+func (w *rateWorker) findRates(
+	ctx context.Context,
+	after time.Time,
+	quorum int,
+) ([]*bn.DecFloatPointNumber, []time.Time, []types.Signature, bool) {
+
+	// Generate slice of random indices to select data points from.
+	// It is important to select data points randomly to avoid promoting
+	// any particular feed.
+	randIndices, err := randomInts(len(w.feedAddresses))
+	if err != nil {
+		w.log.
+			WithError(err).
+			WithFields(w.logFields()).
+			WithAdvice("This is a bug and needs to be investigated").
+			Error("Failed to generate random indices")
+		return nil, nil, nil, false
+	}
+
+	// Try to get data points from the store from the feeds in the random order
+	// until we get enough data points to satisfy the quorum.
+	var (
+		rates      []*bn.DecFloatPointNumber
+		ages       []time.Time
+		signatures []types.Signature
+	)
+	for _, i := range randIndices {
+		sdp, ok, err := w.dataPointStore.LatestFrom(ctx, w.feedAddresses[i], w.dataModel)
+		if err != nil {
+			w.log.
+				WithError(err).
+				WithFields(w.logFields()).
+				WithField("feedAddress", w.feedAddresses[i]).
+				WithAdvice("Ignore if occurs occasionally").
+				Warn("Failed to get data point")
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if sdp.Signature.V == nil || sdp.Signature.R == nil || sdp.Signature.S == nil {
+			continue
+		}
+		rate, ok := sdp.DataPoint.Value.(value.Rate)
+		if !ok {
+			w.log.
+				WithFields(w.logFields()).
+				WithField("feedAddress", w.feedAddresses[i]).
+				WithAdvice("This is probably caused by setting a wrong data model for this contract").
+				Error("Data point is not a rate")
+			continue
+		}
+		if rate.Period != w.period {
+			w.log.
+				WithFields(w.logFields()).
+				WithFields(log.Fields{
+					"feedAddress": w.feedAddresses[i],
+					"period":      rate.Period,
+				}).
+				WithAdvice("The period of the data model used by the feed differs from the period configured for this contract").
+				Error("Rate period does not match")
+			continue
+		}
+		if sdp.DataPoint.Time.Before(after) {
+			continue
+		}
+		rates = append(rates, rate.Rate)
+		ages = append(ages, sdp.DataPoint.Time)
+		signatures = append(signatures, sdp.Signature)
+		if len(rates) == quorum {
+			break
+		}
+	}
+	if len(rates) != quorum {
+		w.log.
+			WithFields(w.logFields()).
+			WithFields(log.Fields{
+				"quorum": quorum,
+				"found":  len(rates),
+			}).
+			WithAdvice("Ignore if occurs during the first few minutes after the start of the relay").
+			Warn("Unable to obtain enough data points")
+		return nil, nil, nil, false
+	}
+
+	return rates, ages, signatures, true
+}
+
+func (w *rateWorker) handlePokeErr(err error) {
+	if strings.Contains(err.Error(), "replacement transaction underpriced") {
+		w.log.
+			WithError(err).
+			WithFields(w.logFields()).
+			WithAdvice("This is expected during large rate movements; the relay tries to update multiple contracts at once").
+			Warn("Failed to poke the Rate contract; previous transaction is still pending")
+		return
+	}
+	if contract.IsRevert(err) {
+		w.log.
+			WithError(err).
+			WithFields(w.logFields()).
+			WithAdvice("Probably caused by a race condition between multiple relays; if this is a case, no action is required").
+			Error("Failed to poke the Rate contract")
+		return
+	}
+	w.log.
+		WithError(err).
+		WithFields(w.logFields()).
+		WithAdvice("Ignore if it is related to temporary network issues").
+		Error("Failed to poke the Rate contract")
+}
+
+func (w *rateWorker) logFields() log.Fields {
+	return log.Fields{
+		"contractAddress": w.contract.Address(),
+		"dataModel":       w.dataModel,
+		"period":          w.period,
+	}
+}
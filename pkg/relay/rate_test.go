@@ -0,0 +1,190 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/contract"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/store"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+func TestRateWorker(t *testing.T) {
+	testFeed1 := types.MustAddressFromHex("0x1111111111111111111111111111111111111111")
+	testFeed2 := types.MustAddressFromHex("0x2222222222222222222222222222222222222222")
+	mockLogger := newMockLogger(t)
+	mockContract := newMockRateContract(t)
+	mockStore := newMockDataPointProvider(t)
+
+	rw := &rateWorker{
+		log:            mockLogger,
+		dataPointStore: mockStore,
+		feedAddresses:  []types.Address{testFeed1, testFeed2},
+		contract:       mockContract,
+		dataModel:      "DSR",
+		period:         time.Second,
+		spread:         5,
+		expiration:     10 * time.Minute,
+	}
+
+	rateFrom := func(rates map[types.Address]value.Rate) func(context.Context, types.Address, string) (store.StoredDataPoint, bool, error) {
+		return func(ctx context.Context, from types.Address, model string) (store.StoredDataPoint, bool, error) {
+			rate, ok := rates[from]
+			if !ok {
+				return store.StoredDataPoint{}, false, nil
+			}
+			return store.StoredDataPoint{
+				Model: "DSR",
+				DataPoint: datapoint.Point{
+					Time:  time.Now(),
+					Value: rate,
+				},
+				From:      from,
+				Signature: types.SignatureFromVRS(big.NewInt(27), big.NewInt(1), big.NewInt(2)),
+			}, true, nil
+		}
+	}
+
+	t.Run("above spread", func(t *testing.T) {
+		mockLogger.reset(t)
+		mockContract.reset(t)
+
+		ctx := context.Background()
+		mockContract.AddressFn = func() types.Address { return types.Address{} }
+		mockContract.ReadFn = func(ctx context.Context) (contract.RateData, error) {
+			return contract.RateData{
+				Val:    bn.DecFixedPoint(0.01, contract.RatePrecision),
+				Period: time.Second,
+				Age:    time.Now().Add(-1 * time.Minute),
+			}, nil
+		}
+		mockContract.BarFn = func(ctx context.Context) (int, error) { return 1, nil }
+		mockLogger.InfoFn = func(args ...any) {}
+		mockLogger.DebugFn = func(args ...any) {}
+		mockStore.LatestFromFn = rateFrom(map[types.Address]value.Rate{
+			testFeed1: value.NewRate(-0.02, time.Second),
+		})
+
+		pokeCalled := false
+		mockContract.PokeFn = func(ctx context.Context, period time.Duration, vals []contract.RateVal) (*types.Hash, *types.Transaction, error) {
+			pokeCalled = true
+			assert.Equal(t, time.Second, period)
+			assert.Equal(t, 1, len(vals))
+			assert.Equal(t, bn.DecFixedPoint(-0.02, contract.RatePrecision).String(), vals[0].Val.String())
+			assert.Equal(t, uint8(27), vals[0].V)
+			return types.HashFromBigIntPtr(big.NewInt(1)), &types.Transaction{}, nil
+		}
+
+		rw.tryUpdate(ctx)
+		assert.True(t, pokeCalled, "poke should have been called")
+	})
+
+	t.Run("within spread", func(t *testing.T) {
+		mockLogger.reset(t)
+		mockContract.reset(t)
+
+		ctx := context.Background()
+		mockContract.AddressFn = func() types.Address { return types.Address{} }
+		mockContract.ReadFn = func(ctx context.Context) (contract.RateData, error) {
+			return contract.RateData{
+				Val:    bn.DecFixedPoint(1, contract.RatePrecision),
+				Period: time.Second,
+				Age:    time.Now().Add(-1 * time.Minute),
+			}, nil
+		}
+		mockContract.BarFn = func(ctx context.Context) (int, error) { return 1, nil }
+		mockLogger.DebugFn = func(args ...any) {}
+		mockStore.LatestFromFn = rateFrom(map[types.Address]value.Rate{
+			testFeed1: value.NewRate(1.01, time.Second),
+		})
+
+		rw.tryUpdate(ctx)
+	})
+
+	t.Run("different period on contract", func(t *testing.T) {
+		mockLogger.reset(t)
+		mockContract.reset(t)
+
+		ctx := context.Background()
+		mockContract.AddressFn = func() types.Address { return types.Address{} }
+		mockContract.ReadFn = func(ctx context.Context) (contract.RateData, error) {
+			return contract.RateData{
+				Val:    bn.DecFixedPoint(1, contract.RatePrecision),
+				Period: time.Hour,
+				Age:    time.Now().Add(-1 * time.Minute),
+			}, nil
+		}
+		mockContract.BarFn = func(ctx context.Context) (int, error) { return 1, nil }
+		mockLogger.InfoFn = func(args ...any) {}
+		mockLogger.DebugFn = func(args ...any) {}
+		mockStore.LatestFromFn = rateFrom(map[types.Address]value.Rate{
+			testFeed1: value.NewRate(1, time.Second),
+		})
+
+		pokeCalled := false
+		mockContract.PokeFn = func(ctx context.Context, period time.Duration, vals []contract.RateVal) (*types.Hash, *types.Transaction, error) {
+			pokeCalled = true
+			assert.Equal(t, time.Second, period)
+			return types.HashFromBigIntPtr(big.NewInt(1)), &types.Transaction{}, nil
+		}
+
+		rw.tryUpdate(ctx)
+		assert.True(t, pokeCalled, "poke should have been called")
+	})
+
+	t.Run("rates for other periods and ticks are skipped", func(t *testing.T) {
+		mockLogger.reset(t)
+		mockContract.reset(t)
+
+		ctx := context.Background()
+		mockContract.AddressFn = func() types.Address { return types.Address{} }
+		mockContract.ReadFn = func(ctx context.Context) (contract.RateData, error) {
+			return contract.RateData{
+				Val:    bn.DecFixedPoint(1, contract.RatePrecision),
+				Period: time.Second,
+				Age:    time.Now().Add(-15 * time.Minute),
+			}, nil
+		}
+		mockContract.BarFn = func(ctx context.Context) (int, error) { return 1, nil }
+		errCount := 0
+		mockLogger.ErrorFn = func(args ...any) { errCount++ }
+		mockLogger.WarnFn = func(args ...any) {}
+		mockStore.LatestFromFn = func(ctx context.Context, from types.Address, model string) (store.StoredDataPoint, bool, error) {
+			var v value.Value = value.NewRate(1, time.Hour)
+			if from == testFeed2 {
+				v = value.Tick{Price: bn.DecFloatPoint(1)}
+			}
+			return store.StoredDataPoint{
+				Model:     "DSR",
+				DataPoint: datapoint.Point{Time: time.Now(), Value: v},
+				From:      from,
+				Signature: types.SignatureFromVRS(big.NewInt(27), big.NewInt(1), big.NewInt(2)),
+			}, true, nil
+		}
+
+		rw.tryUpdate(ctx)
+		assert.Equal(t, 2, errCount)
+	})
+}
@@ -50,6 +50,22 @@ type MedianContract interface {
 	)
 }
 
+type RateContract interface {
+	Address() types.Address
+	Read(ctx context.Context) (contract.RateData, error)
+	Wat(ctx context.Context) (string, error)
+	Bar(ctx context.Context) (int, error)
+	Poke(
+		ctx context.Context,
+		period time.Duration,
+		vals []contract.RateVal,
+	) (
+		*types.Hash,
+		*types.Transaction,
+		error,
+	)
+}
+
 type ScribeContract interface {
 	Address() types.Address
 	Wat(ctx context.Context) (string, error)
@@ -88,6 +104,7 @@ type Relay struct {
 	log    log.Logger
 
 	medians   []*medianWorker
+	rates     []*rateWorker
 	scribes   []*scribeWorker
 	opScribes []*opScribeWorker
 }
@@ -97,6 +114,9 @@ type Config struct {
 	// Medians is the list of median contracts configured for the relay.
 	Medians []ConfigMedian
 
+	// Rates is the list of rate contracts configured for the relay.
+	Rates []ConfigRate
+
 	// Scribes is the list of scribe contracts configured for the relay.
 	Scribes []ConfigScribe
 
@@ -140,6 +160,41 @@ type ConfigMedian struct {
 	Ticker *timeutil.Ticker
 }
 
+type ConfigRate struct {
+	// Client is the RPC client used to interact with the blockchain.
+	Client rpc.RPC
+
+	// DataPointStore is the store used to retrieve data points.
+	DataPointStore datapointStore.DataPointProvider
+
+	// DataModel is the name of the data model from which rate data points
+	// are retrieved.
+	DataModel string
+
+	// ContractAddress is the address of the Rate contract.
+	ContractAddress types.Address
+
+	// FeedAddresses is the list of feed addresses that are allowed to
+	// update the Rate contract.
+	FeedAddresses []types.Address
+
+	// Period is the period over which the rate applies. Only rates signed
+	// for this period are relayed.
+	Period time.Duration
+
+	// Spread is the minimum spread between the oracle rate and new
+	// rate required to send update.
+	Spread float64
+
+	// Expiration is the minimum time difference between the last oracle
+	// update on the Rate contract and current time required to send
+	// update.
+	Expiration time.Duration
+
+	// Ticker notifies the relay to check if an update is required.
+	Ticker *timeutil.Ticker
+}
+
 type ConfigScribe struct {
 	// Client is the RPC client used to interact with the blockchain.
 	Client rpc.RPC
@@ -219,6 +274,19 @@ func New(cfg Config) (*Relay, error) {
 			ticker:         m.Ticker,
 		})
 	}
+	for _, c := range cfg.Rates {
+		r.rates = append(r.rates, &rateWorker{
+			log:            logger,
+			dataPointStore: c.DataPointStore,
+			feedAddresses:  c.FeedAddresses,
+			contract:       contract.NewRate(c.Client, c.ContractAddress),
+			dataModel:      c.DataModel,
+			period:         c.Period,
+			spread:         c.Spread,
+			expiration:     c.Expiration,
+			ticker:         c.Ticker,
+		})
+	}
 	for _, s := range cfg.Scribes {
 		r.scribes = append(r.scribes, &scribeWorker{
 			log:        logger,
@@ -258,6 +326,9 @@ func (m *Relay) Start(ctx context.Context) error {
 	for _, w := range m.medians {
 		go w.workerRoutine(ctx)
 	}
+	for _, w := range m.rates {
+		go w.workerRoutine(ctx)
+	}
 	for _, w := range m.scribes {
 		go w.workerRoutine(ctx)
 	}
@@ -208,6 +208,63 @@ func (m *mockMedianContract) Poke(ctx context.Context, vals []contract.MedianVal
 	return m.PokeFn(ctx, vals)
 }
 
+type mockRateContract struct {
+	AddressFn func() types.Address
+	ReadFn    func(ctx context.Context) (contract.RateData, error)
+	BarFn     func(ctx context.Context) (int, error)
+	WatFn     func(ctx context.Context) (string, error)
+	PokeFn    func(ctx context.Context, period time.Duration, vals []contract.RateVal) (*types.Hash, *types.Transaction, error)
+}
+
+func newMockRateContract(t *testing.T) *mockRateContract {
+	mc := &mockRateContract{}
+	mc.reset(t)
+	return mc
+}
+
+func (m *mockRateContract) reset(t *testing.T) {
+	m.AddressFn = func() types.Address {
+		assert.FailNow(t, "unexpected call to Address")
+		return types.Address{}
+	}
+	m.ReadFn = func(ctx context.Context) (contract.RateData, error) {
+		assert.FailNow(t, "unexpected call to Read")
+		return contract.RateData{}, nil
+	}
+	m.BarFn = func(ctx context.Context) (int, error) {
+		assert.FailNow(t, "unexpected call to Bar")
+		return 0, nil
+	}
+	m.WatFn = func(ctx context.Context) (string, error) {
+		assert.FailNow(t, "unexpected call to Wat")
+		return "", nil
+	}
+	m.PokeFn = func(ctx context.Context, period time.Duration, vals []contract.RateVal) (*types.Hash, *types.Transaction, error) {
+		assert.FailNow(t, "unexpected call to Poke")
+		return nil, nil, nil
+	}
+}
+
+func (m *mockRateContract) Address() types.Address {
+	return m.AddressFn()
+}
+
+func (m *mockRateContract) Read(ctx context.Context) (contract.RateData, error) {
+	return m.ReadFn(ctx)
+}
+
+func (m *mockRateContract) Bar(ctx context.Context) (int, error) {
+	return m.BarFn(ctx)
+}
+
+func (m *mockRateContract) Wat(ctx context.Context) (string, error) {
+	return m.WatFn(ctx)
+}
+
+func (m *mockRateContract) Poke(ctx context.Context, period time.Duration, vals []contract.RateVal) (*types.Hash, *types.Transaction, error) {
+	return m.PokeFn(ctx, period, vals)
+}
+
 // Assume mockScribeContract, mockMuSigStore, and mockTicker are similar to the mock structures used in previous tests.
 type mockScribeContract struct {
 	AddressFn func() types.Address
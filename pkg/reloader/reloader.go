@@ -0,0 +1,185 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package reloader
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/httpserver"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+)
+
+const LoggerTag = "RELOADER"
+
+// AdminReloadPath is the path of the admin API endpoint that triggers
+// reloading. It accepts only POST requests.
+const AdminReloadPath = "/reload"
+
+const reloadTimeout = time.Minute
+
+// Config is the configuration for the Reloader.
+type Config struct {
+	// Reload is a function that reloads the configuration. If it returns
+	// an error, the previous configuration must be kept.
+	Reload func(ctx context.Context) error
+
+	// Signals is a list of signals that trigger reloading. If empty,
+	// SIGHUP is used.
+	Signals []os.Signal
+
+	// ListenAddr is the address of the admin API that allows to trigger
+	// reloading. If empty, the admin API is disabled.
+	ListenAddr string
+
+	// Logger is a current logger interface used by the Reloader.
+	// If nil, null logger will be used.
+	Logger log.Logger
+}
+
+// Reloader is a service that reloads the configuration when a signal is
+// received or when requested using the admin API.
+type Reloader struct {
+	mu      sync.Mutex
+	ctx     context.Context
+	waitCh  chan error
+	reload  func(ctx context.Context) error
+	signals []os.Signal
+	srv     *httpserver.HTTPServer
+	log     log.Logger
+}
+
+type adminResponse struct {
+	Error string `json:"error,omitempty"`
+}
+
+// New creates a new Reloader instance.
+func New(cfg Config) (*Reloader, error) {
+	if cfg.Reload == nil {
+		return nil, errors.New("reload function must not be nil")
+	}
+	if len(cfg.Signals) == 0 {
+		cfg.Signals = []os.Signal{syscall.SIGHUP}
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = null.New()
+	}
+	r := &Reloader{
+		waitCh:  make(chan error),
+		reload:  cfg.Reload,
+		signals: cfg.Signals,
+		log:     cfg.Logger.WithField("tag", LoggerTag),
+	}
+	if cfg.ListenAddr != "" {
+		mux := http.NewServeMux()
+		mux.HandleFunc(AdminReloadPath, r.handleReload)
+		r.srv = httpserver.New(&http.Server{
+			Addr:              cfg.ListenAddr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		})
+	}
+	return r, nil
+}
+
+// Start implements the supervisor.Service interface.
+func (r *Reloader) Start(ctx context.Context) error {
+	if r.ctx != nil {
+		return errors.New("service can be started only once")
+	}
+	if ctx == nil {
+		return errors.New("context must not be nil")
+	}
+	r.log.Debug("Starting")
+	r.ctx = ctx
+	if r.srv != nil {
+		if err := r.srv.Start(ctx); err != nil {
+			return err
+		}
+	}
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, r.signals...)
+	go r.signalRoutine(sigCh)
+	go r.contextCancelHandler(sigCh)
+	return nil
+}
+
+// Wait implements the supervisor.Service interface.
+func (r *Reloader) Wait() <-chan error {
+	return r.waitCh
+}
+
+// Reload reloads the configuration. Concurrent calls are serialized.
+func (r *Reloader) Reload(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.log.Info("Reloading configuration")
+	ctx, ctxCancel := context.WithTimeout(ctx, reloadTimeout)
+	defer ctxCancel()
+	if err := r.reload(ctx); err != nil {
+		r.log.
+			WithError(err).
+			WithAdvice("The previous configuration is still in use, fix the configuration and try again").
+			Error("Unable to reload configuration")
+		return err
+	}
+	r.log.Info("Configuration reloaded")
+	return nil
+}
+
+func (r *Reloader) handleReload(rw http.ResponseWriter, req *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+	if req.Method != http.MethodPost {
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+		_ = json.NewEncoder(rw).Encode(adminResponse{Error: "method not allowed"})
+		return
+	}
+	if err := r.Reload(req.Context()); err != nil {
+		rw.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(rw).Encode(adminResponse{Error: err.Error()})
+		return
+	}
+	_ = json.NewEncoder(rw).Encode(adminResponse{})
+}
+
+func (r *Reloader) signalRoutine(sigCh chan os.Signal) {
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-sigCh:
+			_ = r.Reload(r.ctx)
+		}
+	}
+}
+
+func (r *Reloader) contextCancelHandler(sigCh chan os.Signal) {
+	<-r.ctx.Done()
+	signal.Stop(sigCh)
+	if r.srv != nil {
+		<-r.srv.Wait()
+	}
+	r.log.Debug("Stopped")
+	close(r.waitCh)
+}
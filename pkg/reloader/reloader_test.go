@@ -0,0 +1,95 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package reloader
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloader_Signal(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	var calls int32
+	r, err := New(Config{
+		Reload: func(context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		},
+		Signals: []os.Signal{syscall.SIGUSR1},
+	})
+	require.NoError(t, err)
+	require.NoError(t, r.Start(ctx))
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&calls) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	ctxCancel()
+	select {
+	case <-r.Wait():
+	case <-time.After(time.Second):
+		require.Fail(t, "reloader did not stop")
+	}
+}
+
+func TestReloader_Admin(t *testing.T) {
+	fail := false
+	r, err := New(Config{
+		Reload: func(context.Context) error {
+			if fail {
+				return errors.New("invalid config")
+			}
+			return nil
+		},
+		ListenAddr: "127.0.0.1:0",
+	})
+	require.NoError(t, err)
+
+	rw := httptest.NewRecorder()
+	r.handleReload(rw, httptest.NewRequest(http.MethodPost, AdminReloadPath, nil))
+	assert.Equal(t, http.StatusOK, rw.Code)
+
+	fail = true
+	rw = httptest.NewRecorder()
+	r.handleReload(rw, httptest.NewRequest(http.MethodPost, AdminReloadPath, nil))
+	assert.Equal(t, http.StatusUnprocessableEntity, rw.Code)
+	var res adminResponse
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &res))
+	assert.Equal(t, "invalid config", res.Error)
+
+	rw = httptest.NewRecorder()
+	r.handleReload(rw, httptest.NewRequest(http.MethodGet, AdminReloadPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rw.Code)
+}
+
+func TestNew_NilReload(t *testing.T) {
+	_, err := New(Config{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,58 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpcsplitter
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/httpserver"
+)
+
+// Paths of the admin API endpoints:
+const (
+	AdminEndpointsPath     = "/endpoints"
+	AdminDisagreementsPath = "/disagreements"
+)
+
+// NewAdminHandler returns the HTTP handler for the admin API of the given
+// RPC-Splitter handler, which must be created by NewServer.
+//
+// The admin API exposes statistics of the endpoints and recorded
+// disagreements. It should be served on a separate address that is not
+// available publicly.
+func NewAdminHandler(h http.Handler) (http.Handler, error) {
+	s, ok := h.(*server)
+	if !ok {
+		return nil, fmt.Errorf("rpc-splitter error: handler must be created by NewServer")
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(AdminEndpointsPath, httpserver.JSONMethod(http.MethodGet, func(r *http.Request) (any, error) {
+		return s.reputation.stats(), nil
+	}))
+	mux.HandleFunc(AdminDisagreementsPath, httpserver.JSONMethod(http.MethodGet, func(r *http.Request) (any, error) {
+		var limit int
+		if l := r.URL.Query().Get("limit"); l != "" {
+			var err error
+			if limit, err = strconv.Atoi(l); err != nil || limit < 0 {
+				return nil, fmt.Errorf("invalid limit: %s", l)
+			}
+		}
+		return s.forensics.query(r.URL.Query().Get("method"), r.URL.Query().Get("endpoint"), limit), nil
+	}))
+	return mux, nil
+}
@@ -0,0 +1,291 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpcsplitter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+)
+
+// batchFlushDelay is the maximum time a call may wait in the queue before
+// the batch is sent to the endpoint.
+const batchFlushDelay = 10 * time.Millisecond
+
+// maxBatchItems is the maximum number of items in a batch request. It is the
+// same as the default limit used by the go-ethereum RPC server.
+const maxBatchItems = 1000
+
+// maxBatchConcurrency is the maximum number of batch items that are handled
+// concurrently.
+const maxBatchConcurrency = 16
+
+// maxRequestContentLength is the maximum size of a request body. It is the
+// same as the limit used by the go-ethereum RPC server.
+const maxRequestContentLength = 1024 * 1024 * 5
+
+// batchCaller is implemented by callers that support batch requests.
+type batchCaller interface {
+	BatchCallContext(ctx context.Context, b []gethRPC.BatchElem) error
+}
+
+type batchCtxKey struct{}
+
+// batch collects calls made to endpoints while handling a JSON-RPC batch
+// request, so that they can be sent to each endpoint as a single batch
+// request instead of separate requests.
+//
+// Calls are queued until all batch items that are still being handled wait
+// for a response from the endpoint, or until batchFlushDelay expires.
+// Identical calls, e.g. eth_blockNumber calls used to resolve block tags,
+// are sent only once.
+type batch struct {
+	mu         sync.Mutex
+	timeout    time.Duration          // timeout for batch requests
+	flushDelay time.Duration          // maximum time a call may wait in the queue
+	pending    int                    // number of batch items that are being handled
+	queues     map[string]*batchQueue // queues of calls for each endpoint
+}
+
+type batchQueue struct {
+	caller  batchCaller
+	calls   []*batchCall
+	index   map[string]*batchCall // calls indexed by the method and arguments
+	waiters int                   // number of batch items waiting for calls in the queue
+	timer   *time.Timer
+}
+
+type batchCall struct {
+	method string
+	args   []any
+	result json.RawMessage
+	err    error
+	doneCh chan struct{}
+}
+
+func newBatch(timeout time.Duration) *batch {
+	return &batch{
+		timeout:    timeout,
+		flushDelay: batchFlushDelay,
+		queues:     make(map[string]*batchQueue),
+	}
+}
+
+// call queues the call and waits for the response.
+func (b *batch) call(ctx context.Context, endpoint string, c batchCaller, result any, method string, args ...any) error {
+	bc := b.enqueue(endpoint, c, method, args)
+	defer b.leave(endpoint)
+	select {
+	case <-bc.doneCh:
+		if bc.err != nil {
+			return bc.err
+		}
+		return json.Unmarshal(bc.result, result)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// start must be called before a batch item is handled.
+func (b *batch) start() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pending++
+}
+
+// finish must be called after a batch item is handled.
+func (b *batch) finish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pending--
+	b.flushReady()
+}
+
+func (b *batch) enqueue(endpoint string, c batchCaller, method string, args []any) *batchCall {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	q, ok := b.queues[endpoint]
+	if !ok {
+		q = &batchQueue{caller: c, index: make(map[string]*batchCall)}
+		b.queues[endpoint] = q
+	}
+	q.waiters++
+	key, err := json.Marshal(append([]any{method}, args...))
+	if err == nil {
+		if bc, ok := q.index[string(key)]; ok {
+			b.flushReady()
+			return bc
+		}
+	}
+	bc := &batchCall{method: method, args: args, doneCh: make(chan struct{})}
+	if err == nil {
+		q.index[string(key)] = bc
+	}
+	q.calls = append(q.calls, bc)
+	if q.timer == nil {
+		q.timer = time.AfterFunc(b.flushDelay, func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			b.flush(q)
+		})
+	}
+	b.flushReady()
+	return bc
+}
+
+func (b *batch) leave(endpoint string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.queues[endpoint].waiters--
+}
+
+// flushReady sends queued calls to endpoints for which all pending batch
+// items are waiting. Must be called with the mutex locked.
+func (b *batch) flushReady() {
+	for _, q := range b.queues {
+		if len(q.calls) > 0 && q.waiters >= b.pending {
+			b.flush(q)
+		}
+	}
+}
+
+// flush sends queued calls to the endpoint. Must be called with the mutex
+// locked.
+func (b *batch) flush(q *batchQueue) {
+	if q.timer != nil {
+		q.timer.Stop()
+		q.timer = nil
+	}
+	if len(q.calls) == 0 {
+		return
+	}
+	calls := q.calls
+	q.calls = nil
+	q.index = make(map[string]*batchCall)
+	go func() {
+		ctx, ctxCancel := context.WithTimeout(context.Background(), b.timeout)
+		defer ctxCancel()
+		elems := make([]gethRPC.BatchElem, len(calls))
+		for i, c := range calls {
+			elems[i] = gethRPC.BatchElem{Method: c.method, Args: c.args, Result: &c.result}
+		}
+		err := q.caller.BatchCallContext(ctx, elems)
+		for i, c := range calls {
+			if err != nil {
+				c.err = err
+			} else {
+				c.err = elems[i].Error
+			}
+			close(c.doneCh)
+		}
+	}()
+}
+
+// callEndpoint calls the endpoint. If the call is made while handling a batch
+// request, it is added to the batch for the endpoint.
+func callEndpoint(ctx context.Context, endpoint string, c caller, result any, method string, args ...any) error {
+	if b, ok := ctx.Value(batchCtxKey{}).(*batch); ok {
+		if bc, ok := c.(batchCaller); ok {
+			return b.call(ctx, endpoint, bc, result, method, args...)
+		}
+	}
+	return c.CallContext(ctx, result, method, args...)
+}
+
+// serveBatch handles a JSON-RPC batch request. Batch items are handled
+// concurrently, up to maxBatchConcurrency at a time, and calls made to
+// endpoints are grouped into a single batch request per endpoint.
+func (s *server) serveBatch(rw http.ResponseWriter, req *http.Request, body []byte) {
+	var msgs []json.RawMessage
+	if err := json.Unmarshal(body, &msgs); err != nil || len(msgs) == 0 {
+		// Let the RPC server respond with a proper error.
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		s.rpc.ServeHTTP(rw, req)
+		return
+	}
+	if len(msgs) > maxBatchItems {
+		rw.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(rw).Encode(batchTooLargeRes)
+		return
+	}
+	b := newBatch(s.totalTimeout)
+	ctx := context.WithValue(req.Context(), batchCtxKey{}, b)
+	resps := make([]json.RawMessage, len(msgs))
+	sem := make(chan struct{}, maxBatchConcurrency)
+	wg := sync.WaitGroup{}
+	wg.Add(len(msgs))
+	for i, msg := range msgs {
+		i, msg := i, msg
+		sem <- struct{}{}
+		b.start()
+		go func() {
+			defer func() { <-sem }()
+			defer wg.Done()
+			defer b.finish()
+			itemReq := req.Clone(ctx)
+			itemReq.Body = io.NopCloser(bytes.NewReader(msg))
+			itemReq.ContentLength = int64(len(msg))
+			rec := newRecorder()
+			s.rpc.ServeHTTP(rec, itemReq)
+			resps[i] = bytes.TrimSpace(rec.body.Bytes())
+		}()
+	}
+	wg.Wait()
+	res := make([]json.RawMessage, 0, len(resps))
+	for _, r := range resps {
+		// Notifications do not have a response.
+		if len(r) > 0 {
+			res = append(res, r)
+		}
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	if len(res) == 0 {
+		return
+	}
+	_ = json.NewEncoder(rw).Encode(res)
+}
+
+// batchTooLargeRes is the response to batch requests that exceed
+// maxBatchItems.
+var batchTooLargeRes = map[string]any{
+	"jsonrpc": "2.0",
+	"id":      nil,
+	"error": map[string]any{
+		"code":    -32600,
+		"message": "batch too large",
+	},
+}
+
+// readBody reads the request body. It returns false if the body is too large.
+func readBody(req *http.Request) ([]byte, bool, error) {
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxRequestContentLength+1))
+	if err != nil {
+		return nil, false, err
+	}
+	return body, len(body) <= maxRequestContentLength, nil
+}
+
+// isBatch reports whether the request body contains a JSON-RPC batch.
+func isBatch(body []byte) bool {
+	body = bytes.TrimLeft(body, " \t\r\n")
+	return len(body) > 0 && body[0] == '['
+}
@@ -0,0 +1,215 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpcsplitter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchMockClient is a caller that supports batch requests. It returns
+// results for methods regardless of the arguments.
+type batchMockClient struct {
+	mu      sync.Mutex
+	results map[string]any
+	calls   int        // number of CallContext calls
+	batches [][]string // methods sent in each batch
+}
+
+func (c *batchMockClient) CallContext(_ context.Context, result any, method string, _ ...any) error {
+	c.mu.Lock()
+	c.calls++
+	c.mu.Unlock()
+	return c.result(result, method)
+}
+
+func (c *batchMockClient) BatchCallContext(_ context.Context, b []gethRPC.BatchElem) error {
+	c.mu.Lock()
+	var methods []string
+	for _, e := range b {
+		methods = append(methods, e.Method)
+	}
+	c.batches = append(c.batches, methods)
+	c.mu.Unlock()
+	for i := range b {
+		b[i].Error = c.result(b[i].Result, b[i].Method)
+	}
+	return nil
+}
+
+func (c *batchMockClient) result(result any, method string) error {
+	r, ok := c.results[method]
+	if !ok {
+		return errors.New("unexpected method")
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, result)
+}
+
+func (c *batchMockClient) batchedCalls() (n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, b := range c.batches {
+		n += len(b)
+	}
+	return n
+}
+
+func TestServer_Batch(t *testing.T) {
+	callers := map[string]caller{}
+	clients := make([]*batchMockClient, 3)
+	for i := range clients {
+		clients[i] = &batchMockClient{results: map[string]any{
+			"eth_chainId":     "0x1",
+			"net_version":     "1",
+			"eth_blockNumber": "0x10",
+			"eth_getBalance":  "0x64",
+		}}
+		callers[string(rune('a'+i))] = clients[i]
+	}
+	h, err := NewServer(withCallers(callers), WithRequirements(2, 1))
+	require.NoError(t, err)
+
+	body := `[
+		{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]},
+		{"jsonrpc":"2.0","id":2,"method":"net_version","params":[]},
+		{"jsonrpc":"2.0","method":"net_version","params":[]},
+		{"jsonrpc":"2.0","id":3,"method":"eth_getBalance","params":["0x1234567890123456789012345678901234567890","latest"]}
+	]`
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, req)
+
+	var res []rpcRes
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &res))
+	require.Len(t, res, 3)
+	assert.Equal(t, 1, res[0].ID)
+	assert.Equal(t, "0x1", res[0].Result)
+	assert.Equal(t, 2, res[1].ID)
+	assert.Equal(t, "1", res[1].Result)
+	assert.Equal(t, 3, res[2].ID)
+	assert.Equal(t, "0x64", res[2].Result)
+
+	for _, c := range clients {
+		// All calls must be sent in batches. The net_version call from the
+		// notification is identical to the other one, so it is usually sent
+		// once, unless the batch was flushed by the timer in between:
+		assert.Equal(t, 0, c.calls)
+		assert.GreaterOrEqual(t, c.batchedCalls(), 4)
+		assert.LessOrEqual(t, c.batchedCalls(), 5)
+	}
+}
+
+func TestServer_BatchInvalid(t *testing.T) {
+	h, err := NewServer(withCallers(map[string]caller{"a": &batchMockClient{}}), WithRequirements(1, 1))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`[]`))
+	req.Header.Set("Content-Type", "application/json")
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, req)
+
+	var res rpcRes
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &res))
+	assert.NotEmpty(t, res.Error.Message)
+}
+
+func TestServer_BatchTooLarge(t *testing.T) {
+	c := &batchMockClient{results: map[string]any{"eth_chainId": "0x1"}}
+	h, err := NewServer(withCallers(map[string]caller{"a": c}), WithRequirements(1, 1))
+	require.NoError(t, err)
+
+	items := make([]string, maxBatchItems+1)
+	for i := range items {
+		items[i] = `{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`
+	}
+	body := "[" + strings.Join(items, ",") + "]"
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, req)
+
+	var res rpcRes
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &res))
+	assert.Equal(t, "batch too large", res.Error.Message)
+	assert.Zero(t, c.calls)
+	assert.Zero(t, c.batchedCalls())
+}
+
+func TestServer_BatchConcurrency(t *testing.T) {
+	c := &batchMockClient{results: map[string]any{"eth_chainId": "0x1"}}
+	h, err := NewServer(withCallers(map[string]caller{"a": c}), WithRequirements(1, 1))
+	require.NoError(t, err)
+
+	// The batch is larger than the concurrency limit, so items must be
+	// handled in several rounds:
+	items := make([]string, maxBatchConcurrency*3)
+	for i := range items {
+		items[i] = fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":"eth_chainId","params":[]}`, i)
+	}
+	body := "[" + strings.Join(items, ",") + "]"
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, req)
+
+	var res []rpcRes
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &res))
+	require.Len(t, res, len(items))
+	for i, r := range res {
+		assert.Equal(t, i, r.ID)
+		assert.Equal(t, "0x1", r.Result)
+	}
+}
+
+func Test_batch_deduplicate(t *testing.T) {
+	c := &batchMockClient{results: map[string]any{"eth_blockNumber": "0x10"}}
+	b := newBatch(time.Second)
+	b.flushDelay = time.Hour
+	b.start()
+	b.start()
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			defer wg.Done()
+			defer b.finish()
+			var res string
+			assert.NoError(t, b.call(context.Background(), "a", c, &res, "eth_blockNumber"))
+			assert.Equal(t, "0x10", res)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, [][]string{{"eth_blockNumber"}}, c.batches)
+}
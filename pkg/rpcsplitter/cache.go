@@ -0,0 +1,330 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpcsplitter
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/rpcsplitter/types"
+)
+
+const defaultCacheMaxEntries = 10000
+
+// defaultCacheReorgDepth is the default number of blocks after which
+// responses for a specific block are considered immutable.
+const defaultCacheReorgDepth = 64
+
+// cachePolicy describes for how long a response to a method may be cached.
+type cachePolicy int
+
+const (
+	// cacheNone disables caching and coalescing of the method.
+	cacheNone cachePolicy = iota
+
+	// cacheTTL caches the response for a TTL. It is used for methods which
+	// responses depend on the chain head.
+	cacheTTL
+
+	// cacheBlock caches the response only if the block referenced in
+	// arguments is at least reorg depth blocks below the chain head, in
+	// which case the response is considered immutable. Responses for blocks
+	// that may still be reorged are not cached. If arguments do not
+	// reference a specific block, the response is cached for a TTL.
+	cacheBlock
+
+	// cacheForever caches the response indefinitely.
+	cacheForever
+)
+
+var cachePolicies = map[string]cachePolicy{
+	"eth_blockNumber":           cacheTTL,
+	"eth_gasPrice":              cacheTTL,
+	"eth_maxPriorityFeePerGas":  cacheTTL,
+	"eth_getBlockByHash":        cacheTTL,
+	"eth_getTransactionByHash":  cacheTTL,
+	"eth_getTransactionReceipt": cacheTTL,
+	"eth_getBlockByNumber":      cacheBlock,
+	"eth_getTransactionCount":   cacheBlock,
+	"eth_getBalance":            cacheBlock,
+	"eth_getCode":               cacheBlock,
+	"eth_getStorageAt":          cacheBlock,
+	"eth_call":                  cacheBlock,
+	"eth_estimateGas":           cacheBlock,
+	"eth_feeHistory":            cacheBlock,
+	"eth_getLogs":               cacheBlock,
+	"eth_chainId":               cacheForever,
+	"net_version":               cacheForever,
+}
+
+// cache is a short-lived cache of resolved responses. Responses are keyed by
+// the method name and arguments. Because tagged block numbers are resolved
+// before calling endpoints, arguments always contain the resolved block
+// number.
+//
+// Concurrent identical requests are coalesced, so only one of them is sent
+// to the endpoints. The coalesced call is not canceled when the request that
+// started it is canceled, because other requests may still wait for it.
+type cache struct {
+	mu sync.Mutex
+
+	ttl        time.Duration
+	reorgDepth uint64
+	maxEntries int
+
+	head     uint64                   // Highest known block number.
+	lru      *list.List               // Least recently used entries at the back.
+	entries  map[string]*list.Element // Values are *cacheEntry.
+	inflight map[string]*cacheCall
+}
+
+type cacheEntry struct {
+	key       string
+	value     any
+	expiresAt time.Time // Zero if the entry never expires.
+}
+
+type cacheCall struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+func newCache(ttl time.Duration, reorgDepth uint64, maxEntries int) *cache {
+	return &cache{
+		ttl:        ttl,
+		reorgDepth: reorgDepth,
+		maxEntries: maxEntries,
+		lru:        list.New(),
+		entries:    make(map[string]*list.Element),
+		inflight:   make(map[string]*cacheCall),
+	}
+}
+
+// do returns a cached response for the given method and arguments, or
+// calls fn and caches its result. If there is an identical call in
+// progress, do waits for its result instead of calling fn.
+//
+// The result must be a pointer, the cached value is copied to it.
+func (c *cache) do(
+	ctx context.Context,
+	result any,
+	method string,
+	args []any,
+	fn func(ctx context.Context, result any) error,
+) error {
+
+	policy := cachePolicies[method]
+	if policy == cacheNone {
+		return fn(ctx, result)
+	}
+	argsJSON, err := json.Marshal(removeTrailingNilArgs(args))
+	if err != nil {
+		return fn(ctx, result)
+	}
+	key := method + string(argsJSON)
+
+	c.mu.Lock()
+	if v, ok := c.get(key); ok {
+		c.mu.Unlock()
+		setResult(result, v)
+		return nil
+	}
+	if call, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		return call.wait(ctx, result)
+	}
+	call := &cacheCall{done: make(chan struct{})}
+	c.inflight[key] = call
+	c.mu.Unlock()
+
+	callCtx, callCtxCancel := detachContext(ctx)
+	go func() {
+		defer callCtxCancel()
+		c.call(callCtx, call, key, policy, method, args, reflect.TypeOf(result).Elem(), fn)
+	}()
+	return call.wait(ctx, result)
+}
+
+// call calls fn, caches its result and notifies requests waiting for it.
+func (c *cache) call(
+	ctx context.Context,
+	call *cacheCall,
+	key string,
+	policy cachePolicy,
+	method string,
+	args []any,
+	typ reflect.Type,
+	fn func(ctx context.Context, result any) error,
+) {
+
+	defer close(call.done)
+	result := reflect.New(typ)
+	call.err = fn(ctx, result.Interface())
+	if call.err == nil {
+		call.value = result.Elem().Interface()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.inflight, key)
+	if call.err != nil {
+		return
+	}
+	if method == "eth_blockNumber" {
+		if n, ok := call.value.(types.Number); ok && n.Big().IsUint64() && n.Big().Uint64() > c.head {
+			c.head = n.Big().Uint64()
+		}
+	}
+	if expiresAt, ok := c.expiration(policy, method, args); ok {
+		c.set(key, call.value, expiresAt)
+	}
+}
+
+// wait waits for the call to finish and copies its result.
+func (cc *cacheCall) wait(ctx context.Context, result any) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-cc.done:
+		if cc.err != nil {
+			return cc.err
+		}
+		setResult(result, cc.value)
+		return nil
+	}
+}
+
+// expiration returns the time at which a response expires. A zero time means
+// that the response never expires. If the response must not be cached, false
+// is returned.
+func (c *cache) expiration(policy cachePolicy, method string, args []any) (time.Time, bool) {
+	switch policy {
+	case cacheForever:
+		return time.Time{}, true
+	case cacheBlock:
+		if n, ok := blockFromArgs(method, args); ok {
+			// If the head is not known yet, it cannot be determined whether
+			// the block may still be reorged.
+			if c.head > 0 && c.head >= c.reorgDepth && n <= c.head-c.reorgDepth {
+				return time.Time{}, true
+			}
+			return time.Time{}, false
+		}
+	}
+	return time.Now().Add(c.ttl), true
+}
+
+// get returns a cached value. The mutex must be held by the caller.
+func (c *cache) get(key string) (any, bool) {
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*cacheEntry)
+	if !e.expiresAt.IsZero() && time.Now().After(e.expiresAt) {
+		c.lru.Remove(el)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.lru.MoveToFront(el)
+	return e.value, true
+}
+
+// set adds a value to the cache and evicts the least recently used entries
+// if the cache is full. The mutex must be held by the caller.
+func (c *cache) set(key string, value any, expiresAt time.Time) {
+	if el, ok := c.entries[key]; ok {
+		e := el.Value.(*cacheEntry)
+		e.value = value
+		e.expiresAt = expiresAt
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.lru.Len() > c.maxEntries {
+		el := c.lru.Back()
+		c.lru.Remove(el)
+		delete(c.entries, el.Value.(*cacheEntry).key)
+	}
+}
+
+// blockFromArgs returns the highest block number referenced in the method
+// arguments. It returns false if arguments do not reference a specific block,
+// e.g. they use the "latest" tag or a block hash.
+func blockFromArgs(method string, args []any) (uint64, bool) {
+	var (
+		block uint64
+		found bool
+	)
+	use := func(n types.BlockNumber) bool {
+		if n.IsTag() || !n.Big().IsUint64() {
+			return false
+		}
+		if !found || n.Big().Uint64() > block {
+			block = n.Big().Uint64()
+		}
+		found = true
+		return true
+	}
+	for _, arg := range args {
+		switch a := arg.(type) {
+		case types.BlockNumber:
+			if !use(a) {
+				return 0, false
+			}
+		case types.FilterLogsQuery:
+			if a.BlockHash != nil || a.ToBlock == nil || !use(*a.ToBlock) {
+				return 0, false
+			}
+		}
+	}
+	if method == "eth_getBlockByNumber" && len(args) > 0 {
+		if n, ok := args[0].(types.Number); ok {
+			return blockFromArgs("", []any{types.BigToBlockNumber(n.Big())})
+		}
+	}
+	return block, found
+}
+
+// detachedContext is a context that carries values of its parent, but is
+// never canceled.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+func (c detachedContext) Value(key any) any         { return c.parent.Value(key) }
+
+// detachContext returns a context that is not canceled together with the
+// given context, but has the same deadline and values.
+func detachContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if deadline, ok := ctx.Deadline(); ok {
+		return context.WithDeadline(detachedContext{parent: ctx}, deadline)
+	}
+	return context.WithCancel(detachedContext{parent: ctx})
+}
+
+// setResult copies the value to the result pointer.
+func setResult(result any, value any) {
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(value))
+}
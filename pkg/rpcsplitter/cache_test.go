@@ -0,0 +1,288 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpcsplitter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/rpcsplitter/types"
+)
+
+func numberFn(calls *int32, n int64) func(context.Context, any) error {
+	return func(_ context.Context, result any) error {
+		atomic.AddInt32(calls, 1)
+		*result.(*types.Number) = types.BigToNumber(big.NewInt(n))
+		return nil
+	}
+}
+
+func Test_cache_TTL(t *testing.T) {
+	c := newCache(50*time.Millisecond, 10, defaultCacheMaxEntries)
+	ctx := context.Background()
+
+	var calls int32
+	for i := 0; i < 3; i++ {
+		res := &types.Number{}
+		require.NoError(t, c.do(ctx, res, "eth_blockNumber", nil, numberFn(&calls, 100)))
+		assert.Equal(t, int64(100), res.Big().Int64())
+	}
+	assert.Equal(t, int32(1), calls)
+	assert.Equal(t, uint64(100), c.head)
+
+	time.Sleep(60 * time.Millisecond)
+	require.NoError(t, c.do(ctx, &types.Number{}, "eth_blockNumber", nil, numberFn(&calls, 101)))
+	assert.Equal(t, int32(2), calls)
+	assert.Equal(t, uint64(101), c.head)
+}
+
+func Test_cache_ImmutableBlocks(t *testing.T) {
+	c := newCache(time.Minute, 10, defaultCacheMaxEntries)
+	ctx := context.Background()
+
+	var calls int32
+	require.NoError(t, c.do(ctx, &types.Number{}, "eth_blockNumber", nil, numberFn(&calls, 100)))
+
+	// Block 90 is at reorg depth, so the response never expires:
+	args := []any{types.Address{}, types.Uint64ToBlockNumber(90)}
+	require.NoError(t, c.do(ctx, &types.Number{}, "eth_getBalance", args, numberFn(&calls, 1)))
+
+	// Block 95 may still be reorged, so the response is not cached:
+	args = []any{types.Address{}, types.Uint64ToBlockNumber(95)}
+	require.NoError(t, c.do(ctx, &types.Number{}, "eth_getBalance", args, numberFn(&calls, 1)))
+
+	// Chain ID never expires:
+	require.NoError(t, c.do(ctx, &types.Number{}, "eth_chainId", nil, numberFn(&calls, 1)))
+
+	expires := map[string]bool{}
+	for el := c.lru.Front(); el != nil; el = el.Next() {
+		e := el.Value.(*cacheEntry)
+		expires[e.key] = !e.expiresAt.IsZero()
+	}
+	assert.Equal(t, map[string]bool{
+		`eth_blockNumbernull`: true,
+		`eth_getBalance["0x0000000000000000000000000000000000000000","0x5a"]`: false,
+		`eth_chainIdnull`: false,
+	}, expires)
+}
+
+func Test_cache_Coalescing(t *testing.T) {
+	c := newCache(time.Minute, 10, defaultCacheMaxEntries)
+	ctx := context.Background()
+
+	var calls int32
+	release := make(chan struct{})
+	fn := func(ctx context.Context, result any) error {
+		<-release
+		return numberFn(&calls, 1)(ctx, result)
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res := &types.Number{}
+			assert.NoError(t, c.do(ctx, res, "eth_chainId", nil, fn))
+			assert.Equal(t, int64(1), res.Big().Int64())
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), calls)
+}
+
+func Test_cache_UnknownHead(t *testing.T) {
+	c := newCache(time.Minute, 10, defaultCacheMaxEntries)
+	ctx := context.Background()
+
+	// Without a known head, it cannot be determined whether the block may
+	// be reorged, so the response is not cached:
+	var calls int32
+	args := []any{types.Address{}, types.Uint64ToBlockNumber(1)}
+	require.NoError(t, c.do(ctx, &types.Number{}, "eth_getBalance", args, numberFn(&calls, 1)))
+	require.NoError(t, c.do(ctx, &types.Number{}, "eth_getBalance", args, numberFn(&calls, 1)))
+	assert.Equal(t, int32(2), calls)
+}
+
+func Test_cache_CanceledLeader(t *testing.T) {
+	c := newCache(time.Minute, 10, defaultCacheMaxEntries)
+
+	var calls int32
+	release := make(chan struct{})
+	fn := func(ctx context.Context, result any) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-release:
+		}
+		return numberFn(&calls, 1)(ctx, result)
+	}
+
+	// The request that started the call is canceled:
+	leaderCtx, leaderCtxCancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error)
+	go func() {
+		leaderErr <- c.do(leaderCtx, &types.Number{}, "eth_chainId", nil, fn)
+	}()
+	require.Eventually(t, func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return len(c.inflight) == 1
+	}, time.Second, time.Millisecond)
+	followerErr := make(chan error)
+	res := &types.Number{}
+	go func() {
+		followerErr <- c.do(context.Background(), res, "eth_chainId", nil, fn)
+	}()
+	leaderCtxCancel()
+	assert.ErrorIs(t, <-leaderErr, context.Canceled)
+
+	// Other requests must still receive the result:
+	close(release)
+	require.NoError(t, <-followerErr)
+	assert.Equal(t, int64(1), res.Big().Int64())
+	assert.Equal(t, int32(1), calls)
+}
+
+func Test_cache_Errors(t *testing.T) {
+	c := newCache(time.Minute, 10, defaultCacheMaxEntries)
+	ctx := context.Background()
+
+	var calls int32
+	fn := func(context.Context, any) error {
+		atomic.AddInt32(&calls, 1)
+		return errors.New("error")
+	}
+	assert.Error(t, c.do(ctx, &types.Number{}, "eth_chainId", nil, fn))
+	assert.Error(t, c.do(ctx, &types.Number{}, "eth_chainId", nil, fn))
+	assert.Equal(t, int32(2), calls)
+}
+
+func Test_cache_NotCacheable(t *testing.T) {
+	c := newCache(time.Minute, 10, defaultCacheMaxEntries)
+	ctx := context.Background()
+
+	var calls int32
+	fn := func(context.Context, any) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}
+	args := []any{types.Bytes{1}}
+	require.NoError(t, c.do(ctx, &types.Hash{}, "eth_sendRawTransaction", args, fn))
+	require.NoError(t, c.do(ctx, &types.Hash{}, "eth_sendRawTransaction", args, fn))
+	assert.Equal(t, int32(2), calls)
+}
+
+func Test_cache_Eviction(t *testing.T) {
+	c := newCache(time.Minute, 10, 2)
+	c.head = 100
+	ctx := context.Background()
+
+	var calls int32
+	for i := uint64(1); i <= 3; i++ {
+		args := []any{types.Address{}, types.Uint64ToBlockNumber(i)}
+		require.NoError(t, c.do(ctx, &types.Number{}, "eth_getBalance", args, numberFn(&calls, 1)))
+	}
+	assert.Equal(t, 2, c.lru.Len())
+	assert.Len(t, c.entries, 2)
+
+	// The first entry was evicted:
+	args := []any{types.Address{}, types.Uint64ToBlockNumber(1)}
+	require.NoError(t, c.do(ctx, &types.Number{}, "eth_getBalance", args, numberFn(&calls, 1)))
+	assert.Equal(t, int32(4), calls)
+}
+
+func Test_blockFromArgs(t *testing.T) {
+	latest := types.StringToBlockNumber("latest")
+	from := types.Uint64ToBlockNumber(10)
+	to := types.Uint64ToBlockNumber(20)
+	tests := []struct {
+		method string
+		args   []any
+		block  uint64
+		ok     bool
+	}{
+		{method: "eth_call", args: []any{Any{}, types.Uint64ToBlockNumber(5)}, block: 5, ok: true},
+		{method: "eth_call", args: []any{Any{}, latest}, ok: false},
+		{method: "eth_getBlockByNumber", args: []any{types.Uint64ToNumber(7), false}, block: 7, ok: true},
+		{method: "eth_getStorageAt", args: []any{types.Address{}, types.Uint64ToNumber(99), types.Uint64ToBlockNumber(3)}, block: 3, ok: true},
+		{method: "eth_getLogs", args: []any{types.FilterLogsQuery{FromBlock: &from, ToBlock: &to}}, block: 20, ok: true},
+		{method: "eth_getLogs", args: []any{types.FilterLogsQuery{FromBlock: &from}}, ok: false},
+		{method: "eth_chainId", args: nil, ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			block, ok := blockFromArgs(tt.method, tt.args)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.block, block)
+		})
+	}
+}
+
+func TestServer_Cache(t *testing.T) {
+	callers := map[string]caller{}
+	clients := make([]*batchMockClient, 3)
+	for i := range clients {
+		clients[i] = &batchMockClient{results: map[string]any{
+			"eth_blockNumber": "0x10",
+			"eth_getBalance":  "0x64",
+		}}
+		callers[string(rune('a'+i))] = clients[i]
+	}
+	h, err := NewServer(withCallers(callers), WithRequirements(2, 1), WithCache(time.Minute, 10))
+	require.NoError(t, err)
+
+	getBalance := func(block string) {
+		body := `{"jsonrpc":"2.0","id":1,"method":"eth_getBalance","params":["0x1234567890123456789012345678901234567890","` + block + `"]}`
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		rw := httptest.NewRecorder()
+		h.ServeHTTP(rw, req)
+
+		var res rpcRes
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &res))
+		assert.Equal(t, "0x64", res.Result)
+	}
+	for i := 0; i < 3; i++ {
+		getBalance("latest")
+	}
+	for _, c := range clients {
+		// One eth_blockNumber call to resolve the "latest" tag. The latest
+		// block may still be reorged, so eth_getBalance is not cached:
+		assert.Equal(t, 4, c.calls)
+	}
+	for i := 0; i < 3; i++ {
+		getBalance("0x5")
+	}
+	for _, c := range clients {
+		// Block 5 is at reorg depth, so eth_getBalance is cached:
+		assert.Equal(t, 5, c.calls)
+	}
+}
@@ -0,0 +1,215 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpcsplitter
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+const defaultForensicsSize = 1000
+
+// Disagreement is a record of a resolution that failed or in which some
+// endpoints returned responses different from the resolved one.
+type Disagreement struct {
+	// Time is the time at which the responses were resolved.
+	Time time.Time `json:"time"`
+
+	// Method is the RPC method name.
+	Method string `json:"method"`
+
+	// Args are the RPC method arguments, as sent to the endpoints.
+	Args json.RawMessage `json:"args"`
+
+	// Result is the resolved response, empty if the resolution failed.
+	Result json.RawMessage `json:"result,omitempty"`
+
+	// Error is the resolution error, empty if the resolution succeeded.
+	Error string `json:"error,omitempty"`
+
+	// Responses are the responses from all endpoints that responded.
+	Responses []EndpointResponse `json:"responses"`
+}
+
+// EndpointResponse is a response from a single endpoint.
+type EndpointResponse struct {
+	// Endpoint is the endpoint name.
+	Endpoint string `json:"endpoint"`
+
+	// Response is the raw response returned by the endpoint.
+	Response json.RawMessage `json:"response,omitempty"`
+
+	// Error is the error returned by the endpoint.
+	Error string `json:"error,omitempty"`
+
+	// Latency is the response time.
+	Latency time.Duration `json:"latency"`
+}
+
+// forensics records disagreements in a ring buffer and, optionally, writes
+// them to a sink as JSON lines.
+type forensics struct {
+	mu   sync.Mutex
+	buf  []Disagreement
+	next int  // index of the next record in buf
+	full bool // true if buf was filled at least once
+	sink io.Writer
+}
+
+func newForensics(size int, sink io.Writer) *forensics {
+	return &forensics{buf: make([]Disagreement, size), sink: sink}
+}
+
+// record records the resolution if it failed or if it was contested. Only
+// responses resolved by the default resolver can be contested, because other
+// resolvers aggregate different values by design.
+//
+// It is safe to call record on a nil forensics.
+func (f *forensics) record(
+	resolver resolver,
+	method string,
+	args []any,
+	rs []response,
+	result any,
+	err error,
+) error {
+
+	if f == nil {
+		return nil
+	}
+	if err == nil && !contested(resolver, rs, result) {
+		return nil
+	}
+	d := Disagreement{
+		Time:      time.Now(),
+		Method:    method,
+		Responses: make([]EndpointResponse, len(rs)),
+	}
+	d.Args, _ = json.Marshal(removeTrailingNilArgs(args))
+	if err != nil {
+		d.Error = err.Error()
+	} else {
+		d.Result, _ = json.Marshal(result)
+	}
+	for i, r := range rs {
+		d.Responses[i] = EndpointResponse{Endpoint: r.endpoint, Latency: r.latency}
+		if e, ok := r.value.(error); ok {
+			d.Responses[i].Error = e.Error()
+			continue
+		}
+		d.Responses[i].Response = r.raw
+		if d.Responses[i].Response == nil {
+			d.Responses[i].Response, _ = json.Marshal(r.value)
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.buf[f.next] = d
+	f.next = (f.next + 1) % len(f.buf)
+	if f.next == 0 {
+		f.full = true
+	}
+	if f.sink == nil {
+		return nil
+	}
+	line, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	_, err = f.sink.Write(append(line, '\n'))
+	return err
+}
+
+// query returns recorded disagreements, the newest first. Empty method and
+// endpoint match all records. If limit is zero, all matching records are
+// returned.
+func (f *forensics) query(method, endpoint string, limit int) []Disagreement {
+	ds := []Disagreement{}
+	if f == nil {
+		return ds
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := f.next
+	if f.full {
+		n = len(f.buf)
+	}
+	for i := 0; i < n; i++ {
+		if limit > 0 && len(ds) >= limit {
+			break
+		}
+		d := f.buf[(f.next-1-i+len(f.buf))%len(f.buf)]
+		if method != "" && d.Method != method {
+			continue
+		}
+		if endpoint != "" && !hasEndpoint(d, endpoint) {
+			continue
+		}
+		ds = append(ds, d)
+	}
+	return ds
+}
+
+// contested returns true if any endpoint returned a response different from
+// the resolved one.
+func contested(resolver resolver, rs []response, result any) bool {
+	if _, ok := resolver.(*defaultResolver); !ok {
+		return false
+	}
+	for _, r := range rs {
+		if _, ok := r.value.(error); ok {
+			continue
+		}
+		if !compare(r.value, result) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasEndpoint(d Disagreement, endpoint string) bool {
+	for _, r := range d.Responses {
+		if r.Endpoint == endpoint {
+			return true
+		}
+	}
+	return false
+}
+
+// callEndpointRaw works like callEndpoint, but it also returns the raw
+// response.
+func callEndpointRaw(
+	ctx context.Context,
+	endpoint string,
+	c caller,
+	result any,
+	method string,
+	args ...any,
+) (json.RawMessage, error) {
+
+	var raw json.RawMessage
+	if err := callEndpoint(ctx, endpoint, c, &raw, method, args...); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, result); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
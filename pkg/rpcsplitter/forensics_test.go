@@ -0,0 +1,149 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpcsplitter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_forensics_Query(t *testing.T) {
+	f := newForensics(2, nil)
+	r := &defaultResolver{minResponses: 1}
+	rs := []response{{endpoint: "a", value: errors.New("error")}}
+	for _, m := range []string{"eth_call", "eth_getBalance", "eth_chainId"} {
+		require.NoError(t, f.record(r, m, nil, rs, nil, errNotEnoughResponses))
+	}
+
+	// The oldest record is overwritten, the newest record is returned first:
+	ds := f.query("", "", 0)
+	require.Len(t, ds, 2)
+	assert.Equal(t, "eth_chainId", ds[0].Method)
+	assert.Equal(t, "eth_getBalance", ds[1].Method)
+	assert.Equal(t, errNotEnoughResponses.Error(), ds[0].Error)
+	assert.Equal(t, "error", ds[0].Responses[0].Error)
+
+	assert.Len(t, f.query("", "", 1), 1)
+	assert.Len(t, f.query("eth_getBalance", "", 0), 1)
+	assert.Len(t, f.query("", "a", 0), 2)
+	assert.Len(t, f.query("", "b", 0), 0)
+	assert.Len(t, (*forensics)(nil).query("", "", 0), 0)
+}
+
+func Test_forensics_Contested(t *testing.T) {
+	one, two := newAny(`"1"`), newAny(`"2"`)
+	tests := []struct {
+		name     string
+		resolver resolver
+		rs       []response
+		result   any
+		recorded bool
+	}{
+		{
+			name:     "agreement",
+			resolver: &defaultResolver{minResponses: 2},
+			rs:       []response{{endpoint: "a", value: one}, {endpoint: "b", value: one}},
+			result:   one,
+			recorded: false,
+		},
+		{
+			name:     "error-only",
+			resolver: &defaultResolver{minResponses: 1},
+			rs:       []response{{endpoint: "a", value: one}, {endpoint: "b", value: errors.New("error")}},
+			result:   one,
+			recorded: false,
+		},
+		{
+			name:     "disagreement",
+			resolver: &defaultResolver{minResponses: 1},
+			rs:       []response{{endpoint: "a", value: one}, {endpoint: "b", value: two}},
+			result:   one,
+			recorded: true,
+		},
+		{
+			name:     "aggregating-resolver",
+			resolver: &gasValueResolver{minResponses: 1},
+			rs:       []response{{endpoint: "a", value: one}, {endpoint: "b", value: two}},
+			result:   one,
+			recorded: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newForensics(10, nil)
+			require.NoError(t, f.record(tt.resolver, "net_version", nil, tt.rs, tt.result, nil))
+			assert.Equal(t, tt.recorded, len(f.query("", "", 0)) == 1)
+		})
+	}
+}
+
+func TestServer_Forensics(t *testing.T) {
+	clients := map[string]caller{}
+	for n, v := range map[string]string{"a": `"1"`, "b": `"1"`, "c": `"2"`} {
+		c := &mockClient{t: t}
+		c.mockCall(newAny(v), "net_version")
+		clients[n] = c
+	}
+	sink := &bytes.Buffer{}
+	h, err := NewServer(
+		withCallers(clients),
+		WithRequirements(2, 0),
+		WithForensics(10, sink),
+	)
+	require.NoError(t, err)
+	admin, err := NewAdminHandler(h)
+	require.NoError(t, err)
+
+	res, err := h.(*server).net.Version(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, newAny(`"1"`), res)
+
+	// Sink:
+	lines := strings.Split(strings.TrimSpace(sink.String()), "\n")
+	require.Len(t, lines, 1)
+	var d Disagreement
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &d))
+	assert.Equal(t, "net_version", d.Method)
+	assert.JSONEq(t, `"1"`, string(d.Result))
+	require.Len(t, d.Responses, 3)
+	raw := map[string]string{}
+	for _, r := range d.Responses {
+		raw[r.Endpoint] = string(r.Response)
+	}
+	assert.Equal(t, map[string]string{"a": `"1"`, "b": `"1"`, "c": `"2"`}, raw)
+
+	// Admin API:
+	rw := httptest.NewRecorder()
+	admin.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, AdminDisagreementsPath+"?endpoint=c", nil))
+	require.Equal(t, http.StatusOK, rw.Code)
+	var ds []Disagreement
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &ds))
+	require.Len(t, ds, 1)
+	assert.Equal(t, "net_version", ds[0].Method)
+
+	rw = httptest.NewRecorder()
+	admin.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, AdminDisagreementsPath+"?limit=x", nil))
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+}
@@ -16,6 +16,10 @@
 package rpcsplitter
 
 import (
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
 	"time"
 
 	gethRPC "github.com/ethereum/go-ethereum/rpc"
@@ -27,6 +31,10 @@ type Option func(s *server) error
 
 // WithEndpoints options instructs RPC-Splitter to use provided list of
 // Ethereum RPC nodes.
+//
+// Endpoints are identified by their index and host in statistics, forensics
+// records and logs, e.g. "0:eth.example.com", because URLs may contain API
+// keys.
 func WithEndpoints(endpoints []string) Option {
 	return func(s *server) error {
 		for _, e := range endpoints {
@@ -34,7 +42,7 @@ func WithEndpoints(endpoints []string) Option {
 			if err != nil {
 				return err
 			}
-			s.callers[e] = c
+			s.callers[endpointName(len(s.callers), e)] = &gethClient{Client: c}
 		}
 		return nil
 	}
@@ -61,6 +69,32 @@ func WithRequirements(minResponses int, maxBlockBehind int) Option {
 	}
 }
 
+// MethodOptions overrides the default requirements and timeouts for a single
+// RPC method. Zero values mean that the default values are used.
+type MethodOptions struct {
+	// MinResponses is the minimum number of responses required to resolve
+	// the response.
+	MinResponses int
+
+	// TotalTimeout is the timeout for all endpoints.
+	TotalTimeout time.Duration
+
+	// GracefulTimeout is the timeout for slower endpoints.
+	GracefulTimeout time.Duration
+}
+
+// WithMethodOptions overrides the default requirements and timeouts for the
+// given method, e.g. "eth_call".
+func WithMethodOptions(method string, opts MethodOptions) Option {
+	return func(s *server) error {
+		if opts.MinResponses < 0 || opts.TotalTimeout < 0 || opts.GracefulTimeout < 0 {
+			return fmt.Errorf("method options for %s must not be negative", method)
+		}
+		s.methods[method] = opts
+		return nil
+	}
+}
+
 // WithTotalTimeout sets the total timeout for all endpoints. When the timeout
 // is exceeded, RPC-Splitter cancels all requests to the endpoints.
 func WithTotalTimeout(t time.Duration) Option {
@@ -82,6 +116,69 @@ func WithGracefulTimeout(t time.Duration) Option {
 	}
 }
 
+// WithQuarantine sets the score below which an endpoint is quarantined and
+// the score above which it is released. Scores are between 0 and 1. Setting
+// the quarantine score to 0 disables quarantining of endpoints that return
+// errors or disagree with other endpoints.
+func WithQuarantine(quarantineScore, recoveryScore float64) Option {
+	return func(s *server) error {
+		if quarantineScore < 0 || recoveryScore > 1 {
+			return fmt.Errorf("quarantine scores must be between 0 and 1")
+		}
+		s.quarantineScore = quarantineScore
+		s.recoveryScore = recoveryScore
+		return nil
+	}
+}
+
+// WithWebsocketOrigins sets the list of origins from which WebSocket
+// connections are accepted. Connections from non-browser clients, which do
+// not send the Origin header, are always accepted.
+func WithWebsocketOrigins(origins []string) Option {
+	return func(s *server) error {
+		s.wsOrigins = origins
+		return nil
+	}
+}
+
+// WithCache enables a short-lived cache of responses. Responses that depend
+// on the chain head, like "eth_blockNumber", are cached for the given TTL.
+// Responses for a specific block, like "eth_call", are cached until evicted,
+// but only if the block is at least reorgDepth blocks behind the head. If
+// reorgDepth is zero, 64 blocks are used. Concurrent identical requests are
+// coalesced into one.
+func WithCache(ttl time.Duration, reorgDepth uint64) Option {
+	return func(s *server) error {
+		if ttl <= 0 {
+			return fmt.Errorf("cache TTL must be positive")
+		}
+		if reorgDepth == 0 {
+			reorgDepth = defaultCacheReorgDepth
+		}
+		s.cache = newCache(ttl, reorgDepth, defaultCacheMaxEntries)
+		return nil
+	}
+}
+
+// WithForensics enables recording of resolutions that failed or in which
+// some endpoints returned different responses. The last size records are
+// kept in memory and are available through the admin API. If sink is not
+// nil, every record is also written to it as a JSON line.
+//
+// Because raw responses are kept, enabling forensics increases memory usage.
+func WithForensics(size int, sink io.Writer) Option {
+	return func(s *server) error {
+		if size < 0 {
+			return fmt.Errorf("forensics size must not be negative")
+		}
+		if size == 0 {
+			size = defaultForensicsSize
+		}
+		s.forensics = newForensics(size, sink)
+		return nil
+	}
+}
+
 // WithLogger sets logger.
 func WithLogger(logger log.Logger) Option {
 	return func(s *server) error {
@@ -96,3 +193,14 @@ func withCallers(callers map[string]caller) Option {
 		return nil
 	}
 }
+
+// endpointName returns the name of the endpoint with the given index and URL.
+// The name contains only the host, so it does not leak credentials that may
+// be part of the URL.
+func endpointName(idx int, endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Hostname() == "" {
+		return strconv.Itoa(idx)
+	}
+	return strconv.Itoa(idx) + ":" + u.Hostname()
+}
@@ -0,0 +1,257 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpcsplitter
+
+import (
+	"encoding/json"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/rpcsplitter/types"
+)
+
+// Parameters of the endpoint reputation:
+const (
+	// reputationSmoothing is the smoothing factor of the moving averages
+	// used to calculate the error rate, agreement rate and latency.
+	reputationSmoothing = 0.1
+
+	// reputationMinSamples is the minimum number of responses that must be
+	// received from an endpoint before it can be quarantined.
+	reputationMinSamples = 10
+
+	defaultQuarantineScore = 0.5
+	defaultRecoveryScore   = 0.8
+)
+
+// EndpointStats contains statistics of a single RPC endpoint.
+type EndpointStats struct {
+	// Endpoint is the endpoint name.
+	Endpoint string `json:"endpoint"`
+
+	// Responses is the number of received responses, including errors.
+	Responses uint64 `json:"responses"`
+
+	// Errors is the number of received errors.
+	Errors uint64 `json:"errors"`
+
+	// Agreements and Disagreements are the number of responses that agreed
+	// or disagreed with the consensus.
+	Agreements    uint64 `json:"agreements"`
+	Disagreements uint64 `json:"disagreements"`
+
+	// ErrorRate is the moving average of the error rate.
+	ErrorRate float64 `json:"error_rate"`
+
+	// AgreementRate is the moving average of the rate of responses that
+	// agreed with the consensus.
+	AgreementRate float64 `json:"agreement_rate"`
+
+	// Latency is the moving average of the response time.
+	Latency time.Duration `json:"latency"`
+
+	// BlocksBehind is the number of blocks the endpoint was behind the
+	// consensus block number during the last eth_blockNumber call.
+	BlocksBehind int64 `json:"blocks_behind"`
+
+	// Score is the endpoint score between 0 and 1. It is used as the weight
+	// of the endpoint responses in the quorum.
+	Score float64 `json:"score"`
+
+	// Quarantined is true if the endpoint responses are not taken into
+	// account when resolving the responses.
+	Quarantined bool `json:"quarantined"`
+
+	// QuarantinedAt is the time at which the endpoint was quarantined.
+	QuarantinedAt time.Time `json:"quarantined_at,omitempty"`
+}
+
+// response is a response from a single endpoint.
+type response struct {
+	endpoint string
+	value    any             // value is either a response or an error
+	raw      json.RawMessage // raw response, only if forensics is enabled
+	latency  time.Duration
+}
+
+// reputation keeps statistics of the RPC endpoints.
+//
+// Endpoints that consistently return errors, disagree with other endpoints
+// or lag behind are quarantined until their score recovers. Requests are
+// still sent to quarantined endpoints, so their statistics are updated, but
+// their responses are not used to resolve the final response.
+type reputation struct {
+	mu        sync.Mutex
+	endpoints map[string]*EndpointStats
+
+	quarantineScore float64 // score below which an endpoint is quarantined
+	recoveryScore   float64 // score above which a quarantined endpoint is released
+	maxBlocksBehind int64   // maximum number of blocks an endpoint may be behind
+	minActive       int     // minimum number of endpoints that must not be quarantined
+}
+
+func newReputation(endpoints []string, quarantineScore, recoveryScore float64, maxBlocksBehind, minActive int) *reputation {
+	r := &reputation{
+		endpoints:       make(map[string]*EndpointStats, len(endpoints)),
+		quarantineScore: quarantineScore,
+		recoveryScore:   recoveryScore,
+		maxBlocksBehind: int64(maxBlocksBehind),
+		minActive:       minActive,
+	}
+	for _, e := range endpoints {
+		r.endpoints[e] = &EndpointStats{Endpoint: e, AgreementRate: 1, Score: 1}
+	}
+	return r
+}
+
+// stats returns statistics of all endpoints sorted by the endpoint name.
+func (r *reputation) stats() []EndpointStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := make([]EndpointStats, 0, len(r.endpoints))
+	for _, e := range r.endpoints {
+		s = append(s, *e)
+	}
+	sort.Slice(s, func(i, j int) bool {
+		return s[i].Endpoint < s[j].Endpoint
+	})
+	return s
+}
+
+// active returns the responses from endpoints that are not quarantined and
+// their weights.
+func (r *reputation) active(rs []response) ([]any, []float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var (
+		vals    = make([]any, 0, len(rs))
+		weights = make([]float64, 0, len(rs))
+	)
+	for _, res := range rs {
+		weight := 1.0
+		if e, ok := r.endpoints[res.endpoint]; ok {
+			if e.Quarantined {
+				continue
+			}
+			weight = e.Score
+		}
+		vals = append(vals, res.value)
+		weights = append(weights, weight)
+	}
+	return vals, weights
+}
+
+// update updates statistics of endpoints using responses received during
+// a single call. The result is the resolved response, or nil if it was not
+// possible to resolve responses.
+func (r *reputation) update(resolver resolver, rs []response, result any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, res := range rs {
+		e, ok := r.endpoints[res.endpoint]
+		if !ok {
+			continue
+		}
+		e.Responses++
+		if e.Responses == 1 {
+			e.Latency = res.latency
+		} else {
+			e.Latency = time.Duration(ewma(float64(e.Latency), float64(res.latency)))
+		}
+		if _, ok := res.value.(error); ok {
+			e.Errors++
+			e.ErrorRate = ewma(e.ErrorRate, 1)
+		} else {
+			e.ErrorRate = ewma(e.ErrorRate, 0)
+			if result != nil {
+				r.assess(resolver, e, res.value, result)
+			}
+		}
+		e.Score = e.AgreementRate * (1 - e.ErrorRate)
+	}
+	for _, e := range r.endpoints {
+		r.updateQuarantine(e)
+	}
+}
+
+// assess compares the response with the resolved response. Only the
+// default resolver expects identical responses from all endpoints, other
+// resolvers aggregate different values, so they cannot be used to verify
+// the agreement.
+func (r *reputation) assess(resolver resolver, e *EndpointStats, value, result any) {
+	switch resolver.(type) {
+	case *defaultResolver:
+		if compare(value, result) {
+			e.Agreements++
+			e.AgreementRate = ewma(e.AgreementRate, 1)
+		} else {
+			e.Disagreements++
+			e.AgreementRate = ewma(e.AgreementRate, 0)
+		}
+	case *blockNumberResolver:
+		n, ok := value.(*types.Number)
+		if !ok {
+			return
+		}
+		m, ok := result.(*types.Number)
+		if !ok {
+			return
+		}
+		behind := new(big.Int).Sub(m.Big(), n.Big())
+		if behind.Sign() < 0 || !behind.IsInt64() {
+			e.BlocksBehind = 0
+			return
+		}
+		e.BlocksBehind = behind.Int64()
+	}
+}
+
+// updateQuarantine quarantines or releases the endpoint depending on its
+// score. An endpoint is not quarantined if it would leave fewer active
+// endpoints than required to resolve responses.
+func (r *reputation) updateQuarantine(e *EndpointStats) {
+	lagging := e.BlocksBehind > r.maxBlocksBehind
+	switch {
+	case e.Quarantined:
+		if e.Score >= r.recoveryScore && !lagging {
+			e.Quarantined = false
+			e.QuarantinedAt = time.Time{}
+		}
+	case e.Responses >= reputationMinSamples && (e.Score < r.quarantineScore || lagging):
+		if r.activeCount() <= r.minActive {
+			return
+		}
+		e.Quarantined = true
+		e.QuarantinedAt = time.Now()
+	}
+}
+
+func (r *reputation) activeCount() int {
+	n := 0
+	for _, e := range r.endpoints {
+		if !e.Quarantined {
+			n++
+		}
+	}
+	return n
+}
+
+// ewma calculates the exponentially weighted moving average.
+func ewma(avg, v float64) float64 {
+	return avg + reputationSmoothing*(v-avg)
+}
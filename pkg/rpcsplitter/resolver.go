@@ -32,6 +32,12 @@ type resolver interface {
 	resolve([]any) (any, error)
 }
 
+// weightedResolver is a resolver that takes into account the weights of
+// responses. Weights are based on the reputation of endpoints.
+type weightedResolver interface {
+	resolveWeighted([]any, []float64) (any, error)
+}
+
 // defaultResolver compares responses with each other and returns the most
 // common one. If there are multiple responses with the same number of
 // occurrences but greater than minResponses, an error is returned.
@@ -41,36 +47,72 @@ type defaultResolver struct {
 
 // resolve implements resolver interface.
 func (r *defaultResolver) resolve(resps []any) (any, error) {
-	resps, errs := extractErrors(resps)
-	if len(resps) < r.minResponses {
+	weights := make([]float64, len(resps))
+	for i := range weights {
+		weights[i] = 1
+	}
+	return r.resolveWeighted(resps, weights)
+}
+
+// resolveWeighted works like resolve, but instead of the most common
+// response, it returns the response with the highest sum of weights.
+// Only responses that occur at least minResponses times are taken into
+// account.
+func (r *defaultResolver) resolveWeighted(resps []any, weights []float64) (any, error) {
+	var errs []error
+	var vals []any
+	var ws []float64
+	for i, v := range resps {
+		if e, ok := v.(error); ok {
+			errs = append(errs, e)
+			continue
+		}
+		vals = append(vals, v)
+		ws = append(ws, weights[i])
+	}
+	if len(vals) < r.minResponses {
 		return nil, addError(errNotEnoughResponses, errs...)
 	}
-	if len(resps) == 1 {
-		return resps[0], nil
+	if len(vals) == 1 {
+		return vals[0], nil
 	}
-	mostCommonResp := resps[0]
-	mostCommonCounter := 0
-	multiple := false
-	for _, a := range resps {
+	var (
+		bestResp   any
+		bestWeight = -1.0
+		multiple   = false
+	)
+	for i, a := range vals {
 		counter := 0
-		for _, b := range resps {
+		weight := 0.0
+		for j, b := range vals {
 			if compare(a, b) {
+				if j < i {
+					// The response was already counted.
+					counter = -1
+					break
+				}
 				counter++
+				weight += ws[j]
 			}
 		}
-		if counter > mostCommonCounter {
-			multiple = false
-			mostCommonResp = a
-			mostCommonCounter = counter
+		// Only responses that occur at least minResponses times can be
+		// chosen, regardless of their weight.
+		if counter < r.minResponses {
+			continue
 		}
-		if counter == mostCommonCounter && !compare(mostCommonResp, a) {
+		switch {
+		case weight > bestWeight:
+			multiple = false
+			bestResp = a
+			bestWeight = weight
+		case weight == bestWeight:
 			multiple = true
 		}
 	}
-	if multiple || mostCommonCounter < r.minResponses {
+	if bestResp == nil || multiple {
 		return nil, addError(errDifferentResponses, errs...)
 	}
-	return mostCommonResp, nil
+	return bestResp, nil
 }
 
 // gasValueResolver is designed to handle responses from methods returning a
@@ -157,6 +199,20 @@ func (r *blockNumberResolver) resolve(resps []any) (any, error) {
 	return bigToNumberPtr(block), nil
 }
 
+// withMinResponses returns a copy of the resolver with a different minimum
+// number of responses.
+func withMinResponses(r resolver, minResponses int) resolver {
+	switch r := r.(type) {
+	case *defaultResolver:
+		return &defaultResolver{minResponses: minResponses}
+	case *gasValueResolver:
+		return &gasValueResolver{minResponses: minResponses}
+	case *blockNumberResolver:
+		return &blockNumberResolver{minResponses: minResponses, maxBlocksBehind: r.maxBlocksBehind}
+	}
+	return r
+}
+
 func extractErrors(resps []any) (filtered []any, errs []error) {
 	for _, r := range resps {
 		if e, ok := r.(error); ok {
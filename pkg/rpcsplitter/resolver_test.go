@@ -97,6 +97,70 @@ func Test_defaultResolver_resolve(t *testing.T) {
 	}
 }
 
+func Test_defaultResolver_resolveWeighted(t *testing.T) {
+	tests := []struct {
+		resps        []any
+		weights      []float64
+		minResponses int
+		want         any
+		wantErr      bool
+	}{
+		{
+			resps:        []any{newAny(`"a"`), newAny(`"b"`)},
+			weights:      []float64{1, 0.5},
+			minResponses: 1,
+			want:         newAny(`"a"`),
+		},
+		{
+			resps:        []any{newAny(`"a"`), newAny(`"b"`)},
+			weights:      []float64{0.5, 1},
+			minResponses: 1,
+			want:         newAny(`"b"`),
+		},
+		{
+			resps:        []any{newAny(`"a"`), newAny(`"a"`), newAny(`"b"`), newAny(`"b"`)},
+			weights:      []float64{1, 1, 1, 0.2},
+			minResponses: 2,
+			want:         newAny(`"a"`),
+		},
+		{
+			resps:        []any{newAny(`"a"`), newAny(`"b"`), newAny(`"b"`)},
+			weights:      []float64{1, 0.3, 0.3},
+			minResponses: 2,
+			want:         newAny(`"b"`), // "a" has the highest weight but occurred only once
+		},
+		{
+			resps:        []any{newAny(`"a"`), newAny(`"b"`), newAny(`"c"`)},
+			weights:      []float64{1, 1, 1},
+			minResponses: 2,
+			wantErr:      true,
+		},
+		{
+			resps:        []any{newAny(`"a"`), newAny(`"b"`)},
+			weights:      []float64{0.5, 0.5},
+			minResponses: 1,
+			wantErr:      true,
+		},
+		{
+			resps:        []any{errors.New("err"), newAny(`"a"`), newAny(`"b"`)},
+			weights:      []float64{1, 0.5, 1},
+			minResponses: 1,
+			want:         newAny(`"b"`),
+		},
+	}
+	for n, tt := range tests {
+		t.Run(fmt.Sprintf("case-%d", n), func(t *testing.T) {
+			r := defaultResolver{minResponses: tt.minResponses}
+			v, err := r.resolveWeighted(tt.resps, tt.weights)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			assert.Equal(t, tt.want, v)
+		})
+	}
+}
+
 func Test_gasValueResolver_resolve(t *testing.T) {
 	tests := []struct {
 		resps        []any
@@ -16,10 +16,12 @@
 package rpcsplitter
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"reflect"
 	"time"
@@ -43,6 +45,7 @@ type caller interface {
 // server is an RPC proxy server. It merges multiple RPC endpoints into one.
 type server struct {
 	rpc *gethRPC.Server // rpc is an RPC server.
+	ws  http.Handler    // ws is a WebSocket handler for the RPC server.
 	eth *rpcETHAPI      // eth implements procedures with the "eth_" prefix.
 	net *rpcNETAPI      // net implements procedures with the "net_" prefix.
 	log log.Logger
@@ -55,10 +58,28 @@ type server struct {
 	// if there is enough responses.
 	gracefulTimeout time.Duration
 
+	// Options that override the default requirements and timeouts for
+	// specific methods.
+	methods map[string]MethodOptions
+
 	// Resolvers used to convert multiple responses into a single response:
 	defaultResolver     *defaultResolver
 	gasValueResolver    *gasValueResolver
 	blockNumberResolver *blockNumberResolver
+
+	// Endpoint reputation:
+	reputation      *reputation
+	quarantineScore float64
+	recoveryScore   float64
+
+	// forensics records failed and contested resolutions, nil if disabled.
+	forensics *forensics
+
+	// cache is the response cache, nil if disabled.
+	cache *cache
+
+	// List of allowed origins for WebSocket connections.
+	wsOrigins []string
 }
 
 type rpcETHAPI struct {
@@ -71,8 +92,11 @@ type rpcNETAPI struct {
 
 func NewServer(opts ...Option) (http.Handler, error) {
 	h := &server{
-		rpc:     gethRPC.NewServer(),
-		callers: map[string]caller{},
+		rpc:             gethRPC.NewServer(),
+		callers:         map[string]caller{},
+		methods:         map[string]MethodOptions{},
+		quarantineScore: defaultQuarantineScore,
+		recoveryScore:   defaultRecoveryScore,
 	}
 	eth := &rpcETHAPI{handler: h}
 	net := &rpcNETAPI{handler: h}
@@ -105,20 +129,56 @@ func NewServer(opts ...Option) (http.Handler, error) {
 	if h.gracefulTimeout == 0 {
 		h.gracefulTimeout = defaultGracefulTimeout
 	}
+	if h.recoveryScore < h.quarantineScore {
+		return nil, fmt.Errorf("rpc-splitter error: recovery score must not be lower than quarantine score")
+	}
+	endpoints := make([]string, 0, len(h.callers))
+	for e := range h.callers {
+		endpoints = append(endpoints, e)
+	}
+	h.reputation = newReputation(
+		endpoints,
+		h.quarantineScore,
+		h.recoveryScore,
+		h.blockNumberResolver.maxBlocksBehind,
+		h.defaultResolver.minResponses,
+	)
+	h.ws = h.rpc.WebsocketHandler(h.wsOrigins)
 	h.log = h.log.WithField("tag", LoggerTag)
 	return h, nil
 }
 
 func (s *server) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
-	s.rpc.ServeHTTP(rw, req)
+	switch {
+	case isWebsocket(req):
+		s.ws.ServeHTTP(rw, req)
+	case req.Method == http.MethodPost:
+		body, ok, err := readBody(req)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !ok {
+			http.Error(rw, "content length too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		if isBatch(body) {
+			s.serveBatch(rw, req, body)
+			return
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		s.rpc.ServeHTTP(rw, req)
+	default:
+		s.rpc.ServeHTTP(rw, req)
+	}
 }
 
 // BlockNumber implements the "eth_blockNumber" call.
 //
 // It returns the most common response that occurred at least as many times as
 // specified in the minRes method.
-func (r *rpcETHAPI) BlockNumber() (any, error) {
-	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
+func (r *rpcETHAPI) BlockNumber(ctx context.Context) (any, error) {
+	ctx, ctxCancel := r.handler.withTimeout(ctx, "eth_blockNumber")
 	defer ctxCancel()
 
 	res := &types.Number{}
@@ -131,8 +191,8 @@ func (r *rpcETHAPI) BlockNumber() (any, error) {
 //
 // The number returned by this method is the median of all numbers returned
 // by the endpoints.
-func (r *rpcETHAPI) GetBlockByHash(blockHash types.Hash, obj bool) (any, error) {
-	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
+func (r *rpcETHAPI) GetBlockByHash(ctx context.Context, blockHash types.Hash, obj bool) (any, error) {
+	ctx, ctxCancel := r.handler.withTimeout(ctx, "eth_getBlockByHash")
 	defer ctxCancel()
 
 	var res any
@@ -151,8 +211,8 @@ func (r *rpcETHAPI) GetBlockByHash(blockHash types.Hash, obj bool) (any, error)
 //
 // It returns the most common response that occurred at least as many times as
 // specified in the minRes method.
-func (r *rpcETHAPI) GetBlockByNumber(blockNumber types.Number, obj bool) (any, error) {
-	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
+func (r *rpcETHAPI) GetBlockByNumber(ctx context.Context, blockNumber types.Number, obj bool) (any, error) {
+	ctx, ctxCancel := r.handler.withTimeout(ctx, "eth_getBlockByNumber")
 	defer ctxCancel()
 
 	var res any
@@ -171,8 +231,8 @@ func (r *rpcETHAPI) GetBlockByNumber(blockNumber types.Number, obj bool) (any, e
 //
 // It returns the most common response that occurred at least as many times as
 // specified in the minRes method.
-func (r *rpcETHAPI) GetTransactionByHash(txHash types.Hash) (any, error) {
-	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
+func (r *rpcETHAPI) GetTransactionByHash(ctx context.Context, txHash types.Hash) (any, error) {
+	ctx, ctxCancel := r.handler.withTimeout(ctx, "eth_getTransactionByHash")
 	defer ctxCancel()
 
 	res := &types.Transaction{}
@@ -189,8 +249,8 @@ func (r *rpcETHAPI) GetTransactionByHash(txHash types.Hash) (any, error) {
 // If the block number is set to "latest" or "pending", it will be replaced by
 // the block number returned by the BlockNumber method. The "earliest" tag is
 // not supported.
-func (r *rpcETHAPI) GetTransactionCount(addr types.Address, blockID types.BlockNumber) (any, error) {
-	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
+func (r *rpcETHAPI) GetTransactionCount(ctx context.Context, addr types.Address, blockID types.BlockNumber) (any, error) {
+	ctx, ctxCancel := r.handler.withTimeout(ctx, "eth_getTransactionCount")
 	defer ctxCancel()
 
 	blockNumber, err := r.handler.taggedBlockToNumber(ctx, blockID)
@@ -207,8 +267,8 @@ func (r *rpcETHAPI) GetTransactionCount(addr types.Address, blockID types.BlockN
 //
 // It returns the most common response that occurred at least as many times as
 // specified in the minRes method.
-func (r *rpcETHAPI) GetTransactionReceipt(txHash types.Hash) (any, error) {
-	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
+func (r *rpcETHAPI) GetTransactionReceipt(ctx context.Context, txHash types.Hash) (any, error) {
+	ctx, ctxCancel := r.handler.withTimeout(ctx, "eth_getTransactionReceipt")
 	defer ctxCancel()
 
 	res := &types.TransactionReceiptType{}
@@ -225,8 +285,8 @@ func (r *rpcETHAPI) GetTransactionReceipt(txHash types.Hash) (any, error) {
 // SendRawTransaction implements the "eth_sendRawTransaction" call.
 //
 // It returns the most common response.
-func (r *rpcETHAPI) SendRawTransaction(data types.Bytes) (any, error) {
-	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
+func (r *rpcETHAPI) SendRawTransaction(ctx context.Context, data types.Bytes) (any, error) {
+	ctx, ctxCancel := r.handler.withTimeout(ctx, "eth_sendRawTransaction")
 	defer ctxCancel()
 
 	res := &types.Hash{}
@@ -243,8 +303,8 @@ func (r *rpcETHAPI) SendRawTransaction(data types.Bytes) (any, error) {
 // If the block number is set to "latest" or "pending", it will be replaced by
 // the block number returned by the BlockNumber method. The "earliest" tag is
 // not supported.
-func (r *rpcETHAPI) GetBalance(addr types.Address, blockID types.BlockNumber) (any, error) {
-	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
+func (r *rpcETHAPI) GetBalance(ctx context.Context, addr types.Address, blockID types.BlockNumber) (any, error) {
+	ctx, ctxCancel := r.handler.withTimeout(ctx, "eth_getBalance")
 	defer ctxCancel()
 
 	blockNumber, err := r.handler.taggedBlockToNumber(ctx, blockID)
@@ -265,8 +325,8 @@ func (r *rpcETHAPI) GetBalance(addr types.Address, blockID types.BlockNumber) (a
 // If the block number is set to "latest" or "pending", it will be replaced by
 // the block number returned by the BlockNumber method. The "earliest" tag is
 // not supported.
-func (r *rpcETHAPI) GetCode(addr types.Address, blockID types.BlockNumber) (any, error) {
-	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
+func (r *rpcETHAPI) GetCode(ctx context.Context, addr types.Address, blockID types.BlockNumber) (any, error) {
+	ctx, ctxCancel := r.handler.withTimeout(ctx, "eth_getCode")
 	defer ctxCancel()
 
 	blockNumber, err := r.handler.taggedBlockToNumber(ctx, blockID)
@@ -287,8 +347,8 @@ func (r *rpcETHAPI) GetCode(addr types.Address, blockID types.BlockNumber) (any,
 // If the block number is set to "latest" or "pending", it will be replaced by
 // the block number returned by the BlockNumber method. The "earliest" tag is
 // not supported.
-func (r *rpcETHAPI) GetStorageAt(data types.Address, pos types.Number, blockID types.BlockNumber) (any, error) {
-	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
+func (r *rpcETHAPI) GetStorageAt(ctx context.Context, data types.Address, pos types.Number, blockID types.BlockNumber) (any, error) {
+	ctx, ctxCancel := r.handler.withTimeout(ctx, "eth_getStorageAt")
 	defer ctxCancel()
 
 	blockNumber, err := r.handler.taggedBlockToNumber(ctx, blockID)
@@ -312,8 +372,8 @@ func (r *rpcETHAPI) GetStorageAt(data types.Address, pos types.Number, blockID t
 // If the block number is set to "latest" or "pending", it will be replaced by
 // the block number returned by the BlockNumber method. The "earliest" tag is
 // not supported.
-func (r *rpcETHAPI) Call(args Any, blockID types.BlockNumber, overrides *Any) (any, error) {
-	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
+func (r *rpcETHAPI) Call(ctx context.Context, args Any, blockID types.BlockNumber, overrides *Any) (any, error) {
+	ctx, ctxCancel := r.handler.withTimeout(ctx, "eth_call")
 	defer ctxCancel()
 
 	blockNumber, err := r.handler.taggedBlockToNumber(ctx, blockID)
@@ -334,8 +394,8 @@ func (r *rpcETHAPI) Call(args Any, blockID types.BlockNumber, overrides *Any) (a
 // If the block number is set to "latest" or "pending", it will be replaced by
 // the block number returned by the BlockNumber method. The "earliest" tag is
 // not supported.
-func (r *rpcETHAPI) GetLogs(logFilter types.FilterLogsQuery) (any, error) {
-	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
+func (r *rpcETHAPI) GetLogs(ctx context.Context, logFilter types.FilterLogsQuery) (any, error) {
+	ctx, ctxCancel := r.handler.withTimeout(ctx, "eth_getLogs")
 	defer ctxCancel()
 
 	if logFilter.FromBlock != nil {
@@ -364,8 +424,8 @@ func (r *rpcETHAPI) GetLogs(logFilter types.FilterLogsQuery) (any, error) {
 //
 // The number returned by this method is the median of all numbers returned
 // by the endpoints.
-func (r *rpcETHAPI) GasPrice() (any, error) {
-	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
+func (r *rpcETHAPI) GasPrice(ctx context.Context) (any, error) {
+	ctx, ctxCancel := r.handler.withTimeout(ctx, "eth_gasPrice")
 	defer ctxCancel()
 
 	res := &types.Number{}
@@ -382,8 +442,8 @@ func (r *rpcETHAPI) GasPrice() (any, error) {
 // If the block number is set to "latest" or "pending", it will be replaced by
 // the block number returned by the BlockNumber method. The "earliest" tag is
 // not supported.
-func (r *rpcETHAPI) EstimateGas(args Any, blockID types.BlockNumber) (any, error) {
-	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
+func (r *rpcETHAPI) EstimateGas(ctx context.Context, args Any, blockID types.BlockNumber) (any, error) {
+	ctx, ctxCancel := r.handler.withTimeout(ctx, "eth_estimateGas")
 	defer ctxCancel()
 
 	blockNumber, err := r.handler.taggedBlockToNumber(ctx, blockID)
@@ -400,8 +460,8 @@ func (r *rpcETHAPI) EstimateGas(args Any, blockID types.BlockNumber) (any, error
 //
 // It returns the most common response that occurred at least as many times as
 // specified in the minRes method.
-func (r *rpcETHAPI) FeeHistory(count types.Number, newestBlockID types.BlockNumber, percentiles Any) (any, error) {
-	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
+func (r *rpcETHAPI) FeeHistory(ctx context.Context, count types.Number, newestBlockID types.BlockNumber, percentiles Any) (any, error) {
+	ctx, ctxCancel := r.handler.withTimeout(ctx, "eth_feeHistory")
 	defer ctxCancel()
 
 	blockNumber, err := r.handler.taggedBlockToNumber(ctx, newestBlockID)
@@ -418,8 +478,8 @@ func (r *rpcETHAPI) FeeHistory(count types.Number, newestBlockID types.BlockNumb
 //
 // The number returned by this method is the median of all numbers returned
 // by the endpoints.
-func (r *rpcETHAPI) MaxPriorityFeePerGas() (any, error) {
-	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
+func (r *rpcETHAPI) MaxPriorityFeePerGas(ctx context.Context) (any, error) {
+	ctx, ctxCancel := r.handler.withTimeout(ctx, "eth_maxPriorityFeePerGas")
 	defer ctxCancel()
 
 	res := &types.Number{}
@@ -432,8 +492,8 @@ func (r *rpcETHAPI) MaxPriorityFeePerGas() (any, error) {
 //
 // It returns the most common response that occurred at least as many times as
 // specified in the minRes method.
-func (r *rpcETHAPI) ChainId() (any, error) { //nolint:revive,stylecheck
-	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
+func (r *rpcETHAPI) ChainId(ctx context.Context) (any, error) { //nolint:revive,stylecheck
+	ctx, ctxCancel := r.handler.withTimeout(ctx, "eth_chainId")
 	defer ctxCancel()
 
 	res := &types.Number{}
@@ -457,8 +517,8 @@ func (r *rpcETHAPI) ChainId() (any, error) { //nolint:revive,stylecheck
 //
 // It returns the most common response that occurred at least as many times as
 // specified in the minRes method.
-func (r *rpcNETAPI) Version() (any, error) {
-	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
+func (r *rpcNETAPI) Version(ctx context.Context) (any, error) {
+	ctx, ctxCancel := r.handler.withTimeout(ctx, "net_version")
 	defer ctxCancel()
 
 	res := &Any{}
@@ -467,6 +527,15 @@ func (r *rpcNETAPI) Version() (any, error) {
 	return res, err
 }
 
+// withTimeout returns a context with the total timeout for the method.
+func (s *server) withTimeout(ctx context.Context, method string) (context.Context, context.CancelFunc) {
+	timeout := s.totalTimeout
+	if o, ok := s.methods[method]; ok && o.TotalTimeout > 0 {
+		timeout = o.TotalTimeout
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
 // taggedBlockToNumber returns a block number for tagged blocks. This is
 // necessary because different RPC endpoints may convert tags to different
 // block numbers.
@@ -493,10 +562,10 @@ func (s *server) taggedBlockToNumber(ctx context.Context, blockID types.BlockNum
 
 // call executes RPC on all endpoints with the given arguments. If the context is
 // canceled before the call has successfully returned, call returns immediately.
+// If the cache is enabled, cached responses are returned without calling
+// the endpoints.
 //
 // The result must be a pointer with a proper type.
-//
-//nolint:funlen
 func (s *server) call(
 	ctx context.Context,
 	resolver resolver,
@@ -508,6 +577,24 @@ func (s *server) call(
 	if reflect.TypeOf(result).Kind() != reflect.Ptr {
 		return fmt.Errorf("call result parameter must be pointer")
 	}
+	if s.cache == nil {
+		return s.callEndpoints(ctx, resolver, result, method, args...)
+	}
+	return s.cache.do(ctx, result, method, args, func(ctx context.Context, result any) error {
+		return s.callEndpoints(ctx, resolver, result, method, args...)
+	})
+}
+
+// callEndpoints executes RPC on all endpoints and resolves their responses.
+//
+//nolint:funlen
+func (s *server) callEndpoints(
+	ctx context.Context,
+	resolver resolver,
+	result any,
+	method string,
+	args ...any,
+) error {
 
 	// Recover from panics.
 	defer func() {
@@ -520,14 +607,26 @@ func (s *server) call(
 		}
 	}()
 
+	// Apply method options.
+	gracefulTimeout := s.gracefulTimeout
+	if o, ok := s.methods[method]; ok {
+		if o.GracefulTimeout > 0 {
+			gracefulTimeout = o.GracefulTimeout
+		}
+		if o.MinResponses > 0 {
+			resolver = withMinResponses(resolver, o.MinResponses)
+		}
+	}
+
 	// Send request to all endpoints.
-	ch := make(chan any, len(s.callers))
+	ch := make(chan response, len(s.callers))
 	rt := reflect.TypeOf(result).Elem()
 	for n, c := range s.callers {
 		n, c := n, c
 		go func() {
 			t := time.Now()
 			var res any
+			var raw json.RawMessage
 			var err error
 			defer func() {
 				if r := recover(); r != nil {
@@ -542,7 +641,7 @@ func (s *server) call(
 						WithField("duration", time.Since(t)).
 						WithError(err).
 						Debug("Call error")
-					ch <- err
+					ch <- response{endpoint: n, value: err, latency: time.Since(t)}
 				default:
 					s.log.
 						WithField("name", n).
@@ -550,20 +649,24 @@ func (s *server) call(
 						// WithField("args", args).
 						WithField("duration", time.Since(t)).
 						Debug("Call")
-					ch <- res
+					ch <- response{endpoint: n, value: res, raw: raw, latency: time.Since(t)}
 				}
 			}()
 			res = reflect.New(rt).Interface()
-			err = c.CallContext(ctx, res, method, removeTrailingNilArgs(args)...)
+			if s.forensics != nil {
+				raw, err = callEndpointRaw(ctx, n, c, res, method, removeTrailingNilArgs(args)...)
+				return
+			}
+			err = callEndpoint(ctx, n, c, res, method, removeTrailingNilArgs(args)...)
 		}()
 	}
 	// Wait for response. The following code will wait for the above requests
 	// to complete, but if gracefulTimeout exceeds and there are enough
 	// responses to return a valid response, then the context will be canceled
 	// and the response returned.
-	t := time.NewTimer(s.gracefulTimeout)
+	t := time.NewTimer(gracefulTimeout)
 	defer t.Stop()
-	var rs []any
+	var rs []response
 	for {
 		wait := true
 		select {
@@ -576,18 +679,42 @@ func (s *server) call(
 			wait = false
 		}
 		if !wait {
-			res, err := resolver.resolve(rs)
+			res, err := s.resolve(resolver, rs)
 			switch {
 			case err == nil:
+				s.reputation.update(resolver, rs, res)
+				s.recordDisagreement(resolver, method, args, rs, res, nil)
 				reflect.ValueOf(result).Elem().Set(reflect.ValueOf(res).Elem())
 				return nil
 			case len(rs) >= len(s.callers):
+				s.reputation.update(resolver, rs, nil)
+				s.recordDisagreement(resolver, method, args, rs, nil, err)
 				return err
 			}
 		}
 	}
 }
 
+// resolve resolves responses from endpoints that are not quarantined. If the
+// resolver supports weights, responses are weighted by the endpoint scores.
+func (s *server) resolve(resolver resolver, rs []response) (any, error) {
+	vals, weights := s.reputation.active(rs)
+	if r, ok := resolver.(weightedResolver); ok {
+		return r.resolveWeighted(vals, weights)
+	}
+	return resolver.resolve(vals)
+}
+
+// recordDisagreement records the resolution in the forensics log if it
+// failed or was contested.
+func (s *server) recordDisagreement(resolver resolver, method string, args []any, rs []response, result any, err error) {
+	if ferr := s.forensics.record(resolver, method, args, rs, result, err); ferr != nil {
+		s.log.
+			WithError(ferr).
+			Warn("Unable to write the disagreement to the forensics sink")
+	}
+}
+
 // removeTrailingNilArgs removes trailing nil parameters from the params
 // slice. Some RPC servers do not like null parameters and will return a
 // "bad request" error if they occur.
@@ -1390,6 +1390,40 @@ func Test_RPC_Timeout(t *testing.T) {
 	})
 }
 
+func Test_RPC_MethodOptions(t *testing.T) {
+	t.Run("min-responses", func(t *testing.T) {
+		prepareHandlerTest(t, 3, "eth_chainId").
+			setOptions(WithRequirements(2, 10), WithMethodOptions("eth_chainId", MethodOptions{MinResponses: 3})).
+			mockClientCall(0, `0x1`, "eth_chainId").
+			mockClientCall(1, `0x1`, "eth_chainId").
+			mockClientCall(2, errors.New("error#1"), "eth_chainId").
+			expectedError("error#1").
+			test()
+	})
+	t.Run("other-method", func(t *testing.T) {
+		prepareHandlerTest(t, 3, "net_version").
+			setOptions(WithRequirements(2, 10), WithMethodOptions("eth_chainId", MethodOptions{MinResponses: 3})).
+			mockClientCall(0, 1, "net_version").
+			mockClientCall(1, 1, "net_version").
+			mockClientCall(2, errors.New("error#1"), "net_version").
+			expectedResult(1).
+			test()
+	})
+	t.Run("total-timeout", func(t *testing.T) {
+		prepareHandlerTest(t, 3, "eth_blockNumber").
+			setOptions(
+				WithRequirements(2, 10),
+				WithTotalTimeout(time.Second),
+				WithMethodOptions("eth_blockNumber", MethodOptions{TotalTimeout: 100 * time.Millisecond, GracefulTimeout: 100 * time.Millisecond}),
+			).
+			mockClientSlowCall(time.Millisecond*50, 0, 1, "eth_blockNumber").
+			mockClientSlowCall(time.Millisecond*150, 1, 1, "eth_blockNumber").
+			mockClientSlowCall(time.Millisecond*150, 2, 1, "eth_blockNumber").
+			expectedError("context cancelled").
+			test()
+	})
+}
+
 func newAny(j string) *Any {
 	t := &Any{}
 	if err := t.UnmarshalJSON([]byte(j)); err != nil {
@@ -0,0 +1,247 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpcsplitter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/rpcsplitter/types"
+)
+
+// subscriptionVotesSize is the number of recent notifications for which
+// votes are remembered. It is used to prevent sending the same notification
+// twice.
+const subscriptionVotesSize = 1024
+
+// subscriber is implemented by callers that support subscriptions.
+type subscriber interface {
+	ethSubscribe(ctx context.Context, ch chan<- json.RawMessage, args ...any) (subscription, error)
+}
+
+// subscription is an upstream subscription.
+type subscription interface {
+	Unsubscribe()
+	Err() <-chan error
+}
+
+// gethClient adds support for subscriptions to the go-ethereum RPC client.
+type gethClient struct {
+	*gethRPC.Client
+}
+
+func (c *gethClient) ethSubscribe(ctx context.Context, ch chan<- json.RawMessage, args ...any) (subscription, error) {
+	sub, err := c.EthSubscribe(ctx, ch, args...)
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// NewHeads implements the "eth_subscribe" call with the "newHeads"
+// subscription type.
+//
+// A header is sent once it is reported by at least as many endpoints as
+// specified in the minRes method.
+func (r *rpcETHAPI) NewHeads(ctx context.Context) (*gethRPC.Subscription, error) {
+	return r.handler.subscribe(ctx, headKey, "newHeads")
+}
+
+// Logs implements the "eth_subscribe" call with the "logs" subscription type.
+//
+// A log is sent once it is reported by at least as many endpoints as
+// specified in the minRes method.
+func (r *rpcETHAPI) Logs(ctx context.Context, crit types.FilterLogsQuery) (*gethRPC.Subscription, error) {
+	return r.handler.subscribe(ctx, logKey, "logs", crit)
+}
+
+type notification struct {
+	endpoint string
+	msg      json.RawMessage
+}
+
+// subscribe creates subscriptions on all endpoints that support them and
+// forwards notifications that were reported by enough endpoints. The key
+// function returns a key that identifies the same notification from
+// different endpoints.
+func (s *server) subscribe(ctx context.Context, key func(json.RawMessage) (string, error), args ...any) (*gethRPC.Subscription, error) {
+	notifier, ok := gethRPC.NotifierFromContext(ctx)
+	if !ok {
+		return nil, gethRPC.ErrNotificationsUnsupported
+	}
+	minResponses := s.defaultResolver.minResponses
+	subCtx, subCtxCancel := context.WithCancel(context.Background())
+	notificationCh := make(chan notification)
+	var (
+		subs []subscription
+		errs []error
+	)
+	for n, c := range s.callers {
+		sc, ok := c.(subscriber)
+		if !ok {
+			continue
+		}
+		ch := make(chan json.RawMessage)
+		sub, err := sc.ethSubscribe(ctx, ch, args...)
+		if err != nil {
+			s.log.
+				WithField("name", n).
+				WithError(err).
+				Warn("Unable to subscribe")
+			errs = append(errs, err)
+			continue
+		}
+		subs = append(subs, sub)
+		go s.forwardNotifications(subCtx, n, sub, ch, notificationCh)
+	}
+	unsubscribe := func() {
+		subCtxCancel()
+		for _, sub := range subs {
+			sub.Unsubscribe()
+		}
+	}
+	if len(subs) == 0 || len(subs) < minResponses {
+		unsubscribe()
+		return nil, addError(errNotEnoughResponses, errs...)
+	}
+	rpcSub := notifier.CreateSubscription()
+	go func() {
+		defer unsubscribe()
+		votes := newVotes(subscriptionVotesSize)
+		for {
+			select {
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			case n := <-notificationCh:
+				k, err := key(n.msg)
+				if err != nil {
+					s.log.
+						WithField("name", n.endpoint).
+						WithError(err).
+						Warn("Invalid notification")
+					continue
+				}
+				if votes.add(k, n.endpoint) == minResponses {
+					if err := notifier.Notify(rpcSub.ID, n.msg); err != nil {
+						return
+					}
+				}
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// forwardNotifications forwards notifications from the upstream subscription
+// until the context is canceled or the subscription fails.
+func (s *server) forwardNotifications(
+	ctx context.Context,
+	endpoint string,
+	sub subscription,
+	ch <-chan json.RawMessage,
+	out chan<- notification,
+) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err := <-sub.Err():
+			if err != nil {
+				s.log.
+					WithField("name", endpoint).
+					WithError(err).
+					Warn("Subscription failed")
+			}
+			return
+		case msg := <-ch:
+			select {
+			case out <- notification{endpoint: endpoint, msg: msg}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// votes counts endpoints that reported the same notification. Only the
+// given number of recent notifications is remembered.
+type votes struct {
+	size  int
+	keys  []string
+	votes map[string]map[string]struct{}
+}
+
+func newVotes(size int) *votes {
+	return &votes{size: size, votes: make(map[string]map[string]struct{})}
+}
+
+// add adds a vote of the endpoint and returns the number of endpoints that
+// voted for the key.
+func (v *votes) add(key, endpoint string) int {
+	e, ok := v.votes[key]
+	if !ok {
+		if len(v.keys) >= v.size {
+			delete(v.votes, v.keys[0])
+			v.keys = v.keys[1:]
+		}
+		e = make(map[string]struct{})
+		v.votes[key] = e
+		v.keys = append(v.keys, key)
+	}
+	if _, ok := e[endpoint]; ok {
+		// Do not count the same vote twice, this prevents sending the same
+		// notification again.
+		return 0
+	}
+	e[endpoint] = struct{}{}
+	return len(e)
+}
+
+// headKey identifies headers by their hash.
+func headKey(msg json.RawMessage) (string, error) {
+	var h struct {
+		Hash types.Hash `json:"hash"`
+	}
+	if err := json.Unmarshal(msg, &h); err != nil {
+		return "", err
+	}
+	return h.Hash.String(), nil
+}
+
+// logKey identifies logs by their content.
+func logKey(msg json.RawMessage) (string, error) {
+	var l types.Log
+	if err := json.Unmarshal(msg, &l); err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// isWebsocket reports whether the request is a WebSocket upgrade request.
+func isWebsocket(req *http.Request) bool {
+	return strings.EqualFold(req.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(req.Header.Get("Connection")), "upgrade")
+}
@@ -0,0 +1,159 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpcsplitter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// subMockClient is a caller that supports subscriptions. Channels of created
+// subscriptions are sent to chCh.
+type subMockClient struct {
+	chCh  chan chan<- json.RawMessage
+	errCh chan error
+}
+
+func newSubMockClient() *subMockClient {
+	return &subMockClient{
+		chCh:  make(chan chan<- json.RawMessage, 1),
+		errCh: make(chan error),
+	}
+}
+
+func (c *subMockClient) CallContext(context.Context, any, string, ...any) error {
+	return errors.New("not implemented")
+}
+
+func (c *subMockClient) ethSubscribe(_ context.Context, ch chan<- json.RawMessage, _ ...any) (subscription, error) {
+	c.chCh <- ch
+	return c, nil
+}
+
+func (c *subMockClient) Unsubscribe() {}
+
+func (c *subMockClient) Err() <-chan error {
+	return c.errCh
+}
+
+func TestServer_NewHeads(t *testing.T) {
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer ctxCancel()
+
+	clients := []*subMockClient{newSubMockClient(), newSubMockClient(), newSubMockClient()}
+	h, err := NewServer(
+		withCallers(map[string]caller{"a": clients[0], "b": clients[1], "c": clients[2]}),
+		WithRequirements(2, 1),
+	)
+	require.NoError(t, err)
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	client, err := gethRPC.DialContext(ctx, "ws"+strings.TrimPrefix(srv.URL, "http"))
+	require.NoError(t, err)
+	defer client.Close()
+
+	heads := make(chan json.RawMessage)
+	sub, err := client.EthSubscribe(ctx, heads, "newHeads")
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	var upstream []chan<- json.RawMessage
+	for _, c := range clients {
+		upstream = append(upstream, <-c.chCh)
+	}
+	headA := json.RawMessage(`{"hash":"0x1000000000000000000000000000000000000000000000000000000000000000","number":"0x1"}`)
+	headB := json.RawMessage(`{"hash":"0x2000000000000000000000000000000000000000000000000000000000000000","number":"0x2"}`)
+
+	// The head is sent after it is reported by two endpoints:
+	upstream[0] <- headA
+	upstream[1] <- headA
+	upstream[2] <- headA
+	upstream[0] <- headB
+	upstream[0] <- headB
+	upstream[2] <- headB
+
+	for _, exp := range []json.RawMessage{headA, headB} {
+		select {
+		case head := <-heads:
+			assert.JSONEq(t, string(exp), string(head))
+		case <-ctx.Done():
+			require.Fail(t, "head not received")
+		}
+	}
+	select {
+	case head := <-heads:
+		assert.Fail(t, "unexpected head", string(head))
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestServer_SubscribeNotEnoughEndpoints(t *testing.T) {
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer ctxCancel()
+
+	// Only one endpoint supports subscriptions:
+	h, err := NewServer(
+		withCallers(map[string]caller{"a": newSubMockClient(), "b": &mockClient{t: t}}),
+		WithRequirements(2, 1),
+	)
+	require.NoError(t, err)
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	client, err := gethRPC.DialContext(ctx, "ws"+strings.TrimPrefix(srv.URL, "http"))
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.EthSubscribe(ctx, make(chan json.RawMessage), "newHeads")
+	assert.Error(t, err)
+}
+
+func Test_logKey(t *testing.T) {
+	a, err := logKey(json.RawMessage(`{"address":"0x1234567890123456789012345678901234567890","topics":[],"data":"0x01","blockHash":"0x1000000000000000000000000000000000000000000000000000000000000000","blockNumber":"0x1","transactionHash":"0x2000000000000000000000000000000000000000000000000000000000000000","transactionIndex":"0x0","logIndex":"0x0","removed":false}`))
+	require.NoError(t, err)
+
+	// Field order and additional fields must not matter:
+	b, err := logKey(json.RawMessage(`{"removed":false,"logIndex":"0x0","transactionIndex":"0x0","transactionHash":"0x2000000000000000000000000000000000000000000000000000000000000000","blockNumber":"0x1","blockHash":"0x1000000000000000000000000000000000000000000000000000000000000000","data":"0x01","topics":[],"address":"0x1234567890123456789012345678901234567890","foo":"bar"}`))
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	// Removed logs are different notifications:
+	c, err := logKey(json.RawMessage(`{"address":"0x1234567890123456789012345678901234567890","topics":[],"data":"0x01","blockHash":"0x1000000000000000000000000000000000000000000000000000000000000000","blockNumber":"0x1","transactionHash":"0x2000000000000000000000000000000000000000000000000000000000000000","transactionIndex":"0x0","logIndex":"0x0","removed":true}`))
+	require.NoError(t, err)
+	assert.NotEqual(t, a, c)
+}
+
+func Test_votes(t *testing.T) {
+	v := newVotes(2)
+	assert.Equal(t, 1, v.add("a", "x"))
+	assert.Equal(t, 0, v.add("a", "x"))
+	assert.Equal(t, 2, v.add("a", "y"))
+	assert.Equal(t, 1, v.add("b", "x"))
+	assert.Equal(t, 1, v.add("c", "x"))
+
+	// The oldest key is forgotten:
+	assert.Equal(t, 1, v.add("a", "x"))
+}
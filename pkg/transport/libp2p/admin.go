@@ -0,0 +1,385 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package libp2p
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/httpserver"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/libp2p/crypto/ethkey"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/libp2p/internal"
+)
+
+// Paths of the admin API endpoints:
+const (
+	AdminPeersPath   = "/peers"
+	AdminTopicsPath  = "/topics"
+	AdminBlockedPath = "/blocked"
+	AdminBlockPath   = "/block"
+	AdminUnblockPath = "/unblock"
+)
+
+// adminTimeout is the timeout for the admin API requests.
+const adminTimeout = 10 * time.Second
+
+// AdminPeer describes a connected peer.
+type AdminPeer struct {
+	// ID is the peer ID.
+	ID string `json:"id"`
+
+	// Address is the Ethereum address derived from the peer ID.
+	Address string `json:"address"`
+
+	// Addrs is the list of known peer's multiaddresses.
+	Addrs []string `json:"addrs"`
+
+	// UserAgent is the user agent sent using the identify protocol.
+	UserAgent string `json:"user_agent"`
+
+	// AppUserAgent is the application name and version taken from the last
+	// message authored by the peer, e.g. from the Greet message.
+	AppUserAgent string `json:"app_user_agent,omitempty"`
+
+	// ProtocolVersion is the protocol version sent using the identify
+	// protocol.
+	ProtocolVersion string `json:"protocol_version"`
+
+	// Score is the gossipsub peer score.
+	Score *AdminPeerScore `json:"score,omitempty"`
+
+	// RelayRateLimiter is the state of the rate limiter for messages
+	// relayed by the peer.
+	RelayRateLimiter *AdminRateLimiter `json:"relay_rate_limiter,omitempty"`
+
+	// AuthorRateLimiter is the state of the rate limiter for messages
+	// authored by the peer.
+	AuthorRateLimiter *AdminRateLimiter `json:"author_rate_limiter,omitempty"`
+}
+
+// AdminPeerScore is the gossipsub score of a peer.
+type AdminPeerScore struct {
+	Score              float64                    `json:"score"`
+	AppSpecificScore   float64                    `json:"app_specific_score"`
+	IPColocationFactor float64                    `json:"ip_colocation_factor"`
+	BehaviourPenalty   float64                    `json:"behaviour_penalty"`
+	Topics             map[string]AdminTopicScore `json:"topics,omitempty"`
+}
+
+// AdminTopicScore is the gossipsub score of a peer in a single topic.
+type AdminTopicScore struct {
+	TimeInMesh               time.Duration `json:"time_in_mesh"`
+	FirstMessageDeliveries   float64       `json:"first_message_deliveries"`
+	MeshMessageDeliveries    float64       `json:"mesh_message_deliveries"`
+	InvalidMessageDeliveries float64       `json:"invalid_message_deliveries"`
+}
+
+// AdminRateLimiter is the state of the rate limiter for a single peer.
+type AdminRateLimiter struct {
+	Tokens      float64   `json:"tokens"`
+	LastMessage time.Time `json:"last_message"`
+}
+
+// AdminTopic describes a subscribed topic.
+type AdminTopic struct {
+	// Topic is the topic name.
+	Topic string `json:"topic"`
+
+	// Peers is the list of peers subscribed to the topic.
+	Peers []string `json:"peers"`
+
+	// MeshPeers is the list of peers in the gossipsub mesh of the topic.
+	MeshPeers []string `json:"mesh_peers"`
+}
+
+// AdminBlocked is the list of blocked peers and IP addresses.
+type AdminBlocked struct {
+	Peers []string `json:"peers"`
+	IPs   []string `json:"ips"`
+}
+
+// AdminBlockRequest is the request body for the block and unblock endpoints.
+type AdminBlockRequest struct {
+	// Addr is a multiaddress that contains an IP address, a peer ID or both.
+	Addr string `json:"addr"`
+}
+
+// adminHandler returns the HTTP handler for the admin API.
+func (p *P2P) adminHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(AdminPeersPath, httpserver.JSONMethod(http.MethodGet, func(r *http.Request) (any, error) {
+		return p.adminPeers(), nil
+	}))
+	mux.HandleFunc(AdminTopicsPath, httpserver.JSONMethod(http.MethodGet, func(r *http.Request) (any, error) {
+		return p.adminTopics(), nil
+	}))
+	mux.HandleFunc(AdminBlockedPath, httpserver.JSONMethod(http.MethodGet, func(r *http.Request) (any, error) {
+		return p.adminBlocked(), nil
+	}))
+	mux.HandleFunc(AdminBlockPath, httpserver.JSONMethod(http.MethodPost, func(r *http.Request) (any, error) {
+		return p.adminBlockHandler(r, true)
+	}))
+	mux.HandleFunc(AdminUnblockPath, httpserver.JSONMethod(http.MethodPost, func(r *http.Request) (any, error) {
+		return p.adminBlockHandler(r, false)
+	}))
+	return mux
+}
+
+func (p *P2P) adminPeers() []AdminPeer {
+	h := p.node.Host()
+	ps := p.node.Peerstore()
+	scores := p.node.PeerScores()
+	relayRL := p.node.RelayRateLimiterState()
+	authorRL := p.node.AuthorRateLimiterState()
+	peers := make([]AdminPeer, 0)
+	for _, id := range h.Network().Peers() {
+		ap := AdminPeer{
+			ID:              id.String(),
+			Address:         ethkey.PeerIDToAddress(id).String(),
+			Addrs:           make([]string, 0),
+			UserAgent:       internal.GetPeerUserAgent(ps, id),
+			AppUserAgent:    p.peerUserAgent(id),
+			ProtocolVersion: internal.GetPeerProtocolVersion(ps, id),
+		}
+		for _, addr := range ps.Addrs(id) {
+			ap.Addrs = append(ap.Addrs, addr.String())
+		}
+		if s, ok := scores[id]; ok && s != nil {
+			ap.Score = &AdminPeerScore{
+				Score:              s.Score,
+				AppSpecificScore:   s.AppSpecificScore,
+				IPColocationFactor: s.IPColocationFactor,
+				BehaviourPenalty:   s.BehaviourPenalty,
+				Topics:             make(map[string]AdminTopicScore, len(s.Topics)),
+			}
+			for topic, ts := range s.Topics {
+				if ts == nil {
+					continue
+				}
+				ap.Score.Topics[topic] = AdminTopicScore{
+					TimeInMesh:               ts.TimeInMesh,
+					FirstMessageDeliveries:   ts.FirstMessageDeliveries,
+					MeshMessageDeliveries:    ts.MeshMessageDeliveries,
+					InvalidMessageDeliveries: ts.InvalidMessageDeliveries,
+				}
+			}
+		}
+		if s, ok := relayRL[id]; ok {
+			ap.RelayRateLimiter = &AdminRateLimiter{Tokens: s.Tokens, LastMessage: s.LastMessage}
+		}
+		if s, ok := authorRL[id]; ok {
+			ap.AuthorRateLimiter = &AdminRateLimiter{Tokens: s.Tokens, LastMessage: s.LastMessage}
+		}
+		peers = append(peers, ap)
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].ID < peers[j].ID })
+	return peers
+}
+
+func (p *P2P) adminTopics() []AdminTopic {
+	topics := make([]AdminTopic, 0)
+	ps := p.node.PubSub()
+	if ps == nil {
+		return topics
+	}
+	for _, topic := range ps.GetTopics() {
+		topics = append(topics, AdminTopic{
+			Topic:     topic,
+			Peers:     peerIDsToStrs(ps.ListPeers(topic)),
+			MeshPeers: peerIDsToStrs(p.node.MeshPeers(topic)),
+		})
+	}
+	sort.Slice(topics, func(i, j int) bool { return topics[i].Topic < topics[j].Topic })
+	return topics
+}
+
+func (p *P2P) adminBlocked() AdminBlocked {
+	blocked := AdminBlocked{
+		Peers: peerIDsToStrs(p.node.BlockedPeers()),
+		IPs:   make([]string, 0),
+	}
+	for _, ip := range p.node.BlockedIPs() {
+		blocked.IPs = append(blocked.IPs, ip.String())
+	}
+	sort.Strings(blocked.IPs)
+	return blocked
+}
+
+func (p *P2P) adminBlockHandler(r *http.Request, block bool) (any, error) {
+	var req AdminBlockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+	maddr, err := multiaddr.NewMultiaddr(req.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %w", err)
+	}
+	var found bool
+	multiaddr.ForEach(maddr, func(c multiaddr.Component) bool {
+		switch c.Protocol().Code {
+		case multiaddr.P_IP4, multiaddr.P_IP6:
+			found = true
+			ip := net.ParseIP(c.Value())
+			if block {
+				err = p.node.BlockIP(ip)
+			} else {
+				err = p.node.UnblockIP(ip)
+			}
+		case multiaddr.P_P2P:
+			found = true
+			var pid peer.ID
+			pid, err = peer.IDFromBytes(c.RawValue())
+			if err != nil {
+				return false
+			}
+			if block {
+				err = p.node.BlockPeer(pid)
+			} else {
+				err = p.node.UnblockPeer(pid)
+			}
+		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errors.New("address must contain an IP address or a peer ID")
+	}
+	return p.adminBlocked(), nil
+}
+
+// setPeerUserAgent stores the application user agent of the message author.
+func (p *P2P) setPeerUserAgent(id peer.ID, userAgent string) {
+	if userAgent == "" || userAgent == "/" {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.userAgents[id] = userAgent
+}
+
+// peerUserAgent returns the application user agent of the message author.
+func (p *P2P) peerUserAgent(id peer.ID) string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.userAgents[id]
+}
+
+// removePeerUserAgent removes the application user agent of the message
+// author. It is called when the author disconnects.
+func (p *P2P) removePeerUserAgent(id peer.ID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.userAgents, id)
+}
+
+func peerIDsToStrs(ids []peer.ID) []string {
+	strs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		strs = append(strs, id.String())
+	}
+	sort.Strings(strs)
+	return strs
+}
+
+// AdminClient is a client for the admin API of the libp2p transport.
+type AdminClient struct {
+	addr   string
+	client *http.Client
+}
+
+// NewAdminClient creates a new admin API client. The addr is the address on
+// which the admin API listens, in the format `host:port`.
+func NewAdminClient(addr string) *AdminClient {
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
+	return &AdminClient{
+		addr:   strings.TrimRight(addr, "/"),
+		client: &http.Client{Timeout: adminTimeout},
+	}
+}
+
+// Peers returns the list of connected peers.
+func (c *AdminClient) Peers(ctx context.Context) ([]AdminPeer, error) {
+	var res []AdminPeer
+	return res, c.do(ctx, http.MethodGet, AdminPeersPath, nil, &res)
+}
+
+// Topics returns the list of subscribed topics.
+func (c *AdminClient) Topics(ctx context.Context) ([]AdminTopic, error) {
+	var res []AdminTopic
+	return res, c.do(ctx, http.MethodGet, AdminTopicsPath, nil, &res)
+}
+
+// Blocked returns the list of blocked peers and IP addresses.
+func (c *AdminClient) Blocked(ctx context.Context) (AdminBlocked, error) {
+	var res AdminBlocked
+	return res, c.do(ctx, http.MethodGet, AdminBlockedPath, nil, &res)
+}
+
+// Block blocks the peer ID and/or IP address from the given multiaddress.
+func (c *AdminClient) Block(ctx context.Context, addr string) (AdminBlocked, error) {
+	var res AdminBlocked
+	return res, c.do(ctx, http.MethodPost, AdminBlockPath, AdminBlockRequest{Addr: addr}, &res)
+}
+
+// Unblock unblocks the peer ID and/or IP address from the given multiaddress.
+func (c *AdminClient) Unblock(ctx context.Context, addr string) (AdminBlocked, error) {
+	var res AdminBlocked
+	return res, c.do(ctx, http.MethodPost, AdminUnblockPath, AdminBlockRequest{Addr: addr}, &res)
+}
+
+func (c *AdminClient) do(ctx context.Context, method, path string, req, res any) error {
+	var body io.Reader
+	if req != nil {
+		b, err := json.Marshal(req)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, c.addr+path, body)
+	if err != nil {
+		return err
+	}
+	httpRes, err := c.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpRes.Body.Close()
+	if httpRes.StatusCode != http.StatusOK {
+		var e httpserver.JSONError
+		if err := json.NewDecoder(httpRes.Body).Decode(&e); err != nil || e.Error == "" {
+			return fmt.Errorf("admin API error: %s", httpRes.Status)
+		}
+		return fmt.Errorf("admin API error: %s", e.Error)
+	}
+	return json.NewDecoder(httpRes.Body).Decode(res)
+}
@@ -0,0 +1,86 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package libp2p
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/defiweb/go-eth/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+)
+
+func TestAdminAPI(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	p, err := New(Config{
+		Mode:            ClientMode,
+		Topics:          map[string]transport.Message{messages.DataPointV1MessageName: (*messages.DataPoint)(nil)},
+		ListenAddrs:     []string{"/ip4/127.0.0.1/tcp/0"},
+		AuthorAllowlist: []types.Address{types.MustAddressFromHex("0x1234567890123456789012345678901234567890")},
+	})
+	require.NoError(t, err)
+	require.NoError(t, p.Start(ctx))
+
+	srv := httptest.NewServer(p.adminHandler())
+	defer srv.Close()
+	c := NewAdminClient(srv.URL)
+
+	peers, err := c.Peers(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, peers)
+
+	topics, err := c.Topics(ctx)
+	require.NoError(t, err)
+	require.Len(t, topics, 1)
+	assert.Equal(t, messages.DataPointV1MessageName, topics[0].Topic)
+
+	addr := "/ip4/10.0.0.1/tcp/8000/p2p/12D3KooWRfYU5FaY9SmJcRD5Ku7c1XMBRqV6oM4nsnGQ1QRakSJi"
+	blocked, err := c.Block(ctx, addr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"12D3KooWRfYU5FaY9SmJcRD5Ku7c1XMBRqV6oM4nsnGQ1QRakSJi"}, blocked.Peers)
+	assert.Equal(t, []string{"10.0.0.1"}, blocked.IPs)
+
+	blocked, err = c.Unblock(ctx, addr)
+	require.NoError(t, err)
+	assert.Empty(t, blocked.Peers)
+	assert.Empty(t, blocked.IPs)
+
+	_, err = c.Block(ctx, "/tcp/8000")
+	assert.Error(t, err)
+}
+
+func TestPeerUserAgent(t *testing.T) {
+	p := &P2P{userAgents: map[peer.ID]string{}}
+	id := peer.ID("peer")
+
+	p.setPeerUserAgent(id, "/")
+	assert.Empty(t, p.peerUserAgent(id))
+
+	p.setPeerUserAgent(id, "ghost/1.0.0")
+	assert.Equal(t, "ghost/1.0.0", p.peerUserAgent(id))
+
+	p.removePeerUserAgent(id)
+	assert.Empty(t, p.peerUserAgent(id))
+	assert.Empty(t, p.userAgents)
+}
@@ -41,6 +41,7 @@ var ErrConnectionClosed = errors.New("connection is closed")
 var ErrAlreadySubscribed = errors.New("topic is already subscribed")
 var ErrNotSubscribed = errors.New("topic is not subscribed")
 var ErrPubSubDisabled = errors.New("pubsub protocol is disabled")
+var ErrDenylistDisabled = errors.New("denylist is disabled")
 
 // Node is a single node in the P2P network. It wraps the libp2p library to
 // provide an easier to use and use-case agnostic interface for the pubsub
@@ -61,6 +62,11 @@ type Node struct {
 	validatorSet          *sets.ValidatorSet
 	messageHandlerSet     *sets.MessageHandlerSet
 	subs                  map[string]*Subscription
+	peerScores            map[peer.ID]*pubsub.PeerScoreSnapshot
+	denylist              *denylistConnGater
+	meshTracer            *meshTracer
+	relayRateLimiter      *rateLimiter
+	authorRateLimiter     *rateLimiter
 	tsLog                 tsLogger
 	disablePubSub         bool
 	closed                bool
@@ -186,6 +192,22 @@ func (n *Node) Peerstore() peerstore.Peerstore {
 	return n.peerstore
 }
 
+// PeerScores returns the last known gossipsub score snapshots. Scores are
+// available only if the PeerScoring option is used.
+func (n *Node) PeerScores() map[peer.ID]*pubsub.PeerScoreSnapshot {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.peerScores
+}
+
+func (n *Node) setPeerScores(scores map[peer.ID]*pubsub.PeerScoreSnapshot) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.peerScores = scores
+}
+
 func (n *Node) Connect(maddr multiaddr.Multiaddr) error {
 	pi, err := peer.AddrInfoFromP2pAddr(maddr)
 	if err != nil {
@@ -0,0 +1,338 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/multiformats/go-multiaddr"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/libp2p/internal/sets"
+)
+
+// Parameters of the address book:
+const addressBookSaveInterval = time.Minute
+const addressBookMaxPeers = 100
+const addressBookPeerTTL = 7 * 24 * time.Hour
+
+type AddressBookConfig struct {
+	// Path is the path to the file in which known peers are persisted.
+	Path string
+
+	// SaveInterval specifies how often the address book is written to
+	// the file. The address book is always saved when the node stops.
+	SaveInterval time.Duration
+
+	// MaxPeers is the maximum number of peers stored in the address book.
+	// Peers with the highest scores are preferred.
+	MaxPeers int
+
+	// PeerTTL is the time after which a peer that was not seen is removed
+	// from the address book.
+	PeerTTL time.Duration
+}
+
+// AddressBook persists peers to which the node was connected to a file,
+// together with their scores and last seen times. Peers from the file are
+// redialed when the node starts, so the node can rejoin the network even if
+// bootstrap nodes are not available.
+//
+// Peer scores are available only if the PeerScoring option is used. Peers
+// with a negative score are not stored.
+func AddressBook(cfg AddressBookConfig) Options {
+	return func(n *Node) error {
+		if cfg.Path == "" {
+			return nil
+		}
+		if cfg.SaveInterval == 0 {
+			cfg.SaveInterval = addressBookSaveInterval
+		}
+		if cfg.MaxPeers == 0 {
+			cfg.MaxPeers = addressBookMaxPeers
+		}
+		if cfg.PeerTTL == 0 {
+			cfg.PeerTTL = addressBookPeerTTL
+		}
+		ab := &addressBook{n: n, cfg: cfg, peers: make(map[peer.ID]*AddressBookEntry)}
+		if err := ab.load(); err != nil {
+			// A corrupted address book must not prevent the node from
+			// starting, it will be overwritten with the new data.
+			n.tsLog.get().
+				WithError(err).
+				WithField("path", cfg.Path).
+				Warn("Unable to load the address book")
+		}
+		n.AddNotifee(ab)
+		n.AddNodeEventHandler(sets.NodeEventHandlerFunc(func(event interface{}) {
+			switch event.(type) {
+			case sets.NodeStartedEvent:
+				go ab.dialRoutine()
+			case sets.NodeStoppingEvent:
+				ab.save()
+			}
+		}))
+		return nil
+	}
+}
+
+// AddressBookEntry is a single peer stored in the address book.
+type AddressBookEntry struct {
+	ID       peer.ID   `json:"id"`
+	Addrs    []string  `json:"addrs"`
+	Score    float64   `json:"score"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+type addressBookFile struct {
+	Peers []*AddressBookEntry `json:"peers"`
+}
+
+type addressBook struct {
+	mu sync.Mutex
+
+	n     *Node
+	cfg   AddressBookConfig
+	peers map[peer.ID]*AddressBookEntry
+}
+
+// load reads the address book from the file. A missing file is not
+// considered an error.
+func (a *addressBook) load() error {
+	b, err := os.ReadFile(a.cfg.Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	var f addressBookFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, e := range f.Peers {
+		if e == nil || time.Since(e.LastSeen) > a.cfg.PeerTTL {
+			continue
+		}
+		a.peers[e.ID] = e
+	}
+	return nil
+}
+
+// save writes the address book to the file. To avoid corrupting the file
+// in case of a crash, the data is written to a temporary file first.
+func (a *addressBook) save() {
+	a.update()
+
+	a.mu.Lock()
+	var f addressBookFile
+	for _, e := range a.peers {
+		f.Peers = append(f.Peers, e)
+	}
+	a.mu.Unlock()
+
+	sort.Slice(f.Peers, func(i, j int) bool {
+		if f.Peers[i].Score == f.Peers[j].Score {
+			return f.Peers[i].LastSeen.After(f.Peers[j].LastSeen)
+		}
+		return f.Peers[i].Score > f.Peers[j].Score
+	})
+	if len(f.Peers) > a.cfg.MaxPeers {
+		f.Peers = f.Peers[:a.cfg.MaxPeers]
+	}
+
+	err := func() error {
+		b, err := json.MarshalIndent(f, "", "  ")
+		if err != nil {
+			return err
+		}
+		tmp, err := os.CreateTemp(filepath.Dir(a.cfg.Path), filepath.Base(a.cfg.Path)+".*")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(tmp.Name())
+		if _, err := tmp.Write(b); err != nil {
+			_ = tmp.Close()
+			return err
+		}
+		if err := tmp.Close(); err != nil {
+			return err
+		}
+		return os.Rename(tmp.Name(), a.cfg.Path)
+	}()
+	if err != nil {
+		a.n.tsLog.get().
+			WithError(err).
+			WithField("path", a.cfg.Path).
+			Warn("Unable to save the address book")
+	}
+}
+
+// update updates scores, addresses and last seen times of the currently
+// connected peers and removes expired and misbehaving peers.
+func (a *addressBook) update() {
+	if a.n.host == nil {
+		return
+	}
+	scores := a.n.PeerScores()
+	now := time.Now()
+	connected := a.n.host.Network().Peers()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, id := range connected {
+		e, ok := a.peers[id]
+		if !ok {
+			e = &AddressBookEntry{ID: id}
+			a.peers[id] = e
+		}
+		if addrs := a.n.peerstore.Addrs(id); len(addrs) > 0 {
+			e.Addrs = maddrsToStrs(addrs)
+		}
+		e.LastSeen = now
+	}
+	for id, e := range a.peers {
+		if s, ok := scores[id]; ok && s != nil {
+			e.Score = s.Score
+		}
+		if e.Score < 0 || len(e.Addrs) == 0 || now.Sub(e.LastSeen) > a.cfg.PeerTTL {
+			delete(a.peers, id)
+		}
+	}
+}
+
+// dial connects to all peers from the address book.
+func (a *addressBook) dial() {
+	a.mu.Lock()
+	var addrInfos []peer.AddrInfo
+	for _, e := range a.peers {
+		if e.ID == a.n.host.ID() {
+			continue
+		}
+		maddrs, err := strsToMaddrs(e.Addrs)
+		if err != nil || len(maddrs) == 0 {
+			continue
+		}
+		addrInfos = append(addrInfos, peer.AddrInfo{ID: e.ID, Addrs: maddrs})
+	}
+	a.mu.Unlock()
+
+	for _, addrInfo := range addrInfos {
+		if a.n.host.Network().Connectedness(addrInfo.ID) == network.Connected {
+			continue
+		}
+		a.n.peerstore.AddAddrs(addrInfo.ID, addrInfo.Addrs, peerstore.AddressTTL)
+		go func(addrInfo peer.AddrInfo) {
+			if err := a.n.host.Connect(a.n.ctx, addrInfo); err != nil {
+				a.n.tsLog.get().
+					WithError(err).
+					WithFields(log.Fields{
+						"peerID": addrInfo.ID.String(),
+						"addrs":  addrInfo.Addrs,
+					}).
+					Debug("Unable to connect to the peer from the address book")
+			}
+		}(addrInfo)
+	}
+}
+
+func (a *addressBook) dialRoutine() {
+	a.mu.Lock()
+	peerCount := len(a.peers)
+	a.mu.Unlock()
+	a.n.tsLog.get().
+		WithField("peerCount", peerCount).
+		Info("Connecting to peers from the address book")
+	a.dial()
+	t := time.NewTicker(a.cfg.SaveInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-a.n.ctx.Done():
+			return
+		case <-t.C:
+			a.save()
+			// If the node lost all connections, try to reconnect to
+			// the known peers:
+			if len(a.n.host.Network().Peers()) == 0 {
+				a.dial()
+			}
+		}
+	}
+}
+
+// Listen implements the network.Notifiee interface.
+func (a *addressBook) Listen(network.Network, multiaddr.Multiaddr) {}
+
+// ListenClose implements the network.Notifiee interface.
+func (a *addressBook) ListenClose(network.Network, multiaddr.Multiaddr) {}
+
+// Connected implements the network.Notifiee interface.
+func (a *addressBook) Connected(_ network.Network, conn network.Conn) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	e, ok := a.peers[conn.RemotePeer()]
+	if !ok {
+		e = &AddressBookEntry{ID: conn.RemotePeer()}
+		a.peers[conn.RemotePeer()] = e
+	}
+	if conn.Stat().Direction == network.DirOutbound && len(e.Addrs) == 0 {
+		e.Addrs = []string{conn.RemoteMultiaddr().String()}
+	}
+	e.LastSeen = time.Now()
+}
+
+// Disconnected implements the network.Notifiee interface.
+func (a *addressBook) Disconnected(_ network.Network, conn network.Conn) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if e, ok := a.peers[conn.RemotePeer()]; ok {
+		e.LastSeen = time.Now()
+	}
+}
+
+func maddrsToStrs(maddrs []multiaddr.Multiaddr) []string {
+	strs := make([]string, len(maddrs))
+	for i, maddr := range maddrs {
+		strs[i] = maddr.String()
+	}
+	return strs
+}
+
+func strsToMaddrs(strs []string) ([]multiaddr.Multiaddr, error) {
+	maddrs := make([]multiaddr.Multiaddr, 0, len(strs))
+	for _, str := range strs {
+		maddr, err := multiaddr.NewMultiaddr(str)
+		if err != nil {
+			return nil, err
+		}
+		maddrs = append(maddrs, maddr)
+	}
+	return maddrs, nil
+}
@@ -0,0 +1,109 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNode_AddressBook(t *testing.T) {
+	// This test checks whether peers are persisted in the address book and
+	// redialed on startup when the AddressBook option is used.
+	//
+	// Topology:
+	//   n1 --[connect]--> n0
+	//   n2 --[address book]--> n0
+
+	peers, err := getNodeInfo(3)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "address_book.json")
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	n0, err := NewNode(
+		PeerPrivKey(peers[0].PrivKey),
+		ListenAddrs(peers[0].ListenAddrs),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n0.Start(ctx))
+
+	n1Ctx, n1CtxCancel := context.WithCancel(ctx)
+	n1, err := NewNode(
+		PeerPrivKey(peers[1].PrivKey),
+		ListenAddrs(peers[1].ListenAddrs),
+		AddressBook(AddressBookConfig{Path: path}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n1.Start(n1Ctx))
+	require.NoError(t, n1.Connect(peers[0].PeerAddrs[0]))
+
+	// The address book must be saved when the node stops:
+	n1CtxCancel()
+	<-n1.Wait()
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var f addressBookFile
+	require.NoError(t, json.Unmarshal(b, &f))
+	require.Len(t, f.Peers, 1)
+	assert.Equal(t, peers[0].ID, f.Peers[0].ID)
+	assert.NotEmpty(t, f.Peers[0].Addrs)
+	assert.WithinDuration(t, time.Now(), f.Peers[0].LastSeen, time.Minute)
+
+	// A new node using the same address book should connect to n0:
+	n2, err := NewNode(
+		PeerPrivKey(peers[2].PrivKey),
+		ListenAddrs(peers[2].ListenAddrs),
+		AddressBook(AddressBookConfig{Path: path}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n2.Start(ctx))
+
+	waitFor(t, func() bool {
+		return n2.Host().Network().Connectedness(peers[0].ID) == network.Connected
+	})
+}
+
+func TestNode_AddressBook_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "address_book.json")
+	require.NoError(t, os.WriteFile(path, []byte("invalid"), 0600))
+
+	// A corrupted address book must not prevent the node from starting:
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	n, err := NewNode(AddressBook(AddressBookConfig{Path: path}))
+	require.NoError(t, err)
+	require.NoError(t, n.Start(ctx))
+
+	ctxCancel()
+	<-n.Wait()
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, json.Valid(b))
+}
@@ -17,6 +17,7 @@ package internal
 
 import (
 	"net"
+	"sync"
 
 	"github.com/libp2p/go-libp2p/core/control"
 	"github.com/libp2p/go-libp2p/core/network"
@@ -26,20 +27,19 @@ import (
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 )
 
-// Denylist allows to block peer by their IP addresses or IDs.
+// Denylist allows to block peer by their IP addresses or IDs. Peers and
+// addresses may also be blocked and unblocked at runtime using the BlockPeer,
+// UnblockPeer, BlockIP and UnblockIP methods of the Node.
 func Denylist(addrs []multiaddr.Multiaddr) Options {
 	return func(n *Node) error {
-		if len(addrs) == 0 {
-			return nil
-		}
-
-		cg := &denylistConnGater{n: n}
+		cg := &denylistConnGater{n: n, filters: multiaddr.NewFilters(), pids: make(map[peer.ID]struct{})}
+		n.denylist = cg
 		n.AddConnectionGater(cg)
 		for _, maddr := range addrs {
 			multiaddr.ForEach(maddr, func(c multiaddr.Component) bool {
 				switch c.Protocol().Code {
 				case multiaddr.P_IP4, multiaddr.P_IP6:
-					cg.BlockIP(net.ParseIP(c.String()))
+					cg.BlockIP(net.ParseIP(c.Value()))
 				case multiaddr.P_P2P:
 					pid, err := peer.IDFromBytes(c.RawValue())
 					if err != nil {
@@ -54,60 +54,171 @@ func Denylist(addrs []multiaddr.Multiaddr) Options {
 	}
 }
 
+// BlockPeer blocks connections with the given peer and closes existing
+// connections. It requires the Denylist option.
+func (n *Node) BlockPeer(pid peer.ID) error {
+	if n.denylist == nil {
+		return ErrDenylistDisabled
+	}
+	n.denylist.BlockPID(pid)
+	if n.host != nil {
+		return n.host.Network().ClosePeer(pid)
+	}
+	return nil
+}
+
+// UnblockPeer removes the given peer from the denylist. It requires
+// the Denylist option.
+func (n *Node) UnblockPeer(pid peer.ID) error {
+	if n.denylist == nil {
+		return ErrDenylistDisabled
+	}
+	n.denylist.UnblockPID(pid)
+	return nil
+}
+
+// BlockIP blocks connections with the given IP address and closes existing
+// connections. It requires the Denylist option.
+func (n *Node) BlockIP(ip net.IP) error {
+	if n.denylist == nil {
+		return ErrDenylistDisabled
+	}
+	n.denylist.BlockIP(ip)
+	if n.host != nil {
+		for _, conn := range n.host.Network().Conns() {
+			if n.denylist.filters.AddrBlocked(conn.RemoteMultiaddr()) {
+				_ = conn.Close()
+			}
+		}
+	}
+	return nil
+}
+
+// UnblockIP removes the given IP address from the denylist. It requires
+// the Denylist option.
+func (n *Node) UnblockIP(ip net.IP) error {
+	if n.denylist == nil {
+		return ErrDenylistDisabled
+	}
+	n.denylist.UnblockIP(ip)
+	return nil
+}
+
+// BlockedPeers returns the list of blocked peer IDs.
+func (n *Node) BlockedPeers() []peer.ID {
+	if n.denylist == nil {
+		return nil
+	}
+	return n.denylist.PIDs()
+}
+
+// BlockedIPs returns the list of blocked IP addresses.
+func (n *Node) BlockedIPs() []net.IP {
+	if n.denylist == nil {
+		return nil
+	}
+	return n.denylist.IPs()
+}
+
 type denylistConnGater struct {
+	mu sync.RWMutex
+
 	n       *Node
-	filters multiaddr.Filters
-	pids    []peer.ID
+	filters *multiaddr.Filters
+	pids    map[peer.ID]struct{}
 }
 
 // BlockPID blocks connections from given peer ID.
 func (f *denylistConnGater) BlockPID(pid peer.ID) {
-	f.pids = append(f.pids, pid)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pids[pid] = struct{}{}
+}
+
+// UnblockPID unblocks connections from given peer ID.
+func (f *denylistConnGater) UnblockPID(pid peer.ID) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.pids, pid)
 }
 
 // BlockIP blocks connections from given IP address.
 func (f *denylistConnGater) BlockIP(ip net.IP) {
-	f.filters.AddFilter(net.IPNet{
-		IP:   ip,
-		Mask: net.CIDRMask(len(ip)*8, len(ip)*8),
-	}, multiaddr.ActionDeny)
+	f.filters.AddFilter(ipNet(ip), multiaddr.ActionDeny)
+}
+
+// UnblockIP unblocks connections from given IP address.
+func (f *denylistConnGater) UnblockIP(ip net.IP) {
+	f.filters.RemoveLiteral(ipNet(ip))
+}
+
+// PIDs returns the list of blocked peer IDs.
+func (f *denylistConnGater) PIDs() []peer.ID {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	pids := make([]peer.ID, 0, len(f.pids))
+	for pid := range f.pids {
+		pids = append(pids, pid)
+	}
+	return pids
+}
+
+// IPs returns the list of blocked IP addresses.
+func (f *denylistConnGater) IPs() []net.IP {
+	var ips []net.IP
+	for _, ipnet := range f.filters.FiltersForAction(multiaddr.ActionDeny) {
+		ips = append(ips, ipnet.IP)
+	}
+	return ips
+}
+
+func (f *denylistConnGater) pidBlocked(pid peer.ID) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	_, ok := f.pids[pid]
+	return ok
 }
 
 // InterceptAddrDial implements the connmgr.ConnectionGater interface.
 func (f *denylistConnGater) InterceptAddrDial(pid peer.ID, addr multiaddr.Multiaddr) bool {
-	if f.filters.AddrBlocked(addr) {
+	if f.filters.AddrBlocked(addr) || f.pidBlocked(pid) {
+		f.n.tsLog.get().
+			WithFields(log.Fields{
+				"peerID": pid.String(),
+				"addr":   addr.String(),
+			}).
+			Info("Blocked connection to peer by denylist")
 		return false
 	}
-	for _, p := range f.pids {
-		if p == pid {
-			return false
-		}
-	}
-	f.n.tsLog.get().
-		WithFields(log.Fields{
-			"peerID": pid.String(),
-			"addr":   addr.String(),
-		}).
-		Info("Blocked connection to peer by denylist")
 	return true
 }
 
 // InterceptPeerDial implements the connmgr.ConnectionGater interface.
-func (f *denylistConnGater) InterceptPeerDial(peer.ID) bool {
-	return true
+func (f *denylistConnGater) InterceptPeerDial(pid peer.ID) bool {
+	return !f.pidBlocked(pid)
 }
 
 // InterceptAccept implements the connmgr.ConnectionGater interface.
-func (f *denylistConnGater) InterceptAccept(network.ConnMultiaddrs) bool {
-	return true
+func (f *denylistConnGater) InterceptAccept(addrs network.ConnMultiaddrs) bool {
+	return !f.filters.AddrBlocked(addrs.RemoteMultiaddr())
 }
 
 // InterceptSecured implements the connmgr.ConnectionGater interface.
-func (f *denylistConnGater) InterceptSecured(network.Direction, peer.ID, network.ConnMultiaddrs) bool {
-	return true
+func (f *denylistConnGater) InterceptSecured(_ network.Direction, pid peer.ID, _ network.ConnMultiaddrs) bool {
+	return !f.pidBlocked(pid)
 }
 
 // InterceptUpgraded implements the connmgr.ConnectionGater interface.
 func (f *denylistConnGater) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
 	return true, 0
 }
+
+func ipNet(ip net.IP) net.IPNet {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	return net.IPNet{
+		IP:   ip,
+		Mask: net.CIDRMask(len(ip)*8, len(ip)*8),
+	}
+}
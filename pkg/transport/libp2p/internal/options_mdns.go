@@ -0,0 +1,91 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package internal
+
+import (
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/discovery/mdns"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/libp2p/internal/sets"
+)
+
+// MDNS configures node to discover peers in the local network using
+// the multicast DNS. It may be used as an alternative to the KAD-DHT
+// discovery in local and private deployments. Nodes discover only peers
+// that use the same service name.
+func MDNS(serviceName string) Options {
+	return func(n *Node) error {
+		var service mdns.Service
+		n.AddNodeEventHandler(sets.NodeEventHandlerFunc(func(event interface{}) {
+			switch event.(type) {
+			case sets.NodeHostStartedEvent:
+				n.tsLog.get().
+					WithField("serviceName", serviceName).
+					Debug("Starting mDNS discovery")
+				service = mdns.NewMdnsService(n.host, serviceName, &mdnsNotifee{n: n})
+				if err := service.Start(); err != nil {
+					n.tsLog.get().
+						WithError(err).
+						Error("Unable to start mDNS discovery")
+					service = nil
+				}
+			case sets.NodeStoppingEvent:
+				if service == nil {
+					return
+				}
+				if err := service.Close(); err != nil {
+					n.tsLog.get().
+						WithError(err).
+						Warn("Unable to close mDNS discovery")
+				}
+			}
+		}))
+		return nil
+	}
+}
+
+type mdnsNotifee struct {
+	n *Node
+}
+
+// HandlePeerFound implements the mdns.Notifee interface.
+func (m *mdnsNotifee) HandlePeerFound(addrInfo peer.AddrInfo) {
+	if addrInfo.ID == m.n.host.ID() {
+		return
+	}
+	if m.n.host.Network().Connectedness(addrInfo.ID) == network.Connected {
+		return
+	}
+	go func() {
+		m.n.tsLog.get().
+			WithFields(log.Fields{
+				"peerID": addrInfo.ID.String(),
+				"addrs":  addrInfo.Addrs,
+			}).
+			Debug("Peer found using mDNS")
+		if err := m.n.host.Connect(m.n.ctx, addrInfo); err != nil {
+			m.n.tsLog.get().
+				WithError(err).
+				WithFields(log.Fields{
+					"peerID": addrInfo.ID.String(),
+					"addrs":  addrInfo.Addrs,
+				}).
+				Debug("Unable to connect to the peer found using mDNS")
+		}
+	}()
+}
@@ -0,0 +1,130 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package internal
+
+import (
+	"sync"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// MeshTracer keeps track of peers in the gossipsub mesh of each topic.
+// The list of mesh peers is available through the MeshPeers method.
+func MeshTracer() Options {
+	return func(n *Node) error {
+		n.meshTracer = &meshTracer{mesh: make(map[string]map[peer.ID]struct{})}
+		n.pubsubOpts = append(n.pubsubOpts, pubsub.WithRawTracer(n.meshTracer))
+		return nil
+	}
+}
+
+// MeshPeers returns the list of peers in the gossipsub mesh for the given
+// topic. It requires the MeshTracer option.
+func (n *Node) MeshPeers(topic string) []peer.ID {
+	if n.meshTracer == nil {
+		return nil
+	}
+	return n.meshTracer.peers(topic)
+}
+
+type meshTracer struct {
+	mu   sync.RWMutex
+	mesh map[string]map[peer.ID]struct{}
+}
+
+func (m *meshTracer) peers(topic string) []peer.ID {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var pids []peer.ID
+	for pid := range m.mesh[topic] {
+		pids = append(pids, pid)
+	}
+	return pids
+}
+
+// AddPeer implements the pubsub.RawTracer interface.
+func (m *meshTracer) AddPeer(peer.ID, protocol.ID) {}
+
+// RemovePeer implements the pubsub.RawTracer interface.
+func (m *meshTracer) RemovePeer(p peer.ID) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, peers := range m.mesh {
+		delete(peers, p)
+	}
+}
+
+// Join implements the pubsub.RawTracer interface.
+func (m *meshTracer) Join(topic string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mesh[topic] = make(map[peer.ID]struct{})
+}
+
+// Leave implements the pubsub.RawTracer interface.
+func (m *meshTracer) Leave(topic string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.mesh, topic)
+}
+
+// Graft implements the pubsub.RawTracer interface.
+func (m *meshTracer) Graft(p peer.ID, topic string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.mesh[topic]; !ok {
+		m.mesh[topic] = make(map[peer.ID]struct{})
+	}
+	m.mesh[topic][p] = struct{}{}
+}
+
+// Prune implements the pubsub.RawTracer interface.
+func (m *meshTracer) Prune(p peer.ID, topic string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.mesh[topic], p)
+}
+
+// ValidateMessage implements the pubsub.RawTracer interface.
+func (m *meshTracer) ValidateMessage(*pubsub.Message) {}
+
+// DeliverMessage implements the pubsub.RawTracer interface.
+func (m *meshTracer) DeliverMessage(*pubsub.Message) {}
+
+// RejectMessage implements the pubsub.RawTracer interface.
+func (m *meshTracer) RejectMessage(*pubsub.Message, string) {}
+
+// DuplicateMessage implements the pubsub.RawTracer interface.
+func (m *meshTracer) DuplicateMessage(*pubsub.Message) {}
+
+// ThrottlePeer implements the pubsub.RawTracer interface.
+func (m *meshTracer) ThrottlePeer(peer.ID) {}
+
+// RecvRPC implements the pubsub.RawTracer interface.
+func (m *meshTracer) RecvRPC(*pubsub.RPC) {}
+
+// SendRPC implements the pubsub.RawTracer interface.
+func (m *meshTracer) SendRPC(*pubsub.RPC, peer.ID) {}
+
+// DropRPC implements the pubsub.RawTracer interface.
+func (m *meshTracer) DropRPC(*pubsub.RPC, peer.ID) {}
+
+// UndeliverableMessage implements the pubsub.RawTracer interface.
+func (m *meshTracer) UndeliverableMessage(*pubsub.Message) {}
+
+var _ pubsub.RawTracer = (*meshTracer)(nil)
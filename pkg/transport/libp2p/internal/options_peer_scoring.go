@@ -35,6 +35,7 @@ func PeerScoring(
 			n.pubsubOpts,
 			pubsub.WithPeerScore(params, thresholds),
 			pubsub.WithPeerScoreInspect(func(m map[peer.ID]*pubsub.PeerScoreSnapshot) {
+				n.setPeerScores(m)
 				for id, ps := range m {
 					if ps != nil {
 						n.tsLog.get().
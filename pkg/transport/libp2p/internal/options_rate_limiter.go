@@ -96,6 +96,31 @@ func (p *rateLimiter) allow(id peer.ID, msgSize int) bool {
 	return prl.limiter.AllowN(prl.lastMsg, msgSize)
 }
 
+// RateLimiterState contains the state of the rate limiter for a single peer.
+type RateLimiterState struct {
+	// Tokens is the number of bytes that can be sent by the peer without
+	// exceeding the rate limit.
+	Tokens float64
+
+	// LastMessage is the time of the last message.
+	LastMessage time.Time
+}
+
+// state returns the state of the rate limiter for all known peers.
+func (p *rateLimiter) state() map[peer.ID]RateLimiterState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	s := make(map[peer.ID]RateLimiterState, len(p.peerLimiters))
+	for id, pl := range p.peerLimiters {
+		s[id] = RateLimiterState{
+			Tokens:      pl.limiter.TokensAt(now),
+			LastMessage: pl.lastMsg,
+		}
+	}
+	return s
+}
+
 // gc removes inactive peers.
 func (p *rateLimiter) gc() {
 	p.mu.Lock()
@@ -107,6 +132,24 @@ func (p *rateLimiter) gc() {
 	}
 }
 
+// RelayRateLimiterState returns the state of the rate limiter for message
+// relays. It requires the RateLimiter option.
+func (n *Node) RelayRateLimiterState() map[peer.ID]RateLimiterState {
+	if n.relayRateLimiter == nil {
+		return nil
+	}
+	return n.relayRateLimiter.state()
+}
+
+// AuthorRateLimiterState returns the state of the rate limiter for message
+// authors. It requires the RateLimiter option.
+func (n *Node) AuthorRateLimiterState() map[peer.ID]RateLimiterState {
+	if n.authorRateLimiter == nil {
+		return nil
+	}
+	return n.authorRateLimiter.state()
+}
+
 // RateLimiter limits the number of bytes which is allowed to receive from
 // the network using the token bucket algorithm:
 // https://en.wikipedia.org/wiki/Token_bucket
@@ -122,6 +165,8 @@ func RateLimiter(cfg RateLimiterConfig) Options {
 		relayRL := newRateLimiter(cfg.RelayBytesPerSecond, cfg.RelayBurstSize)
 		// Rate limiter for message authors:
 		msgRL := newRateLimiter(cfg.BytesPerSecond, cfg.BurstSize)
+		n.relayRateLimiter = relayRL
+		n.authorRateLimiter = msgRL
 		n.AddValidator(func(ctx context.Context, topic string, id peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
 			if n.Host().ID() == id {
 				return pubsub.ValidationAccept
@@ -20,6 +20,8 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"net/http"
+	"sync"
 	"time"
 
 	cryptoETH "github.com/defiweb/go-eth/crypto"
@@ -28,9 +30,12 @@ import (
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core"
 	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 
+	"github.com/chronicleprotocol/oracle-suite/pkg/httpserver"
+	"github.com/chronicleprotocol/oracle-suite/pkg/httpserver/middleware"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
@@ -70,6 +75,9 @@ const maxInvalidMsgsPerHour float64 = 60 // per topic
 // the Ethereum wallet requires more time.
 const connectionTimeout = 120 * time.Second
 
+// mdnsServiceName is the service name used by the mDNS discovery.
+const mdnsServiceName = "_oracle-suite._udp"
+
 // defaultListenAddrs is the list of default multiaddresses on which node will
 // be listening on.
 var defaultListenAddrs = []string{"/ip4/0.0.0.0/tcp/0"}
@@ -77,12 +85,17 @@ var defaultListenAddrs = []string{"/ip4/0.0.0.0/tcp/0"}
 // P2P is the wrapper for the Node that implements the transport.Transport
 // interface.
 type P2P struct {
+	mu sync.RWMutex
+
 	id         peer.ID
 	node       *internal.Node
 	mode       Mode
 	topics     map[string]transport.Message
 	msgCh      map[string]chan transport.ReceivedMessage
 	msgFanOut  map[string]*chanutil.FanOut[transport.ReceivedMessage]
+	userAgents map[peer.ID]string
+	admin      httpserver.Service
+	waitCh     <-chan error
 	appName    string
 	appVersion string
 }
@@ -136,6 +149,28 @@ type Config struct {
 	// to connect to the network. Always enabled in bootstrap mode.
 	Discovery bool
 
+	// MDNS indicates whenever peers in the local network should be
+	// discovered using the multicast DNS. It can be used together with or
+	// instead of the KAD-DHT discovery.
+	MDNS bool
+
+	// AddressBookPath is a path to the file in which known peers are
+	// persisted. Peers from that file are redialed on startup. If empty,
+	// peers are not persisted.
+	AddressBookPath string
+
+	// AdminListenAddr is the address on which the admin API will listen.
+	// The admin API allows to inspect connected peers and topics and to
+	// block peers at runtime. It should listen only on a local interface.
+	// If empty, the admin API is disabled.
+	//
+	// Ignored if AdminServer is not nil.
+	AdminListenAddr string
+
+	// AdminServer is an optional custom HTTP server that will be used to
+	// serve the admin API.
+	AdminServer httpserver.Service
+
 	// Signer used to verify price messages. Ignored in bootstrap mode.
 	Signer wallet.Key
 
@@ -211,6 +246,12 @@ func New(cfg Config) (*P2P, error) {
 	if cfg.ExternalAddr != nil {
 		opts = append(opts, internal.ExternalAddr(cfg.ExternalAddr))
 	}
+	if cfg.MDNS {
+		opts = append(opts, internal.MDNS(mdnsServiceName))
+	}
+	if cfg.AddressBookPath != "" {
+		opts = append(opts, internal.AddressBook(internal.AddressBookConfig{Path: cfg.AddressBookPath}))
+	}
 	if cfg.PeerPrivKey != nil {
 		opts = append(opts, internal.PeerPrivKey(cfg.PeerPrivKey))
 	}
@@ -229,6 +270,7 @@ func New(cfg Config) (*P2P, error) {
 				}
 				return nil
 			}),
+			internal.MeshTracer(),
 			messageValidator(cfg.Topics, logger), // must be registered before any other validator
 			feedValidator(cfg.AuthorAllowlist, logger),
 			priceValidator(logger, cryptoETH.ECRecoverer),
@@ -258,16 +300,47 @@ func New(cfg Config) (*P2P, error) {
 		return nil, fmt.Errorf("P2P transport error, unable to get public ID from private key: %w", err)
 	}
 
-	return &P2P{
+	p := &P2P{
 		id:         id,
 		node:       n,
 		mode:       cfg.Mode,
 		topics:     cfg.Topics,
 		msgCh:      map[string]chan transport.ReceivedMessage{},
 		msgFanOut:  map[string]*chanutil.FanOut[transport.ReceivedMessage]{},
+		userAgents: map[peer.ID]string{},
+		admin:      cfg.AdminServer,
+		waitCh:     n.Wait(),
 		appName:    cfg.AppName,
 		appVersion: cfg.AppVersion,
-	}, nil
+	}
+	n.AddNotifee(&network.NotifyBundle{
+		DisconnectedF: func(nw network.Network, conn network.Conn) {
+			// There may be more than one connection to the same peer.
+			if nw.Connectedness(conn.RemotePeer()) != network.Connected {
+				p.removePeerUserAgent(conn.RemotePeer())
+			}
+		},
+	})
+	if p.admin == nil && cfg.AdminListenAddr != "" {
+		srv := httpserver.New(&http.Server{
+			Addr:              cfg.AdminListenAddr,
+			ReadTimeout:       adminTimeout,
+			ReadHeaderTimeout: adminTimeout,
+			WriteTimeout:      adminTimeout,
+			IdleTimeout:       adminTimeout,
+		})
+		srv.Use(&middleware.Recover{Recover: func(err any) {
+			logger.
+				WithField("panic", err).
+				WithAdvice("This is a bug and needs to be investigated").
+				Error("Admin API handler panicked")
+		}})
+		p.admin = srv
+	}
+	if p.admin != nil {
+		p.admin.SetHandler(p.adminHandler())
+	}
+	return p, nil
 }
 
 // Start implements the transport.Transport interface.
@@ -275,6 +348,15 @@ func (p *P2P) Start(ctx context.Context) error {
 	if err := p.node.Start(ctx); err != nil {
 		return fmt.Errorf("P2P transport error, unable to start node: %w", err)
 	}
+	p.waitCh = p.node.Wait()
+	if p.admin != nil {
+		if err := p.admin.Start(ctx); err != nil {
+			return fmt.Errorf("P2P transport error, unable to start admin API: %w", err)
+		}
+		fi := chanutil.NewFanIn(p.node.Wait(), p.admin.Wait())
+		fi.AutoClose()
+		p.waitCh = fi.Chan()
+	}
 	if p.mode == ClientMode {
 		for topic := range p.topics {
 			msgCh := make(chan transport.ReceivedMessage)
@@ -290,7 +372,7 @@ func (p *P2P) Start(ctx context.Context) error {
 
 // Wait implements the transport.Transport interface.
 func (p *P2P) Wait() <-chan error {
-	return p.node.Wait()
+	return p.waitCh
 }
 
 // Broadcast implements the transport.Transport interface.
@@ -340,6 +422,7 @@ func (p *P2P) messagesLoop(topic string, sub *internal.Subscription) {
 			userAgent := ""
 			if appInfo, ok := msg.(transport.WithAppInfo); ok {
 				userAgent = fmt.Sprintf("%s/%s", appInfo.GetAppInfo().Name, appInfo.GetAppInfo().Version)
+				p.setPeerUserAgent(id, userAgent)
 			}
 
 			p.msgCh[topic] <- transport.ReceivedMessage{
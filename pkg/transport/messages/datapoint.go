@@ -29,8 +29,13 @@ import (
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages/pb"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
 )
 
+// DataPointMessageName is the name of the data point message, without
+// the version suffix.
+const DataPointMessageName = "data_point"
+
 const DataPointV1MessageName = "data_point/v1"
 
 const maxSubPointReferenceDepth = 2
@@ -199,6 +204,52 @@ func dataPointValueToProtobuf(val value.Value) (*pb.DataPointValue, error) {
 				Volume24H: volume24H,
 			},
 		}
+	case value.Rate:
+		rate, err := nullableDecFloatPointToBytes(typ.Rate)
+		if err != nil {
+			return nil, err
+		}
+		msg.Value = &pb.DataPointValue_Rate{
+			Rate: &pb.DataPointRateValue{
+				Rate:   rate,
+				Period: int64(typ.Period / time.Second),
+			},
+		}
+	case value.Bar:
+		bar := &pb.DataPointBarValue{
+			Pair:     typ.Pair.String(),
+			Interval: int64(typ.Interval / time.Second),
+		}
+		for _, f := range []struct {
+			dst *[]byte
+			src *bn.DecFloatPointNumber
+		}{
+			{&bar.Open, typ.Open},
+			{&bar.High, typ.High},
+			{&bar.Low, typ.Low},
+			{&bar.Close, typ.Close},
+			{&bar.Volume, typ.Volume},
+		} {
+			if *f.dst, err = nullableDecFloatPointToBytes(f.src); err != nil {
+				return nil, err
+			}
+		}
+		msg.Value = &pb.DataPointValue_Bar{
+			Bar: bar,
+		}
+	case value.Vector:
+		vector := &pb.DataPointVectorValue{
+			Values: make([][]byte, len(typ.Values)),
+			Labels: typ.Labels,
+		}
+		for i, v := range typ.Values {
+			if vector.Values[i], err = nullableDecFloatPointToBytes(v); err != nil {
+				return nil, err
+			}
+		}
+		msg.Value = &pb.DataPointValue_Vector{
+			Vector: vector,
+		}
 	}
 	return msg, nil
 }
@@ -240,10 +291,73 @@ func dataPointValueFromProtobuf(msg *pb.DataPointValue) (value.Value, error) {
 			val.Volume24h = volume24H
 		}
 		return val, nil
+	case *pb.DataPointValue_Rate:
+		rate, err := nullableBytesToDecFloatPoint(typ.Rate.Rate)
+		if err != nil {
+			return nil, err
+		}
+		return value.Rate{
+			Rate:   rate,
+			Period: time.Duration(typ.Rate.Period) * time.Second,
+		}, nil
+	case *pb.DataPointValue_Bar:
+		val := value.Bar{}
+		pair, err := value.PairFromString(typ.Bar.Pair)
+		if err != nil {
+			return nil, err
+		}
+		val.Pair = pair
+		val.Interval = time.Duration(typ.Bar.Interval) * time.Second
+		for _, f := range []struct {
+			dst **bn.DecFloatPointNumber
+			src []byte
+		}{
+			{&val.Open, typ.Bar.Open},
+			{&val.High, typ.Bar.High},
+			{&val.Low, typ.Bar.Low},
+			{&val.Close, typ.Bar.Close},
+			{&val.Volume, typ.Bar.Volume},
+		} {
+			if *f.dst, err = nullableBytesToDecFloatPoint(f.src); err != nil {
+				return nil, err
+			}
+		}
+		return val, nil
+	case *pb.DataPointValue_Vector:
+		val := value.Vector{
+			Values: make([]*bn.DecFloatPointNumber, len(typ.Vector.Values)),
+			Labels: typ.Vector.Labels,
+		}
+		for i, v := range typ.Vector.Values {
+			n, err := nullableBytesToDecFloatPoint(v)
+			if err != nil {
+				return nil, err
+			}
+			val.Values[i] = n
+		}
+		return val, nil
 	}
 	return nil, nil
 }
 
+// nullableDecFloatPointToBytes works like decFloatPointToBytes, but returns
+// nil for a nil number.
+func nullableDecFloatPointToBytes(d *bn.DecFloatPointNumber) ([]byte, error) {
+	if d == nil {
+		return nil, nil
+	}
+	return decFloatPointToBytes(d)
+}
+
+// nullableBytesToDecFloatPoint works like bytesToDecFloatPoint, but returns
+// nil for empty bytes.
+func nullableBytesToDecFloatPoint(b []byte) (*bn.DecFloatPointNumber, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	return bytesToDecFloatPoint(b)
+}
+
 func (d *DataPoint) GobEncode() ([]byte, error) {
 	return d.MarshallBinary()
 }
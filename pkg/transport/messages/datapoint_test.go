@@ -16,9 +16,80 @@
 package messages
 
 import (
+	"bytes"
 	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
 )
 
+func TestDataPoint_MarshallBinary(t *testing.T) {
+	tests := []struct {
+		name  string
+		value value.Value
+	}{
+		{
+			name:  "tick",
+			value: value.NewTick(value.Pair{Base: "ETH", Quote: "USD"}, 1800.5, 100),
+		},
+		{
+			name:  "static",
+			value: value.StaticValue{Value: bn.DecFloatPoint(42)},
+		},
+		{
+			name:  "rate",
+			value: value.NewRate("1.000000001847694957", time.Second),
+		},
+		{
+			name: "bar",
+			value: value.Bar{
+				Pair:     value.Pair{Base: "ETH", Quote: "USD"},
+				Open:     bn.DecFloatPoint(1800),
+				High:     bn.DecFloatPoint(1850.5),
+				Low:      bn.DecFloatPoint(1790),
+				Close:    bn.DecFloatPoint(1820),
+				Interval: time.Hour,
+			},
+		},
+		{
+			name: "vector",
+			value: value.Vector{
+				Values: []*bn.DecFloatPointNumber{bn.DecFloatPoint(0.05), bn.DecFloatPoint(-0.01)},
+				Labels: []string{"1m", "3m"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := DataPoint{
+				Model: "ETH/USD",
+				Point: datapoint.Point{
+					Value: tt.value,
+					Time:  time.Unix(1700000000, 0),
+					Meta:  map[string]any{},
+				},
+				ECDSASignature: types.MustSignatureFromBytes(bytes.Repeat([]byte{0x01}, 65)),
+			}
+			bts, err := msg.MarshallBinary()
+			require.NoError(t, err)
+
+			var decoded DataPoint
+			require.NoError(t, decoded.UnmarshallBinary(bts))
+			require.NoError(t, decoded.Point.Validate())
+			assert.Equal(t, msg.Model, decoded.Model)
+			assert.Equal(t, tt.value.Print(), decoded.Point.Value.Print())
+			assert.IsType(t, tt.value, decoded.Point.Value)
+			assert.Equal(t, msg.ECDSASignature, decoded.ECDSASignature)
+		})
+	}
+}
+
 func FuzzDataPoint_UnmarshallBinary(f *testing.F) {
 	f.Fuzz(func(t *testing.T, data []byte) {
 		_ = (&DataPoint{}).UnmarshallBinary(data)
@@ -19,7 +19,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"time"
 
+	"github.com/defiweb/go-eth/abi"
+	"github.com/defiweb/go-eth/crypto"
 	"github.com/defiweb/go-eth/hexutil"
 	"github.com/defiweb/go-eth/types"
 
@@ -31,6 +34,12 @@ import (
 
 const GreetV1MessageName = "greet/v1"
 
+// greetMessageType is the ABI type of the signed part of the Greet message.
+// The message is a Keccak256 hash of the ABI encoded tuple.
+var greetMessageType = abi.MustParseType(
+	"(bytes32 typ,uint256 timestamp,string appName,string appVersion,string[] capabilities)",
+)
+
 type Greet struct {
 	transport.AppInfo
 
@@ -38,6 +47,14 @@ type Greet struct {
 	PublicKeyX *big.Int
 	PublicKeyY *big.Int
 	WebURL     string
+
+	// Capabilities is a list of message topics supported by the sender,
+	// e.g. "data_point/v1". It is used to negotiate message versions.
+	Capabilities []string
+
+	// Timestamp is the time at which the message was created. It is signed,
+	// so old messages cannot be replayed once they expire.
+	Timestamp time.Time
 }
 
 func (e Greet) MarshalJSON() ([]byte, error) {
@@ -46,15 +63,55 @@ func (e Greet) MarshalJSON() ([]byte, error) {
 		"public_key_x": hexutil.BigIntToHex(e.PublicKeyX),
 		"public_key_y": hexutil.BigIntToHex(e.PublicKeyY),
 		"web_url":      e.WebURL,
+		"capabilities": e.Capabilities,
+		"timestamp":    e.unixTimestamp(),
 	})
 }
 
+// SetAppInfo implements the transport.WithAppInfo interface.
+//
+// The application info is a part of the signed message, so it is set only
+// if it was not set by the sender before signing.
+func (e *Greet) SetAppInfo(info transport.AppInfo) {
+	if e.AppInfo == (transport.AppInfo{}) {
+		e.AppInfo = info
+	}
+}
+
+// Hash returns the hash of the advertised capabilities, the application
+// info and the timestamp. The hash is signed by the sender, so capabilities
+// cannot be advertised on behalf of other peers.
+func (e Greet) Hash() types.Hash {
+	var typ [32]byte
+	copy(typ[:], GreetV1MessageName)
+	return crypto.Keccak256(abi.MustEncodeValue(greetMessageType, map[string]any{
+		"typ":          typ,
+		"timestamp":    big.NewInt(e.unixTimestamp()),
+		"appName":      e.AppInfo.Name,
+		"appVersion":   e.AppInfo.Version,
+		"capabilities": e.Capabilities,
+	}))
+}
+
+// unixTimestamp returns the timestamp as a Unix time, or zero if the
+// timestamp is not set.
+func (e Greet) unixTimestamp() int64 {
+	if e.Timestamp.Unix() <= 0 {
+		return 0
+	}
+	return e.Timestamp.Unix()
+}
+
 // MarshallBinary implements the transport.Message interface.
 func (e Greet) MarshallBinary() ([]byte, error) {
 	var (
+		sig     []byte
 		pubKeyX []byte
 		pubKeyY []byte
 	)
+	if !e.Signature.IsZero() {
+		sig = e.Signature.Bytes()
+	}
 	if e.PublicKeyX != nil {
 		pubKeyX = e.PublicKeyX.Bytes()
 	}
@@ -62,11 +119,13 @@ func (e Greet) MarshallBinary() ([]byte, error) {
 		pubKeyY = e.PublicKeyY.Bytes()
 	}
 	return proto.Marshal(&pb.Greet{
-		Signature: e.Signature.Bytes(),
-		PubKeyX:   pubKeyX,
-		PubKeyY:   pubKeyY,
-		WebURL:    e.WebURL,
-		AppInfo:   appInfoToProtobuf(e.AppInfo),
+		Signature:    sig,
+		PubKeyX:      pubKeyX,
+		PubKeyY:      pubKeyY,
+		WebURL:       e.WebURL,
+		Capabilities: e.Capabilities,
+		Timestamp:    e.unixTimestamp(),
+		AppInfo:      appInfoToProtobuf(e.AppInfo),
 	})
 }
 
@@ -86,6 +145,11 @@ func (e *Greet) UnmarshallBinary(data []byte) (err error) {
 	e.PublicKeyX = new(big.Int).SetBytes(msg.PubKeyX)
 	e.PublicKeyY = new(big.Int).SetBytes(msg.PubKeyY)
 	e.WebURL = msg.WebURL
+	e.Capabilities = msg.Capabilities
+	e.Timestamp = time.Time{}
+	if msg.Timestamp > 0 {
+		e.Timestamp = time.Unix(msg.Timestamp, 0)
+	}
 	e.AppInfo = appInfoFromProtobuf(msg.AppInfo)
 	return nil
 }
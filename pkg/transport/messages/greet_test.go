@@ -18,9 +18,13 @@ package messages
 import (
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/defiweb/go-eth/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
 )
 
 func TestGreet_MarshallBinary(t *testing.T) {
@@ -100,6 +104,59 @@ func TestGreet_UnmarshallBinary(t *testing.T) {
 	}
 }
 
+func TestGreet_Capabilities(t *testing.T) {
+	greet := Greet{
+		Signature:    types.MustSignatureFromHex("0x00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff00"),
+		Capabilities: []string{"data_point/v1", "price/v1"},
+		Timestamp:    time.Unix(1700000000, 0),
+		AppInfo:      transport.AppInfo{Name: "ghost", Version: "1.0.0"},
+	}
+	bytes, err := greet.MarshallBinary()
+	require.NoError(t, err)
+
+	var got Greet
+	require.NoError(t, got.UnmarshallBinary(bytes))
+	assert.Equal(t, greet.Capabilities, got.Capabilities)
+	assert.True(t, greet.Timestamp.Equal(got.Timestamp))
+	assert.Equal(t, greet.AppInfo, got.AppInfo)
+	assert.Equal(t, greet.Hash(), got.Hash())
+}
+
+func TestGreet_Hash(t *testing.T) {
+	greet := Greet{
+		Capabilities: []string{"data_point/v1"},
+		Timestamp:    time.Unix(1700000000, 0),
+		AppInfo:      transport.AppInfo{Name: "ghost", Version: "1.0.0"},
+	}
+	hash := greet.Hash()
+
+	// All signed fields must change the hash:
+	changed := greet
+	changed.Capabilities = []string{"data_point/v2"}
+	assert.NotEqual(t, hash, changed.Hash())
+	changed = greet
+	changed.Timestamp = greet.Timestamp.Add(time.Second)
+	assert.NotEqual(t, hash, changed.Hash())
+	changed = greet
+	changed.AppInfo.Version = "1.0.1"
+	assert.NotEqual(t, hash, changed.Hash())
+
+	// The application info set by the transport must not replace the
+	// signed one:
+	changed = greet
+	changed.SetAppInfo(transport.AppInfo{Name: "spire", Version: "2.0.0"})
+	assert.Equal(t, hash, changed.Hash())
+}
+
+func TestGreet_Unsigned(t *testing.T) {
+	greet := Greet{Capabilities: []string{"data_point/v1"}}
+	bytes, err := greet.MarshallBinary()
+	require.NoError(t, err)
+
+	var got Greet
+	assert.Error(t, got.UnmarshallBinary(bytes))
+}
+
 func FuzzGreet_UnmarshallBinary(f *testing.F) {
 	f.Fuzz(func(t *testing.T, data []byte) {
 		_ = (&Greet{}).UnmarshallBinary(data)
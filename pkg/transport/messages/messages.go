@@ -17,6 +17,8 @@ package messages
 
 import (
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages/pb"
@@ -37,6 +39,53 @@ func (mm MessageMap) SelectByTopic(topics ...string) (MessageMap, error) {
 	return maputil.Select(mm, topics)
 }
 
+// Versions returns a list of topics for the given message name, sorted from
+// the newest to the oldest version, e.g. for "price" it returns
+// ["price/v1", "price/v0"].
+func (mm MessageMap) Versions(name string) []string {
+	return TopicVersions(mm.Keys(), name)
+}
+
+// ParseTopic splits a topic into a message name and a version. Topics must
+// be in the "name/vN" format, e.g. "price/v1". If the topic does not follow
+// this format, ok is false.
+func ParseTopic(topic string) (name string, version int, ok bool) {
+	idx := strings.LastIndex(topic, "/v")
+	if idx <= 0 {
+		return "", 0, false
+	}
+	version, err := strconv.Atoi(topic[idx+2:])
+	if err != nil || version < 0 {
+		return "", 0, false
+	}
+	return topic[:idx], version, true
+}
+
+// TopicVersions returns topics from the given list that belong to the given
+// message name, sorted from the newest to the oldest version.
+func TopicVersions(topics []string, name string) []string {
+	type topicVersion struct {
+		topic   string
+		version int
+	}
+	var tvs []topicVersion
+	for _, topic := range topics {
+		n, v, ok := ParseTopic(topic)
+		if !ok || n != name {
+			continue
+		}
+		tvs = append(tvs, topicVersion{topic: topic, version: v})
+	}
+	sort.Slice(tvs, func(i, j int) bool {
+		return tvs[i].version > tvs[j].version
+	})
+	versions := make([]string, len(tvs))
+	for i, tv := range tvs {
+		versions[i] = tv.topic
+	}
+	return versions
+}
+
 var AllMessagesMap = MessageMap{
 	PriceV0MessageName:                 (*Price)(nil),
 	PriceV1MessageName:                 (*Price)(nil),
@@ -135,3 +135,34 @@ func TestMessageMap_SelectByTopic(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTopic(t *testing.T) {
+	tests := []struct {
+		topic   string
+		name    string
+		version int
+		ok      bool
+	}{
+		{topic: "price/v0", name: "price", version: 0, ok: true},
+		{topic: "data_point/v1", name: "data_point", version: 1, ok: true},
+		{topic: "foo/bar/v12", name: "foo/bar", version: 12, ok: true},
+		{topic: "price", ok: false},
+		{topic: "/v1", ok: false},
+		{topic: "price/vx", ok: false},
+		{topic: "price/v-1", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.topic, func(t *testing.T) {
+			name, version, ok := ParseTopic(tt.topic)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.version, version)
+		})
+	}
+}
+
+func TestMessageMap_Versions(t *testing.T) {
+	assert.Equal(t, []string{"price/v1", "price/v0"}, AllMessagesMap.Versions("price"))
+	assert.Equal(t, []string{"data_point/v1"}, AllMessagesMap.Versions("data_point"))
+	assert.Empty(t, AllMessagesMap.Versions("foo"))
+}
@@ -189,6 +189,9 @@ type DataPointValue struct {
 	//
 	//	*DataPointValue_Static
 	//	*DataPointValue_Tick
+	//	*DataPointValue_Rate
+	//	*DataPointValue_Bar
+	//	*DataPointValue_Vector
 	Value isDataPointValue_Value `protobuf_oneof:"value"`
 }
 
@@ -245,6 +248,27 @@ func (x *DataPointValue) GetTick() *DataPointTickValue {
 	return nil
 }
 
+func (x *DataPointValue) GetRate() *DataPointRateValue {
+	if x, ok := x.GetValue().(*DataPointValue_Rate); ok {
+		return x.Rate
+	}
+	return nil
+}
+
+func (x *DataPointValue) GetBar() *DataPointBarValue {
+	if x, ok := x.GetValue().(*DataPointValue_Bar); ok {
+		return x.Bar
+	}
+	return nil
+}
+
+func (x *DataPointValue) GetVector() *DataPointVectorValue {
+	if x, ok := x.GetValue().(*DataPointValue_Vector); ok {
+		return x.Vector
+	}
+	return nil
+}
+
 type isDataPointValue_Value interface {
 	isDataPointValue_Value()
 }
@@ -257,10 +281,28 @@ type DataPointValue_Tick struct {
 	Tick *DataPointTickValue `protobuf:"bytes,2,opt,name=tick,proto3,oneof"`
 }
 
+type DataPointValue_Rate struct {
+	Rate *DataPointRateValue `protobuf:"bytes,3,opt,name=rate,proto3,oneof"`
+}
+
+type DataPointValue_Bar struct {
+	Bar *DataPointBarValue `protobuf:"bytes,4,opt,name=bar,proto3,oneof"`
+}
+
+type DataPointValue_Vector struct {
+	Vector *DataPointVectorValue `protobuf:"bytes,5,opt,name=vector,proto3,oneof"`
+}
+
 func (*DataPointValue_Static) isDataPointValue_Value() {}
 
 func (*DataPointValue_Tick) isDataPointValue_Value() {}
 
+func (*DataPointValue_Rate) isDataPointValue_Value() {}
+
+func (*DataPointValue_Bar) isDataPointValue_Value() {}
+
+func (*DataPointValue_Vector) isDataPointValue_Value() {}
+
 type DataPointTickValue struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -324,6 +366,211 @@ func (x *DataPointTickValue) GetVolume24H() []byte {
 	return nil
 }
 
+type DataPointRateValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rate   []byte `protobuf:"bytes,1,opt,name=rate,proto3" json:"rate,omitempty"`      // Rate value (bn.DecFixedPoint).
+	Period int64  `protobuf:"varint,2,opt,name=period,proto3" json:"period,omitempty"` // Period of the rate in seconds.
+}
+
+func (x *DataPointRateValue) Reset() {
+	*x = DataPointRateValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_transport_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataPointRateValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataPointRateValue) ProtoMessage() {}
+
+func (x *DataPointRateValue) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataPointRateValue.ProtoReflect.Descriptor instead.
+func (*DataPointRateValue) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DataPointRateValue) GetRate() []byte {
+	if x != nil {
+		return x.Rate
+	}
+	return nil
+}
+
+func (x *DataPointRateValue) GetPeriod() int64 {
+	if x != nil {
+		return x.Period
+	}
+	return 0
+}
+
+type DataPointBarValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair     string `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`          // Pair name, e.g. "ETH/USD".
+	Open     []byte `protobuf:"bytes,2,opt,name=open,proto3" json:"open,omitempty"`          // Open price (bn.DecFixedPoint).
+	High     []byte `protobuf:"bytes,3,opt,name=high,proto3" json:"high,omitempty"`          // High price (bn.DecFixedPoint).
+	Low      []byte `protobuf:"bytes,4,opt,name=low,proto3" json:"low,omitempty"`            // Low price (bn.DecFixedPoint).
+	Close    []byte `protobuf:"bytes,5,opt,name=close,proto3" json:"close,omitempty"`        // Close price (bn.DecFixedPoint).
+	Volume   []byte `protobuf:"bytes,6,opt,name=volume,proto3" json:"volume,omitempty"`      // Volume during the bar (bn.DecFixedPoint).
+	Interval int64  `protobuf:"varint,7,opt,name=interval,proto3" json:"interval,omitempty"` // Duration of the bar in seconds.
+}
+
+func (x *DataPointBarValue) Reset() {
+	*x = DataPointBarValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_transport_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataPointBarValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataPointBarValue) ProtoMessage() {}
+
+func (x *DataPointBarValue) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataPointBarValue.ProtoReflect.Descriptor instead.
+func (*DataPointBarValue) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DataPointBarValue) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *DataPointBarValue) GetOpen() []byte {
+	if x != nil {
+		return x.Open
+	}
+	return nil
+}
+
+func (x *DataPointBarValue) GetHigh() []byte {
+	if x != nil {
+		return x.High
+	}
+	return nil
+}
+
+func (x *DataPointBarValue) GetLow() []byte {
+	if x != nil {
+		return x.Low
+	}
+	return nil
+}
+
+func (x *DataPointBarValue) GetClose() []byte {
+	if x != nil {
+		return x.Close
+	}
+	return nil
+}
+
+func (x *DataPointBarValue) GetVolume() []byte {
+	if x != nil {
+		return x.Volume
+	}
+	return nil
+}
+
+func (x *DataPointBarValue) GetInterval() int64 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+type DataPointVectorValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Values [][]byte `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"` // Values (bn.DecFixedPoint).
+	Labels []string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty"` // Optional labels of the values.
+}
+
+func (x *DataPointVectorValue) Reset() {
+	*x = DataPointVectorValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_transport_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataPointVectorValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataPointVectorValue) ProtoMessage() {}
+
+func (x *DataPointVectorValue) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataPointVectorValue.ProtoReflect.Descriptor instead.
+func (*DataPointVectorValue) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DataPointVectorValue) GetValues() [][]byte {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *DataPointVectorValue) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 type DataPoint struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -338,7 +585,7 @@ type DataPoint struct {
 func (x *DataPoint) Reset() {
 	*x = DataPoint{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_transport_proto_msgTypes[4]
+		mi := &file_transport_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -351,7 +598,7 @@ func (x *DataPoint) String() string {
 func (*DataPoint) ProtoMessage() {}
 
 func (x *DataPoint) ProtoReflect() protoreflect.Message {
-	mi := &file_transport_proto_msgTypes[4]
+	mi := &file_transport_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -364,7 +611,7 @@ func (x *DataPoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DataPoint.ProtoReflect.Descriptor instead.
 func (*DataPoint) Descriptor() ([]byte, []int) {
-	return file_transport_proto_rawDescGZIP(), []int{4}
+	return file_transport_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DataPoint) GetValue() *DataPointValue {
@@ -409,7 +656,7 @@ type DataPointMessage struct {
 func (x *DataPointMessage) Reset() {
 	*x = DataPointMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_transport_proto_msgTypes[5]
+		mi := &file_transport_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -422,7 +669,7 @@ func (x *DataPointMessage) String() string {
 func (*DataPointMessage) ProtoMessage() {}
 
 func (x *DataPointMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_transport_proto_msgTypes[5]
+	mi := &file_transport_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -435,7 +682,7 @@ func (x *DataPointMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DataPointMessage.ProtoReflect.Descriptor instead.
 func (*DataPointMessage) Descriptor() ([]byte, []int) {
-	return file_transport_proto_rawDescGZIP(), []int{5}
+	return file_transport_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DataPointMessage) GetModel() string {
@@ -480,7 +727,7 @@ type MuSigMeta struct {
 func (x *MuSigMeta) Reset() {
 	*x = MuSigMeta{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_transport_proto_msgTypes[6]
+		mi := &file_transport_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -493,7 +740,7 @@ func (x *MuSigMeta) String() string {
 func (*MuSigMeta) ProtoMessage() {}
 
 func (x *MuSigMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_transport_proto_msgTypes[6]
+	mi := &file_transport_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -506,7 +753,7 @@ func (x *MuSigMeta) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MuSigMeta.ProtoReflect.Descriptor instead.
 func (*MuSigMeta) Descriptor() ([]byte, []int) {
-	return file_transport_proto_rawDescGZIP(), []int{6}
+	return file_transport_proto_rawDescGZIP(), []int{9}
 }
 
 func (m *MuSigMeta) GetMsgMeta() isMuSigMeta_MsgMeta {
@@ -548,7 +795,7 @@ type MuSigMetaTickV1 struct {
 func (x *MuSigMetaTickV1) Reset() {
 	*x = MuSigMetaTickV1{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_transport_proto_msgTypes[7]
+		mi := &file_transport_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -561,7 +808,7 @@ func (x *MuSigMetaTickV1) String() string {
 func (*MuSigMetaTickV1) ProtoMessage() {}
 
 func (x *MuSigMetaTickV1) ProtoReflect() protoreflect.Message {
-	mi := &file_transport_proto_msgTypes[7]
+	mi := &file_transport_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -574,7 +821,7 @@ func (x *MuSigMetaTickV1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MuSigMetaTickV1.ProtoReflect.Descriptor instead.
 func (*MuSigMetaTickV1) Descriptor() ([]byte, []int) {
-	return file_transport_proto_rawDescGZIP(), []int{7}
+	return file_transport_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *MuSigMetaTickV1) GetWat() string {
@@ -629,7 +876,7 @@ type MuSigInitializeMessage struct {
 func (x *MuSigInitializeMessage) Reset() {
 	*x = MuSigInitializeMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_transport_proto_msgTypes[8]
+		mi := &file_transport_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -642,7 +889,7 @@ func (x *MuSigInitializeMessage) String() string {
 func (*MuSigInitializeMessage) ProtoMessage() {}
 
 func (x *MuSigInitializeMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_transport_proto_msgTypes[8]
+	mi := &file_transport_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -655,7 +902,7 @@ func (x *MuSigInitializeMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MuSigInitializeMessage.ProtoReflect.Descriptor instead.
 func (*MuSigInitializeMessage) Descriptor() ([]byte, []int) {
-	return file_transport_proto_rawDescGZIP(), []int{8}
+	return file_transport_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *MuSigInitializeMessage) GetSessionID() []byte {
@@ -720,7 +967,7 @@ type MuSigTerminateMessage struct {
 func (x *MuSigTerminateMessage) Reset() {
 	*x = MuSigTerminateMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_transport_proto_msgTypes[9]
+		mi := &file_transport_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -733,7 +980,7 @@ func (x *MuSigTerminateMessage) String() string {
 func (*MuSigTerminateMessage) ProtoMessage() {}
 
 func (x *MuSigTerminateMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_transport_proto_msgTypes[9]
+	mi := &file_transport_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -746,7 +993,7 @@ func (x *MuSigTerminateMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MuSigTerminateMessage.ProtoReflect.Descriptor instead.
 func (*MuSigTerminateMessage) Descriptor() ([]byte, []int) {
-	return file_transport_proto_rawDescGZIP(), []int{9}
+	return file_transport_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *MuSigTerminateMessage) GetSessionID() []byte {
@@ -786,7 +1033,7 @@ type MuSigCommitmentMessage struct {
 func (x *MuSigCommitmentMessage) Reset() {
 	*x = MuSigCommitmentMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_transport_proto_msgTypes[10]
+		mi := &file_transport_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -799,7 +1046,7 @@ func (x *MuSigCommitmentMessage) String() string {
 func (*MuSigCommitmentMessage) ProtoMessage() {}
 
 func (x *MuSigCommitmentMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_transport_proto_msgTypes[10]
+	mi := &file_transport_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -812,7 +1059,7 @@ func (x *MuSigCommitmentMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MuSigCommitmentMessage.ProtoReflect.Descriptor instead.
 func (*MuSigCommitmentMessage) Descriptor() ([]byte, []int) {
-	return file_transport_proto_rawDescGZIP(), []int{10}
+	return file_transport_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *MuSigCommitmentMessage) GetSessionID() []byte {
@@ -870,7 +1117,7 @@ type MuSigPartialSignatureMessage struct {
 func (x *MuSigPartialSignatureMessage) Reset() {
 	*x = MuSigPartialSignatureMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_transport_proto_msgTypes[11]
+		mi := &file_transport_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -883,7 +1130,7 @@ func (x *MuSigPartialSignatureMessage) String() string {
 func (*MuSigPartialSignatureMessage) ProtoMessage() {}
 
 func (x *MuSigPartialSignatureMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_transport_proto_msgTypes[11]
+	mi := &file_transport_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -896,7 +1143,7 @@ func (x *MuSigPartialSignatureMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MuSigPartialSignatureMessage.ProtoReflect.Descriptor instead.
 func (*MuSigPartialSignatureMessage) Descriptor() ([]byte, []int) {
-	return file_transport_proto_rawDescGZIP(), []int{11}
+	return file_transport_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *MuSigPartialSignatureMessage) GetSessionID() []byte {
@@ -939,7 +1186,7 @@ type MuSigSignatureMessage struct {
 func (x *MuSigSignatureMessage) Reset() {
 	*x = MuSigSignatureMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_transport_proto_msgTypes[12]
+		mi := &file_transport_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -952,7 +1199,7 @@ func (x *MuSigSignatureMessage) String() string {
 func (*MuSigSignatureMessage) ProtoMessage() {}
 
 func (x *MuSigSignatureMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_transport_proto_msgTypes[12]
+	mi := &file_transport_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -965,7 +1212,7 @@ func (x *MuSigSignatureMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MuSigSignatureMessage.ProtoReflect.Descriptor instead.
 func (*MuSigSignatureMessage) Descriptor() ([]byte, []int) {
-	return file_transport_proto_rawDescGZIP(), []int{12}
+	return file_transport_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *MuSigSignatureMessage) GetSessionID() []byte {
@@ -1036,17 +1283,19 @@ type Greet struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Signature []byte   `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
-	PubKeyX   []byte   `protobuf:"bytes,2,opt,name=pubKeyX,proto3" json:"pubKeyX,omitempty"`
-	PubKeyY   []byte   `protobuf:"bytes,3,opt,name=pubKeyY,proto3" json:"pubKeyY,omitempty"`
-	WebURL    string   `protobuf:"bytes,4,opt,name=webURL,proto3" json:"webURL,omitempty"`
-	AppInfo   *AppInfo `protobuf:"bytes,1000,opt,name=appInfo,proto3" json:"appInfo,omitempty"` // Application info.
+	Signature    []byte   `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	PubKeyX      []byte   `protobuf:"bytes,2,opt,name=pubKeyX,proto3" json:"pubKeyX,omitempty"`
+	PubKeyY      []byte   `protobuf:"bytes,3,opt,name=pubKeyY,proto3" json:"pubKeyY,omitempty"`
+	WebURL       string   `protobuf:"bytes,4,opt,name=webURL,proto3" json:"webURL,omitempty"`
+	Capabilities []string `protobuf:"bytes,5,rep,name=capabilities,proto3" json:"capabilities,omitempty"` // Supported message topics.
+	Timestamp    int64    `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`      // Unix timestamp of the message.
+	AppInfo      *AppInfo `protobuf:"bytes,1000,opt,name=appInfo,proto3" json:"appInfo,omitempty"`        // Application info.
 }
 
 func (x *Greet) Reset() {
 	*x = Greet{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_transport_proto_msgTypes[13]
+		mi := &file_transport_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1059,7 +1308,7 @@ func (x *Greet) String() string {
 func (*Greet) ProtoMessage() {}
 
 func (x *Greet) ProtoReflect() protoreflect.Message {
-	mi := &file_transport_proto_msgTypes[13]
+	mi := &file_transport_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1072,7 +1321,7 @@ func (x *Greet) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Greet.ProtoReflect.Descriptor instead.
 func (*Greet) Descriptor() ([]byte, []int) {
-	return file_transport_proto_rawDescGZIP(), []int{13}
+	return file_transport_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *Greet) GetSignature() []byte {
@@ -1103,6 +1352,20 @@ func (x *Greet) GetWebURL() string {
 	return ""
 }
 
+func (x *Greet) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *Greet) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
 func (x *Greet) GetAppInfo() *AppInfo {
 	if x != nil {
 		return x.AppInfo
@@ -1123,7 +1386,7 @@ type MuSigMetaTickV1_FeedTick struct {
 func (x *MuSigMetaTickV1_FeedTick) Reset() {
 	*x = MuSigMetaTickV1_FeedTick{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_transport_proto_msgTypes[15]
+		mi := &file_transport_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1136,7 +1399,7 @@ func (x *MuSigMetaTickV1_FeedTick) String() string {
 func (*MuSigMetaTickV1_FeedTick) ProtoMessage() {}
 
 func (x *MuSigMetaTickV1_FeedTick) ProtoReflect() protoreflect.Message {
-	mi := &file_transport_proto_msgTypes[15]
+	mi := &file_transport_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1149,7 +1412,7 @@ func (x *MuSigMetaTickV1_FeedTick) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MuSigMetaTickV1_FeedTick.ProtoReflect.Descriptor instead.
 func (*MuSigMetaTickV1_FeedTick) Descriptor() ([]byte, []int) {
-	return file_transport_proto_rawDescGZIP(), []int{7, 0}
+	return file_transport_proto_rawDescGZIP(), []int{10, 0}
 }
 
 func (x *MuSigMetaTickV1_FeedTick) GetVal() []byte {
@@ -1185,7 +1448,7 @@ type MuSigMetaTickV1_Optimistic struct {
 func (x *MuSigMetaTickV1_Optimistic) Reset() {
 	*x = MuSigMetaTickV1_Optimistic{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_transport_proto_msgTypes[16]
+		mi := &file_transport_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1198,7 +1461,7 @@ func (x *MuSigMetaTickV1_Optimistic) String() string {
 func (*MuSigMetaTickV1_Optimistic) ProtoMessage() {}
 
 func (x *MuSigMetaTickV1_Optimistic) ProtoReflect() protoreflect.Message {
-	mi := &file_transport_proto_msgTypes[16]
+	mi := &file_transport_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1211,7 +1474,7 @@ func (x *MuSigMetaTickV1_Optimistic) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MuSigMetaTickV1_Optimistic.ProtoReflect.Descriptor instead.
 func (*MuSigMetaTickV1_Optimistic) Descriptor() ([]byte, []int) {
-	return file_transport_proto_rawDescGZIP(), []int{7, 1}
+	return file_transport_proto_rawDescGZIP(), []int{10, 1}
 }
 
 func (x *MuSigMetaTickV1_Optimistic) GetEcdsaSignature() []byte {
@@ -1243,153 +1506,185 @@ var file_transport_proto_rawDesc = []byte{
 	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x76, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74,
 	0x72, 0x61, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x72, 0x61, 0x63,
 	0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x5e, 0x0a, 0x0e, 0x44,
-	0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x18, 0x0a,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x12, 0x29, 0x0a, 0x04, 0x74, 0x69, 0x63, 0x6b, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e,
-	0x74, 0x54, 0x69, 0x63, 0x6b, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x04, 0x74, 0x69,
-	0x63, 0x6b, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x5c, 0x0a, 0x12, 0x44,
-	0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x76,
-	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x32, 0x34, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09,
-	0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x32, 0x34, 0x68, 0x22, 0xdd, 0x01, 0x0a, 0x09, 0x44, 0x61,
-	0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69,
-	0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1c,
-	0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x28, 0x0a, 0x09,
-	0x73, 0x75, 0x62, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x0a, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x09, 0x73, 0x75, 0x62,
-	0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x04,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74,
-	0x2e, 0x4d, 0x65, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61,
-	0x1a, 0x37, 0x0a, 0x09, 0x4d, 0x65, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x9f, 0x01, 0x0a, 0x10, 0x44, 0x61,
-	0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14,
-	0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d,
-	0x6f, 0x64, 0x65, 0x6c, 0x12, 0x26, 0x0a, 0x0e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x53, 0x69, 0x67,
-	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x65, 0x63,
-	0x64, 0x73, 0x61, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x28, 0x0a, 0x09,
-	0x64, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0a, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x09, 0x64, 0x61, 0x74,
-	0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x49, 0x6e, 0x66,
+	0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xe2, 0x01, 0x0a, 0x0e,
+	0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x18,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x12, 0x29, 0x0a, 0x04, 0x74, 0x69, 0x63, 0x6b,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69,
+	0x6e, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x04, 0x74,
+	0x69, 0x63, 0x6b, 0x12, 0x29, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x61, 0x74,
+	0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x26,
+	0x0a, 0x03, 0x62, 0x61, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x44, 0x61,
+	0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x42, 0x61, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48,
+	0x00, 0x52, 0x03, 0x62, 0x61, 0x72, 0x12, 0x2f, 0x0a, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69,
+	0x6e, 0x74, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52,
+	0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x22, 0x5c, 0x0a, 0x12, 0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x54, 0x69, 0x63,
+	0x6b, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x32, 0x34, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x09, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x32, 0x34, 0x68, 0x22, 0x40,
+	0x0a, 0x12, 0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x61, 0x74, 0x65, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69,
+	0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x22, 0xab, 0x01, 0x0a, 0x11, 0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x42, 0x61,
+	0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6f, 0x70,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x69, 0x67, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x69,
+	0x67, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f, 0x77, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x03, 0x6c, 0x6f, 0x77, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x05, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x46,
+	0x0a, 0x14, 0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x56, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x22, 0xdd, 0x01, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x28, 0x0a, 0x09, 0x73, 0x75, 0x62,
+	0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x44,
+	0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x09, 0x73, 0x75, 0x62, 0x50, 0x6f, 0x69,
+	0x6e, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x4d, 0x65,
+	0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x1a, 0x37, 0x0a,
+	0x09, 0x4d, 0x65, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x9f, 0x01, 0x0a, 0x10, 0x44, 0x61, 0x74, 0x61, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x12, 0x26, 0x0a, 0x0e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x65, 0x63, 0x64, 0x73, 0x61,
+	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x28, 0x0a, 0x09, 0x64, 0x61, 0x74,
+	0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x44,
+	0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x09, 0x64, 0x61, 0x74, 0x61, 0x50, 0x6f,
+	0x69, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0xe8,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x41, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x07, 0x61, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x40, 0x0a, 0x09, 0x4d, 0x75, 0x53, 0x69,
+	0x67, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x28, 0x0a, 0x05, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x4d, 0x75, 0x53, 0x69, 0x67, 0x4d, 0x65, 0x74, 0x61,
+	0x54, 0x69, 0x63, 0x6b, 0x56, 0x31, 0x48, 0x00, 0x52, 0x05, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x42,
+	0x09, 0x0a, 0x07, 0x6d, 0x73, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x22, 0xd5, 0x02, 0x0a, 0x0f, 0x4d,
+	0x75, 0x53, 0x69, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x54, 0x69, 0x63, 0x6b, 0x56, 0x31, 0x12, 0x10,
+	0x0a, 0x03, 0x77, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x77, 0x61, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x76,
+	0x61, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x03, 0x61, 0x67, 0x65, 0x12, 0x3b, 0x0a, 0x0a, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x74,
+	0x69, 0x63, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x4d, 0x75, 0x53, 0x69, 0x67,
+	0x4d, 0x65, 0x74, 0x61, 0x54, 0x69, 0x63, 0x6b, 0x56, 0x31, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6d,
+	0x69, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0a, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x74, 0x69,
+	0x63, 0x12, 0x2f, 0x0a, 0x05, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x4d, 0x75, 0x53, 0x69, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x54, 0x69, 0x63, 0x6b,
+	0x56, 0x31, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x54, 0x69, 0x63, 0x6b, 0x52, 0x05, 0x74, 0x69, 0x63,
+	0x6b, 0x73, 0x1a, 0x40, 0x0a, 0x08, 0x46, 0x65, 0x65, 0x64, 0x54, 0x69, 0x63, 0x6b, 0x12, 0x10,
+	0x0a, 0x03, 0x76, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x76, 0x61, 0x6c,
+	0x12, 0x10, 0x0a, 0x03, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x61,
+	0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x03, 0x76, 0x72, 0x73, 0x1a, 0x5c, 0x0a, 0x0a, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x74,
+	0x69, 0x63, 0x12, 0x26, 0x0a, 0x0e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x53, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x65, 0x63, 0x64, 0x73,
+	0x61, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x73, 0x69,
+	0x67, 0x6e, 0x65, 0x72, 0x73, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x65, 0x73, 0x22, 0x90, 0x02, 0x0a, 0x16, 0x4d, 0x75, 0x53, 0x69, 0x67, 0x49, 0x6e, 0x69, 0x74,
+	0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x2e, 0x0a, 0x12, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x73,
+	0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x73, 0x67, 0x42, 0x6f, 0x64, 0x79,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6d, 0x73, 0x67, 0x42, 0x6f, 0x64, 0x79, 0x12,
+	0x29, 0x0a, 0x07, 0x6d, 0x73, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0a, 0x2e, 0x4d, 0x75, 0x53, 0x69, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x48, 0x00, 0x52, 0x07,
+	0x6d, 0x73, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x88, 0x01, 0x01, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x69,
+	0x67, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x73, 0x69, 0x67,
+	0x6e, 0x65, 0x72, 0x73, 0x12, 0x23, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x18,
+	0xe8, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x41, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x07, 0x61, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6d, 0x73,
+	0x67, 0x4d, 0x65, 0x74, 0x61, 0x22, 0x72, 0x0a, 0x15, 0x4d, 0x75, 0x53, 0x69, 0x67, 0x54, 0x65,
+	0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x18,
+	0xe8, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x41, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x07, 0x61, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0xdf, 0x01, 0x0a, 0x16, 0x4d, 0x75,
+	0x53, 0x69, 0x67, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x58, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x58, 0x12, 0x18, 0x0a, 0x07,
+	0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x59, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70,
+	0x75, 0x62, 0x4b, 0x65, 0x79, 0x59, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x6d, 0x65, 0x6e, 0x74, 0x4b, 0x65, 0x79, 0x58, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x4b, 0x65, 0x79, 0x58, 0x12, 0x26,
+	0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x4b, 0x65, 0x79, 0x59,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65,
+	0x6e, 0x74, 0x4b, 0x65, 0x79, 0x59, 0x12, 0x23, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x49, 0x6e, 0x66,
+	0x6f, 0x18, 0xe8, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x41, 0x70, 0x70, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x07, 0x61, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x8d, 0x01, 0x0a, 0x1c,
+	0x4d, 0x75, 0x53, 0x69, 0x67, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x2a, 0x0a, 0x10, 0x70, 0x61,
+	0x72, 0x74, 0x69, 0x61, 0x6c, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x53, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x23, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x49, 0x6e, 0x66,
 	0x6f, 0x18, 0xe8, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x41, 0x70, 0x70, 0x49, 0x6e,
-	0x66, 0x6f, 0x52, 0x07, 0x61, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x40, 0x0a, 0x09, 0x4d,
-	0x75, 0x53, 0x69, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x28, 0x0a, 0x05, 0x74, 0x69, 0x63, 0x6b,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x4d, 0x75, 0x53, 0x69, 0x67, 0x4d,
-	0x65, 0x74, 0x61, 0x54, 0x69, 0x63, 0x6b, 0x56, 0x31, 0x48, 0x00, 0x52, 0x05, 0x74, 0x69, 0x63,
-	0x6b, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x6d, 0x73, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x22, 0xd5, 0x02,
-	0x0a, 0x0f, 0x4d, 0x75, 0x53, 0x69, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x54, 0x69, 0x63, 0x6b, 0x56,
-	0x31, 0x12, 0x10, 0x0a, 0x03, 0x77, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x77, 0x61, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x03, 0x76, 0x61, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x03, 0x61, 0x67, 0x65, 0x12, 0x3b, 0x0a, 0x0a, 0x6f, 0x70, 0x74, 0x69, 0x6d,
-	0x69, 0x73, 0x74, 0x69, 0x63, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x4d, 0x75,
-	0x53, 0x69, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x54, 0x69, 0x63, 0x6b, 0x56, 0x31, 0x2e, 0x4f, 0x70,
-	0x74, 0x69, 0x6d, 0x69, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0a, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69,
-	0x73, 0x74, 0x69, 0x63, 0x12, 0x2f, 0x0a, 0x05, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18, 0x05, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x4d, 0x75, 0x53, 0x69, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x54,
-	0x69, 0x63, 0x6b, 0x56, 0x31, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x54, 0x69, 0x63, 0x6b, 0x52, 0x05,
-	0x74, 0x69, 0x63, 0x6b, 0x73, 0x1a, 0x40, 0x0a, 0x08, 0x46, 0x65, 0x65, 0x64, 0x54, 0x69, 0x63,
-	0x6b, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03,
-	0x76, 0x61, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x03, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x03, 0x76, 0x72, 0x73, 0x1a, 0x5c, 0x0a, 0x0a, 0x4f, 0x70, 0x74, 0x69, 0x6d,
-	0x69, 0x73, 0x74, 0x69, 0x63, 0x12, 0x26, 0x0a, 0x0e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x53, 0x69,
-	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x65,
-	0x63, 0x64, 0x73, 0x61, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x26, 0x0a,
-	0x0e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x49, 0x6e,
-	0x64, 0x65, 0x78, 0x65, 0x73, 0x22, 0x90, 0x02, 0x0a, 0x16, 0x4d, 0x75, 0x53, 0x69, 0x67, 0x49,
-	0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x2e,
-	0x0a, 0x12, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x73, 0x74, 0x61, 0x72,
-	0x74, 0x65, 0x64, 0x41, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x18,
-	0x0a, 0x07, 0x6d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x6d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x73, 0x67, 0x42,
-	0x6f, 0x64, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6d, 0x73, 0x67, 0x42, 0x6f,
-	0x64, 0x79, 0x12, 0x29, 0x0a, 0x07, 0x6d, 0x73, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x4d, 0x75, 0x53, 0x69, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x48,
-	0x00, 0x52, 0x07, 0x6d, 0x73, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x88, 0x01, 0x01, 0x12, 0x18, 0x0a,
-	0x07, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07,
-	0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x23, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x49, 0x6e,
-	0x66, 0x6f, 0x18, 0xe8, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x41, 0x70, 0x70, 0x49,
-	0x6e, 0x66, 0x6f, 0x52, 0x07, 0x61, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x42, 0x0a, 0x0a, 0x08,
-	0x5f, 0x6d, 0x73, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x22, 0x72, 0x0a, 0x15, 0x4d, 0x75, 0x53, 0x69,
-	0x67, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12,
-	0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x49, 0x6e,
-	0x66, 0x6f, 0x18, 0xe8, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x41, 0x70, 0x70, 0x49,
-	0x6e, 0x66, 0x6f, 0x52, 0x07, 0x61, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0xdf, 0x01, 0x0a,
-	0x16, 0x4d, 0x75, 0x53, 0x69, 0x67, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x58,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x58, 0x12,
-	0x18, 0x0a, 0x07, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x59, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x07, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x59, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6d,
-	0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x4b, 0x65, 0x79, 0x58, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x4b, 0x65, 0x79,
-	0x58, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x4b,
-	0x65, 0x79, 0x59, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
-	0x74, 0x6d, 0x65, 0x6e, 0x74, 0x4b, 0x65, 0x79, 0x59, 0x12, 0x23, 0x0a, 0x07, 0x61, 0x70, 0x70,
-	0x49, 0x6e, 0x66, 0x6f, 0x18, 0xe8, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x41, 0x70,
-	0x70, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x61, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x8d,
-	0x01, 0x0a, 0x1c, 0x4d, 0x75, 0x53, 0x69, 0x67, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x53,
-	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
-	0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x2a, 0x0a,
-	0x10, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c,
-	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x23, 0x0a, 0x07, 0x61, 0x70, 0x70,
-	0x49, 0x6e, 0x66, 0x6f, 0x18, 0xe8, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x41, 0x70,
-	0x70, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x61, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0xdd,
-	0x02, 0x0a, 0x15, 0x4d, 0x75, 0x53, 0x69, 0x67, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
-	0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x30, 0x0a, 0x13, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74,
-	0x65, 0x64, 0x41, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x13, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x41, 0x74, 0x54,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x73, 0x67, 0x54,
-	0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x73, 0x67, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x73, 0x67, 0x42, 0x6f, 0x64, 0x79, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x07, 0x6d, 0x73, 0x67, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x29, 0x0a, 0x07,
-	0x6d, 0x73, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e,
-	0x4d, 0x75, 0x53, 0x69, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x73, 0x67,
-	0x4d, 0x65, 0x74, 0x61, 0x88, 0x01, 0x01, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x69, 0x67, 0x6e, 0x65,
-	0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72,
-	0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e,
-	0x74, 0x12, 0x2a, 0x0a, 0x10, 0x73, 0x63, 0x68, 0x6e, 0x6f, 0x72, 0x72, 0x53, 0x69, 0x67, 0x6e,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x73, 0x63, 0x68,
-	0x6e, 0x6f, 0x72, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x23, 0x0a,
-	0x07, 0x61, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0xe8, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x08, 0x2e, 0x41, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x61, 0x70, 0x70, 0x49, 0x6e,
-	0x66, 0x6f, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6d, 0x73, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x22, 0x96,
-	0x01, 0x0a, 0x05, 0x47, 0x72, 0x65, 0x65, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67,
-	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79,
-	0x58, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x58,
-	0x12, 0x18, 0x0a, 0x07, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x59, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x07, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x59, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65,
-	0x62, 0x55, 0x52, 0x4c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x65, 0x62, 0x55,
-	0x52, 0x4c, 0x12, 0x23, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0xe8, 0x07,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x41, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07,
-	0x61, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x42, 0x45, 0x5a, 0x43, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x63, 0x6c, 0x65, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x2d, 0x73,
-	0x75, 0x69, 0x74, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f,
-	0x72, 0x74, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2f, 0x70, 0x62, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x66, 0x6f, 0x52, 0x07, 0x61, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0xdd, 0x02, 0x0a, 0x15,
+	0x4d, 0x75, 0x53, 0x69, 0x67, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x44, 0x12, 0x30, 0x0a, 0x13, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x13, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x41, 0x74, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x73, 0x67, 0x42, 0x6f, 0x64, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x07, 0x6d, 0x73, 0x67, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x29, 0x0a, 0x07, 0x6d, 0x73, 0x67,
+	0x4d, 0x65, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x4d, 0x75, 0x53,
+	0x69, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x73, 0x67, 0x4d, 0x65, 0x74,
+	0x61, 0x88, 0x01, 0x01, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x1e,
+	0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2a,
+	0x0a, 0x10, 0x73, 0x63, 0x68, 0x6e, 0x6f, 0x72, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x73, 0x63, 0x68, 0x6e, 0x6f, 0x72,
+	0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x23, 0x0a, 0x07, 0x61, 0x70,
+	0x70, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0xe8, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x41,
+	0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x61, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x42,
+	0x0a, 0x0a, 0x08, 0x5f, 0x6d, 0x73, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x22, 0xd8, 0x01, 0x0a, 0x05,
+	0x47, 0x72, 0x65, 0x65, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x58, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x58, 0x12, 0x18, 0x0a,
+	0x07, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x59, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
+	0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x59, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x62, 0x55, 0x52,
+	0x4c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x65, 0x62, 0x55, 0x52, 0x4c, 0x12,
+	0x22, 0x0a, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x12, 0x23, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0xe8, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x41, 0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x61,
+	0x70, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x42, 0x45, 0x5a, 0x43, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x63, 0x6c, 0x65, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x2d, 0x73, 0x75,
+	0x69, 0x74, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72,
+	0x74, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1404,49 +1699,55 @@ func file_transport_proto_rawDescGZIP() []byte {
 	return file_transport_proto_rawDescData
 }
 
-var file_transport_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_transport_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_transport_proto_goTypes = []interface{}{
 	(*AppInfo)(nil),                      // 0: AppInfo
 	(*Price)(nil),                        // 1: Price
 	(*DataPointValue)(nil),               // 2: DataPointValue
 	(*DataPointTickValue)(nil),           // 3: DataPointTickValue
-	(*DataPoint)(nil),                    // 4: DataPoint
-	(*DataPointMessage)(nil),             // 5: DataPointMessage
-	(*MuSigMeta)(nil),                    // 6: MuSigMeta
-	(*MuSigMetaTickV1)(nil),              // 7: MuSigMetaTickV1
-	(*MuSigInitializeMessage)(nil),       // 8: MuSigInitializeMessage
-	(*MuSigTerminateMessage)(nil),        // 9: MuSigTerminateMessage
-	(*MuSigCommitmentMessage)(nil),       // 10: MuSigCommitmentMessage
-	(*MuSigPartialSignatureMessage)(nil), // 11: MuSigPartialSignatureMessage
-	(*MuSigSignatureMessage)(nil),        // 12: MuSigSignatureMessage
-	(*Greet)(nil),                        // 13: Greet
-	nil,                                  // 14: DataPoint.MetaEntry
-	(*MuSigMetaTickV1_FeedTick)(nil),     // 15: MuSigMetaTickV1.FeedTick
-	(*MuSigMetaTickV1_Optimistic)(nil),   // 16: MuSigMetaTickV1.Optimistic
+	(*DataPointRateValue)(nil),           // 4: DataPointRateValue
+	(*DataPointBarValue)(nil),            // 5: DataPointBarValue
+	(*DataPointVectorValue)(nil),         // 6: DataPointVectorValue
+	(*DataPoint)(nil),                    // 7: DataPoint
+	(*DataPointMessage)(nil),             // 8: DataPointMessage
+	(*MuSigMeta)(nil),                    // 9: MuSigMeta
+	(*MuSigMetaTickV1)(nil),              // 10: MuSigMetaTickV1
+	(*MuSigInitializeMessage)(nil),       // 11: MuSigInitializeMessage
+	(*MuSigTerminateMessage)(nil),        // 12: MuSigTerminateMessage
+	(*MuSigCommitmentMessage)(nil),       // 13: MuSigCommitmentMessage
+	(*MuSigPartialSignatureMessage)(nil), // 14: MuSigPartialSignatureMessage
+	(*MuSigSignatureMessage)(nil),        // 15: MuSigSignatureMessage
+	(*Greet)(nil),                        // 16: Greet
+	nil,                                  // 17: DataPoint.MetaEntry
+	(*MuSigMetaTickV1_FeedTick)(nil),     // 18: MuSigMetaTickV1.FeedTick
+	(*MuSigMetaTickV1_Optimistic)(nil),   // 19: MuSigMetaTickV1.Optimistic
 }
 var file_transport_proto_depIdxs = []int32{
 	3,  // 0: DataPointValue.tick:type_name -> DataPointTickValue
-	2,  // 1: DataPoint.value:type_name -> DataPointValue
-	4,  // 2: DataPoint.subPoints:type_name -> DataPoint
-	14, // 3: DataPoint.meta:type_name -> DataPoint.MetaEntry
-	4,  // 4: DataPointMessage.dataPoint:type_name -> DataPoint
-	0,  // 5: DataPointMessage.appInfo:type_name -> AppInfo
-	7,  // 6: MuSigMeta.ticks:type_name -> MuSigMetaTickV1
-	16, // 7: MuSigMetaTickV1.optimistic:type_name -> MuSigMetaTickV1.Optimistic
-	15, // 8: MuSigMetaTickV1.ticks:type_name -> MuSigMetaTickV1.FeedTick
-	6,  // 9: MuSigInitializeMessage.msgMeta:type_name -> MuSigMeta
-	0,  // 10: MuSigInitializeMessage.appInfo:type_name -> AppInfo
-	0,  // 11: MuSigTerminateMessage.appInfo:type_name -> AppInfo
-	0,  // 12: MuSigCommitmentMessage.appInfo:type_name -> AppInfo
-	0,  // 13: MuSigPartialSignatureMessage.appInfo:type_name -> AppInfo
-	6,  // 14: MuSigSignatureMessage.msgMeta:type_name -> MuSigMeta
-	0,  // 15: MuSigSignatureMessage.appInfo:type_name -> AppInfo
-	0,  // 16: Greet.appInfo:type_name -> AppInfo
-	17, // [17:17] is the sub-list for method output_type
-	17, // [17:17] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	4,  // 1: DataPointValue.rate:type_name -> DataPointRateValue
+	5,  // 2: DataPointValue.bar:type_name -> DataPointBarValue
+	6,  // 3: DataPointValue.vector:type_name -> DataPointVectorValue
+	2,  // 4: DataPoint.value:type_name -> DataPointValue
+	7,  // 5: DataPoint.subPoints:type_name -> DataPoint
+	17, // 6: DataPoint.meta:type_name -> DataPoint.MetaEntry
+	7,  // 7: DataPointMessage.dataPoint:type_name -> DataPoint
+	0,  // 8: DataPointMessage.appInfo:type_name -> AppInfo
+	10, // 9: MuSigMeta.ticks:type_name -> MuSigMetaTickV1
+	19, // 10: MuSigMetaTickV1.optimistic:type_name -> MuSigMetaTickV1.Optimistic
+	18, // 11: MuSigMetaTickV1.ticks:type_name -> MuSigMetaTickV1.FeedTick
+	9,  // 12: MuSigInitializeMessage.msgMeta:type_name -> MuSigMeta
+	0,  // 13: MuSigInitializeMessage.appInfo:type_name -> AppInfo
+	0,  // 14: MuSigTerminateMessage.appInfo:type_name -> AppInfo
+	0,  // 15: MuSigCommitmentMessage.appInfo:type_name -> AppInfo
+	0,  // 16: MuSigPartialSignatureMessage.appInfo:type_name -> AppInfo
+	9,  // 17: MuSigSignatureMessage.msgMeta:type_name -> MuSigMeta
+	0,  // 18: MuSigSignatureMessage.appInfo:type_name -> AppInfo
+	0,  // 19: Greet.appInfo:type_name -> AppInfo
+	20, // [20:20] is the sub-list for method output_type
+	20, // [20:20] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_transport_proto_init() }
@@ -1504,7 +1805,7 @@ func file_transport_proto_init() {
 			}
 		}
 		file_transport_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataPoint); i {
+			switch v := v.(*DataPointRateValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1516,7 +1817,7 @@ func file_transport_proto_init() {
 			}
 		}
 		file_transport_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataPointMessage); i {
+			switch v := v.(*DataPointBarValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1528,7 +1829,7 @@ func file_transport_proto_init() {
 			}
 		}
 		file_transport_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MuSigMeta); i {
+			switch v := v.(*DataPointVectorValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1540,7 +1841,7 @@ func file_transport_proto_init() {
 			}
 		}
 		file_transport_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MuSigMetaTickV1); i {
+			switch v := v.(*DataPoint); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1552,7 +1853,7 @@ func file_transport_proto_init() {
 			}
 		}
 		file_transport_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MuSigInitializeMessage); i {
+			switch v := v.(*DataPointMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1564,7 +1865,7 @@ func file_transport_proto_init() {
 			}
 		}
 		file_transport_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MuSigTerminateMessage); i {
+			switch v := v.(*MuSigMeta); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1576,7 +1877,7 @@ func file_transport_proto_init() {
 			}
 		}
 		file_transport_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MuSigCommitmentMessage); i {
+			switch v := v.(*MuSigMetaTickV1); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1588,7 +1889,7 @@ func file_transport_proto_init() {
 			}
 		}
 		file_transport_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MuSigPartialSignatureMessage); i {
+			switch v := v.(*MuSigInitializeMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1600,7 +1901,7 @@ func file_transport_proto_init() {
 			}
 		}
 		file_transport_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MuSigSignatureMessage); i {
+			switch v := v.(*MuSigTerminateMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1612,7 +1913,19 @@ func file_transport_proto_init() {
 			}
 		}
 		file_transport_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Greet); i {
+			switch v := v.(*MuSigCommitmentMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_transport_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MuSigPartialSignatureMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1624,7 +1937,7 @@ func file_transport_proto_init() {
 			}
 		}
 		file_transport_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MuSigMetaTickV1_FeedTick); i {
+			switch v := v.(*MuSigSignatureMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1636,6 +1949,30 @@ func file_transport_proto_init() {
 			}
 		}
 		file_transport_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Greet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_transport_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MuSigMetaTickV1_FeedTick); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_transport_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MuSigMetaTickV1_Optimistic); i {
 			case 0:
 				return &v.state
@@ -1651,19 +1988,22 @@ func file_transport_proto_init() {
 	file_transport_proto_msgTypes[2].OneofWrappers = []interface{}{
 		(*DataPointValue_Static)(nil),
 		(*DataPointValue_Tick)(nil),
+		(*DataPointValue_Rate)(nil),
+		(*DataPointValue_Bar)(nil),
+		(*DataPointValue_Vector)(nil),
 	}
-	file_transport_proto_msgTypes[6].OneofWrappers = []interface{}{
+	file_transport_proto_msgTypes[9].OneofWrappers = []interface{}{
 		(*MuSigMeta_Ticks)(nil),
 	}
-	file_transport_proto_msgTypes[8].OneofWrappers = []interface{}{}
-	file_transport_proto_msgTypes[12].OneofWrappers = []interface{}{}
+	file_transport_proto_msgTypes[11].OneofWrappers = []interface{}{}
+	file_transport_proto_msgTypes[15].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_transport_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,197 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package nats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+	"github.com/defiweb/go-eth/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+)
+
+type message struct {
+	data []byte
+}
+
+func (m *message) MarshallBinary() ([]byte, error) {
+	return m.data, nil
+}
+
+func (m *message) UnmarshallBinary(i []byte) error {
+	m.data = i
+	return nil
+}
+
+var testTopics = map[string]transport.Message{"foo": (*message)(nil)}
+
+// broadcastUntilReceived broadcasts the message until it is received on
+// the given channel. It is necessary because subscriptions are processed
+// asynchronously by the server. Broadcast errors are ignored because they
+// are expected while the transport is reconnecting.
+func broadcastUntilReceived(t *testing.T, n *NATS, ch <-chan transport.ReceivedMessage) transport.ReceivedMessage {
+	for i := 0; i < 50; i++ {
+		_ = n.Broadcast("foo", &message{data: []byte("bar")})
+		select {
+		case msg := <-ch:
+			return msg
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	t.Fatal("message not received")
+	return transport.ReceivedMessage{}
+}
+
+func TestNATS(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	srv := newTestServer(t)
+	key := wallet.NewRandomKey()
+	producer, err := New(Config{URL: srv.url(), Topics: testTopics, Signer: key})
+	require.NoError(t, err)
+	consumer, err := New(Config{URL: srv.url(), Topics: testTopics, AuthorAllowlist: []types.Address{key.Address()}})
+	require.NoError(t, err)
+	require.NoError(t, producer.Start(ctx))
+	require.NoError(t, consumer.Start(ctx))
+
+	msg := broadcastUntilReceived(t, producer, consumer.Messages("foo"))
+	require.NoError(t, msg.Error)
+	assert.Equal(t, []byte("bar"), msg.Message.(*message).data)
+	assert.Equal(t, key.Address().Bytes(), msg.Author)
+	assert.Equal(t, TransportName, msg.Meta.Transport)
+	assert.Equal(t, "foo", msg.Meta.Topic)
+
+	// Unknown topic:
+	assert.Nil(t, consumer.Messages("bar"))
+
+	// Broadcast without a signer:
+	assert.Error(t, consumer.Broadcast("foo", &message{}))
+}
+
+func TestNATS_Allowlist(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	srv := newTestServer(t)
+	allowed := wallet.NewRandomKey()
+	disallowed := wallet.NewRandomKey()
+	producer1, err := New(Config{URL: srv.url(), Topics: testTopics, Signer: disallowed})
+	require.NoError(t, err)
+	producer2, err := New(Config{URL: srv.url(), Topics: testTopics, Signer: allowed})
+	require.NoError(t, err)
+	consumer, err := New(Config{URL: srv.url(), Topics: testTopics, AuthorAllowlist: []types.Address{allowed.Address()}})
+	require.NoError(t, err)
+	require.NoError(t, producer1.Start(ctx))
+	require.NoError(t, producer2.Start(ctx))
+	require.NoError(t, consumer.Start(ctx))
+
+	ch := consumer.Messages("foo")
+	msg := broadcastUntilReceived(t, producer2, ch)
+	assert.Equal(t, allowed.Address().Bytes(), msg.Author)
+
+	// Messages from authors outside the allowlist must be ignored:
+	require.NoError(t, producer1.Broadcast("foo", &message{data: []byte("disallowed")}))
+	timeout := time.After(200 * time.Millisecond)
+	for {
+		select {
+		case msg := <-ch:
+			assert.Equal(t, allowed.Address().Bytes(), msg.Author)
+		case <-timeout:
+			return
+		}
+	}
+}
+
+func TestNATS_EmptyAllowlist(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	srv := newTestServer(t)
+	key := wallet.NewRandomKey()
+	producer, err := New(Config{URL: srv.url(), Topics: testTopics, Signer: key})
+	require.NoError(t, err)
+	consumer, err := New(Config{URL: srv.url(), Topics: testTopics})
+	require.NoError(t, err)
+	require.NoError(t, producer.Start(ctx))
+	require.NoError(t, consumer.Start(ctx))
+
+	// Without an allowlist, all messages must be rejected:
+	ch := consumer.Messages("foo")
+	for i := 0; i < 5; i++ {
+		require.NoError(t, producer.Broadcast("foo", &message{data: []byte("bar")}))
+		select {
+		case <-ch:
+			t.Fatal("message must be rejected")
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+func TestNATS_Reconnect(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	srv := newTestServer(t)
+	key := wallet.NewRandomKey()
+	producer, err := New(Config{URL: srv.url(), Topics: testTopics, Signer: key, ReconnectDelay: 10 * time.Millisecond})
+	require.NoError(t, err)
+	consumer, err := New(Config{
+		URL:             srv.url(),
+		Topics:          testTopics,
+		AuthorAllowlist: []types.Address{key.Address()},
+		ReconnectDelay:  10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.NoError(t, producer.Start(ctx))
+	require.NoError(t, consumer.Start(ctx))
+
+	ch := consumer.Messages("foo")
+	broadcastUntilReceived(t, producer, ch)
+	srv.disconnectAll()
+
+	// Subscriptions of the consumer must be restored after reconnecting:
+	broadcastUntilReceived(t, producer, ch)
+}
+
+func TestNATS_Credentials(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	srv := newTestServer(t)
+	n, err := New(Config{URL: "nats://user:pass@" + srv.ln.Addr().String(), Topics: testTopics})
+	require.NoError(t, err)
+	require.NoError(t, n.Start(ctx))
+	assert.Contains(t, srv.lastConnect(), `"user":"user","pass":"pass"`)
+}
+
+func TestNew_InvalidURL(t *testing.T) {
+	for _, u := range []string{"", "localhost:4222", "http://localhost:4222", "nats://", "tls://"} {
+		_, err := New(Config{URL: u})
+		assert.Error(t, err, u)
+	}
+}
+
+func TestStart_Unreachable(t *testing.T) {
+	n, err := New(Config{URL: "nats://127.0.0.1:1", DialTimeout: time.Second})
+	require.NoError(t, err)
+	assert.Error(t, n.Start(context.Background()))
+}
@@ -0,0 +1,163 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package nats
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testServer is a minimal in-process NATS server used in tests. It supports
+// only exact subject matching and ignores authentication.
+type testServer struct {
+	mu       sync.Mutex
+	ln       net.Listener
+	clients  map[*testClient]struct{}
+	connects []string
+}
+
+type testClient struct {
+	mu   sync.Mutex
+	conn net.Conn
+	w    *bufio.Writer
+	subs map[string]string // subject -> sid
+}
+
+func newTestServer(t *testing.T) *testServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &testServer{ln: ln, clients: make(map[*testClient]struct{})}
+	go s.acceptRoutine()
+	t.Cleanup(s.close)
+	return s
+}
+
+func (s *testServer) url() string {
+	return "nats://" + s.ln.Addr().String()
+}
+
+// disconnectAll closes all client connections.
+func (s *testServer) disconnectAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.clients {
+		_ = c.conn.Close()
+	}
+}
+
+// lastConnect returns the last CONNECT message payload.
+func (s *testServer) lastConnect() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.connects) == 0 {
+		return ""
+	}
+	return s.connects[len(s.connects)-1]
+}
+
+func (s *testServer) close() {
+	_ = s.ln.Close()
+	s.disconnectAll()
+}
+
+func (s *testServer) acceptRoutine() {
+	for {
+		nc, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		c := &testClient{conn: nc, w: bufio.NewWriter(nc), subs: make(map[string]string)}
+		s.mu.Lock()
+		s.clients[c] = struct{}{}
+		s.mu.Unlock()
+		go s.clientRoutine(c)
+	}
+}
+
+func (s *testServer) clientRoutine(c *testClient) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, c)
+		s.mu.Unlock()
+		_ = c.conn.Close()
+	}()
+	c.write(`INFO {"server_id":"test","max_payload":1048576}` + "\r\n")
+	r := bufio.NewReader(c.conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		op, args := splitOp(strings.TrimRight(line, "\r\n"))
+		switch strings.ToUpper(op) {
+		case "CONNECT":
+			s.mu.Lock()
+			s.connects = append(s.connects, args)
+			s.mu.Unlock()
+		case "PING":
+			c.write("PONG\r\n")
+		case "SUB":
+			f := strings.Fields(args)
+			c.mu.Lock()
+			c.subs[f[0]] = f[len(f)-1]
+			c.mu.Unlock()
+		case "PUB":
+			f := strings.Fields(args)
+			size, _ := strconv.Atoi(f[len(f)-1])
+			data := make([]byte, size+2)
+			if _, err := io.ReadFull(r, data); err != nil {
+				return
+			}
+			s.deliver(f[0], data[:size])
+		}
+	}
+}
+
+func (s *testServer) deliver(subject string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.clients {
+		c.mu.Lock()
+		sid, ok := c.subs[subject]
+		c.mu.Unlock()
+		if ok {
+			c.write(fmt.Sprintf("MSG %s %s %d\r\n%s\r\n", subject, sid, len(data), data))
+		}
+	}
+}
+
+func (c *testClient) write(s string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, _ = c.w.WriteString(s)
+	_ = c.w.Flush()
+}
+
+// splitOp splits a control line into an operation name and its arguments.
+func splitOp(line string) (string, string) {
+	if i := strings.IndexAny(line, " \t"); i >= 0 {
+		return line[:i], line[i+1:]
+	}
+	return line, ""
+}
@@ -0,0 +1,405 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package negotiator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
+	"github.com/defiweb/go-eth/wallet"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/sliceutil"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/timeutil"
+)
+
+const LoggerTag = "NEGOTIATOR"
+
+// peerTTLFactor is used to calculate the default peer TTL as a multiple of
+// the greet interval.
+const peerTTLFactor = 3
+
+// Negotiator advertises message topics supported by the node using the Greet
+// message and collects topics supported by other peers. It is then used to
+// select the newest message version supported by all peers, so message
+// formats can be migrated without a simultaneous upgrade of all nodes.
+//
+// Only peers that advertise at least one version of a message are taken into
+// account when selecting the version for that message. Peers that do not
+// send Greet messages at all are unknown to the Negotiator, hence in mixed
+// networks, the old versions should be dual-published until all nodes are
+// upgraded.
+//
+// Greet messages are signed, and peers are identified by the address
+// recovered from the signature. Only greets signed by the addresses on the
+// feeds list are taken into account, and greets older than the peer TTL are
+// rejected, so other nodes cannot downgrade the negotiated versions by
+// advertising old versions or by replaying old greets.
+type Negotiator struct {
+	mu     sync.RWMutex
+	ctx    context.Context
+	waitCh chan error
+	log    log.Logger
+
+	transport   transport.Transport
+	signer      wallet.Key
+	recover     crypto.Recoverer
+	feeds       []types.Address
+	appInfo     transport.AppInfo
+	topics      []string
+	dualPublish []string
+	interval    *timeutil.Ticker
+	peerTTL     time.Duration
+	peers       map[string]*peer
+}
+
+type peer struct {
+	topics   map[string]struct{}
+	appInfo  transport.AppInfo
+	lastSeen time.Time
+}
+
+// Config is the configuration for the Negotiator.
+type Config struct {
+	// Transport is an implementation of transport used to exchange Greet
+	// messages. The transport must support the messages.GreetV1MessageName
+	// topic.
+	Transport transport.Transport
+
+	// Signer is used to sign Greet messages. If nil, the node does not
+	// advertise its capabilities, but it still collects capabilities of
+	// other peers.
+	Signer wallet.Key
+
+	// Recoverer is used to recover the author of Greet messages. If nil,
+	// crypto.ECRecoverer is used.
+	Recoverer crypto.Recoverer
+
+	// Feeds is a list of addresses whose Greet messages are taken into
+	// account. Greets signed by other addresses are ignored.
+	Feeds []types.Address
+
+	// AppName and AppVersion are advertised in the Greet message. They are
+	// signed, so they must be the same as the ones used by the transport.
+	AppName    string
+	AppVersion string
+
+	// Topics is a list of message topics supported by the node.
+	Topics []string
+
+	// DualPublish is a list of topics that are published in addition to the
+	// negotiated version of a message. It is meant to be used during
+	// migrations, when not all peers advertise their capabilities.
+	DualPublish []string
+
+	// Interval describes how often the Greet message is broadcast.
+	Interval *timeutil.Ticker
+
+	// PeerTTL is the time after which a peer that did not send a Greet
+	// message is no longer taken into account. Greets with a timestamp
+	// that differs from the current time by more than the TTL are rejected.
+	// If zero, three times the interval is used. If the interval is also
+	// zero, peers never expire.
+	PeerTTL time.Duration
+
+	// Logger is a current logger interface used by the Negotiator.
+	// If nil, null logger will be used.
+	Logger log.Logger
+}
+
+// Capabilities contains topics supported by a peer.
+type Capabilities struct {
+	Topics   []string
+	AppInfo  transport.AppInfo
+	LastSeen time.Time
+}
+
+// New creates a new instance of the Negotiator.
+func New(cfg Config) (*Negotiator, error) {
+	if cfg.Transport == nil {
+		return nil, errors.New("transport must not be nil")
+	}
+	if cfg.Interval == nil {
+		return nil, errors.New("interval must not be nil")
+	}
+	if len(cfg.Feeds) == 0 {
+		return nil, errors.New("feeds must not be empty")
+	}
+	for _, topic := range cfg.DualPublish {
+		if !sliceutil.Contains(cfg.Topics, topic) {
+			return nil, fmt.Errorf("dual published topic %s is not supported", topic)
+		}
+	}
+	if cfg.PeerTTL == 0 {
+		cfg.PeerTTL = cfg.Interval.Duration() * peerTTLFactor
+	}
+	if cfg.Recoverer == nil {
+		cfg.Recoverer = crypto.ECRecoverer
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = null.New()
+	}
+	topics := sliceutil.Copy(cfg.Topics)
+	sort.Strings(topics)
+	return &Negotiator{
+		waitCh:      make(chan error),
+		log:         cfg.Logger.WithField("tag", LoggerTag),
+		transport:   cfg.Transport,
+		signer:      cfg.Signer,
+		recover:     cfg.Recoverer,
+		feeds:       cfg.Feeds,
+		appInfo:     transport.AppInfo{Name: cfg.AppName, Version: cfg.AppVersion},
+		topics:      topics,
+		dualPublish: cfg.DualPublish,
+		interval:    cfg.Interval,
+		peerTTL:     cfg.PeerTTL,
+		peers:       make(map[string]*peer),
+	}, nil
+}
+
+// Start implements the supervisor.Service interface.
+func (n *Negotiator) Start(ctx context.Context) error {
+	if n.ctx != nil {
+		return errors.New("service can be started only once")
+	}
+	if ctx == nil {
+		return errors.New("context must not be nil")
+	}
+	n.ctx = ctx
+	n.log.
+		WithFields(log.Fields{
+			"topics":      n.topics,
+			"dualPublish": n.dualPublish,
+			"interval":    n.interval.Duration(),
+		}).
+		Debug("Starting")
+	n.interval.Start(n.ctx)
+	go n.greetRoutine()
+	go n.contextCancelHandler()
+	return nil
+}
+
+// Wait implements the supervisor.Service interface.
+func (n *Negotiator) Wait() <-chan error {
+	return n.waitCh
+}
+
+// Topics returns a list of topics on which the given message should be
+// published. The list contains the newest version of the message supported
+// by the node and all peers that support any version of the message, and
+// the dual-published versions.
+//
+// If there is no common version, the oldest version supported by the node
+// is used.
+func (n *Negotiator) Topics(name string) []string {
+	local := messages.TopicVersions(n.topics, name)
+	if len(local) == 0 {
+		return nil
+	}
+	best := n.bestVersion(local)
+	topics := []string{best}
+	for _, topic := range messages.TopicVersions(n.dualPublish, name) {
+		if topic != best {
+			topics = append(topics, topic)
+		}
+	}
+	return topics
+}
+
+// Subscriptions returns a list of topics on which the given message should
+// be received. Because peers may negotiate any version supported by the node,
+// all supported versions are returned, from the newest to the oldest.
+func (n *Negotiator) Subscriptions(name string) []string {
+	return messages.TopicVersions(n.topics, name)
+}
+
+// Peers returns capabilities of the known peers. The key of the map is the
+// address of the Greet message signer.
+func (n *Negotiator) Peers() map[string]Capabilities {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	peers := make(map[string]Capabilities, len(n.peers))
+	for id, p := range n.peers {
+		if n.expired(p) {
+			continue
+		}
+		topics := make([]string, 0, len(p.topics))
+		for topic := range p.topics {
+			topics = append(topics, topic)
+		}
+		sort.Strings(topics)
+		peers[id] = Capabilities{Topics: topics, AppInfo: p.appInfo, LastSeen: p.lastSeen}
+	}
+	return peers
+}
+
+// bestVersion returns the newest topic from the local list that is
+// supported by all active peers interested in the message.
+func (n *Negotiator) bestVersion(local []string) string {
+	name, _, _ := messages.ParseTopic(local[0])
+
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	var peers []*peer
+	for _, p := range n.peers {
+		if n.expired(p) {
+			continue
+		}
+		for topic := range p.topics {
+			if pn, _, ok := messages.ParseTopic(topic); ok && pn == name {
+				peers = append(peers, p)
+				break
+			}
+		}
+	}
+	for _, topic := range local {
+		supported := true
+		for _, p := range peers {
+			if _, ok := p.topics[topic]; !ok {
+				supported = false
+				break
+			}
+		}
+		if supported {
+			return topic
+		}
+	}
+	return local[len(local)-1]
+}
+
+func (n *Negotiator) greet() {
+	if n.signer == nil {
+		return
+	}
+	msg := &messages.Greet{
+		AppInfo:      n.appInfo,
+		Capabilities: n.topics,
+		Timestamp:    time.Now(),
+	}
+	sig, err := n.signer.SignMessage(msg.Hash().Bytes())
+	if err != nil {
+		n.log.WithError(err).Error("Unable to sign the greet message")
+		return
+	}
+	msg.Signature = *sig
+	if err := n.transport.Broadcast(messages.GreetV1MessageName, msg); err != nil {
+		n.log.
+			WithError(err).
+			WithAdvice("Ignore if it is related to temporary network issues").
+			Warn("Failed to broadcast the greet message")
+	}
+}
+
+func (n *Negotiator) handleGreet(msg transport.ReceivedMessage) {
+	if msg.Error != nil {
+		n.log.WithError(msg.Error).Warn("Unable to receive the greet message")
+		return
+	}
+	greet, ok := msg.Message.(*messages.Greet)
+	if !ok {
+		n.log.Error("Unexpected value returned from the transport layer")
+		return
+	}
+	from, err := n.recover.RecoverMessage(greet.Hash().Bytes(), greet.Signature)
+	if err != nil {
+		n.log.WithError(err).Warn("Unable to recover the greet message signer")
+		return
+	}
+	if !sliceutil.Contains(n.feeds, *from) {
+		n.log.
+			WithField("from", from.String()).
+			Debug("Greet message ignored, the signer is not on the feeds list")
+		return
+	}
+	if greet.Timestamp.IsZero() {
+		n.log.WithField("from", from.String()).Warn("Greet message rejected, the timestamp is not set")
+		return
+	}
+	if d := time.Since(greet.Timestamp); n.peerTTL > 0 && (d > n.peerTTL || -d > n.peerTTL) {
+		n.log.
+			WithFields(log.Fields{
+				"from":      from.String(),
+				"timestamp": greet.Timestamp,
+			}).
+			Warn("Greet message rejected, the timestamp is outside the peer TTL")
+		return
+	}
+	topics := make(map[string]struct{}, len(greet.Capabilities))
+	for _, topic := range greet.Capabilities {
+		topics[topic] = struct{}{}
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if p, ok := n.peers[from.String()]; ok && !greet.Timestamp.After(p.lastSeen) {
+		return // Older or replayed greet.
+	}
+	n.peers[from.String()] = &peer{
+		topics:   topics,
+		appInfo:  greet.AppInfo,
+		lastSeen: greet.Timestamp,
+	}
+}
+
+func (n *Negotiator) greetRoutine() {
+	greetCh := n.transport.Messages(messages.GreetV1MessageName)
+	n.greet()
+	for {
+		select {
+		case <-n.ctx.Done():
+			return
+		case <-n.interval.TickCh():
+			n.greet()
+			n.prune()
+		case msg, ok := <-greetCh:
+			if !ok {
+				return
+			}
+			n.handleGreet(msg)
+		}
+	}
+}
+
+// prune removes peers that have not sent the Greet message for longer than
+// the peer TTL.
+func (n *Negotiator) prune() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for id, p := range n.peers {
+		if n.expired(p) {
+			delete(n.peers, id)
+		}
+	}
+}
+
+func (n *Negotiator) expired(p *peer) bool {
+	return n.peerTTL > 0 && time.Since(p.lastSeen) > n.peerTTL
+}
+
+// contextCancelHandler handles context cancellation.
+func (n *Negotiator) contextCancelHandler() {
+	defer func() { close(n.waitCh) }()
+	defer n.log.Debug("Stopped")
+	<-n.ctx.Done()
+}
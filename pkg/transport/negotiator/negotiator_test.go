@@ -0,0 +1,238 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package negotiator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+	"github.com/defiweb/go-eth/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/local"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/timeutil"
+)
+
+func TestNegotiator(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	l := local.New([]byte("a"), 16, map[string]transport.Message{
+		messages.GreetV1MessageName: (*messages.Greet)(nil),
+	})
+	require.NoError(t, l.Start(ctx))
+
+	ka := wallet.NewRandomKey()
+	kb := wallet.NewRandomKey()
+	ta := timeutil.NewTicker(0)
+	tb := timeutil.NewTicker(0)
+	a, err := New(Config{
+		Transport:   l,
+		Signer:      ka,
+		Feeds:       []types.Address{ka.Address(), kb.Address()},
+		Topics:      []string{"price/v0", "price/v1", "data_point/v1"},
+		DualPublish: []string{"price/v1"},
+		Interval:    ta,
+	})
+	require.NoError(t, err)
+
+	// Without known peers, the newest version is used:
+	assert.Equal(t, []string{"price/v1"}, a.Topics("price"))
+	assert.Equal(t, []string{"data_point/v1"}, a.Topics("data_point"))
+	assert.Nil(t, a.Topics("foo"))
+
+	b, err := New(Config{
+		Transport: l.WithAuthor([]byte("b")),
+		Signer:    kb,
+		Feeds:     []types.Address{ka.Address(), kb.Address()},
+		Topics:    []string{"price/v0", "musig_signature/v1"},
+		Interval:  tb,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, a.Start(ctx))
+	require.NoError(t, b.Start(ctx))
+
+	// After the first round both negotiators are subscribed to the greet
+	// topic, so greets from the second round are received by both:
+	for i := 0; i < 2; i++ {
+		ta.Tick()
+		tb.Tick()
+	}
+	assert.Eventually(t, func() bool {
+		return len(a.Peers()) == 2 && len(b.Peers()) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// Peer "b" supports only price/v0, price/v1 is dual-published:
+	assert.Equal(t, []string{"price/v0", "price/v1"}, a.Topics("price"))
+
+	// Peer "b" does not support data points at all, so it is ignored:
+	assert.Equal(t, []string{"data_point/v1"}, a.Topics("data_point"))
+
+	assert.Equal(t, []string{"musig_signature/v1", "price/v0"}, a.Peers()[kb.Address().String()].Topics)
+
+	// Subscribers receive all supported versions:
+	assert.Equal(t, []string{"price/v1", "price/v0"}, a.Subscriptions("price"))
+}
+
+func TestNegotiator_WithoutSigner(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	l := local.New([]byte("a"), 16, map[string]transport.Message{
+		messages.GreetV1MessageName: (*messages.Greet)(nil),
+	})
+	require.NoError(t, l.Start(ctx))
+
+	ka := wallet.NewRandomKey()
+	ta := timeutil.NewTicker(0)
+	tb := timeutil.NewTicker(0)
+	a, err := New(Config{
+		Transport: l,
+		Signer:    ka,
+		Feeds:     []types.Address{ka.Address()},
+		Topics:    []string{"price/v0"},
+		Interval:  ta,
+	})
+	require.NoError(t, err)
+	b, err := New(Config{
+		Transport: l.WithAuthor([]byte("b")),
+		Feeds:     []types.Address{ka.Address()},
+		Topics:    []string{"price/v1"},
+		Interval:  tb,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, a.Start(ctx))
+	require.NoError(t, b.Start(ctx))
+	for i := 0; i < 2; i++ {
+		ta.Tick()
+		tb.Tick()
+	}
+
+	// Peer "b" cannot sign greet messages, so it is not advertised:
+	assert.Eventually(t, func() bool {
+		return len(b.Peers()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Len(t, a.Peers(), 1)
+}
+
+func TestNew_InvalidDualPublish(t *testing.T) {
+	_, err := New(Config{
+		Transport:   local.New(nil, 0, nil),
+		Feeds:       []types.Address{types.ZeroAddress},
+		Topics:      []string{"price/v0"},
+		DualPublish: []string{"price/v1"},
+		Interval:    timeutil.NewTicker(0),
+	})
+	assert.Error(t, err)
+}
+
+func TestNew_WithoutFeeds(t *testing.T) {
+	_, err := New(Config{
+		Transport: local.New(nil, 0, nil),
+		Topics:    []string{"price/v0"},
+		Interval:  timeutil.NewTicker(0),
+	})
+	assert.Error(t, err)
+}
+
+func TestNegotiator_HandleGreet(t *testing.T) {
+	feed := wallet.NewRandomKey()
+	other := wallet.NewRandomKey()
+	signed := func(key wallet.Key, ts time.Time, topics ...string) transport.ReceivedMessage {
+		greet := &messages.Greet{
+			AppInfo:      transport.AppInfo{Name: "ghost", Version: "1.0.0"},
+			Capabilities: topics,
+			Timestamp:    ts,
+		}
+		sig, err := key.SignMessage(greet.Hash().Bytes())
+		require.NoError(t, err)
+		greet.Signature = *sig
+		return transport.ReceivedMessage{Message: greet}
+	}
+	tests := []struct {
+		name   string
+		msgs   []transport.ReceivedMessage
+		topics []string
+	}{
+		{
+			name:   "valid greet",
+			msgs:   []transport.ReceivedMessage{signed(feed, time.Now(), "price/v0")},
+			topics: []string{"price/v0"},
+		},
+		{
+			name: "signer not on the feeds list",
+			msgs: []transport.ReceivedMessage{signed(other, time.Now(), "price/v0")},
+		},
+		{
+			name: "greet older than the peer TTL",
+			msgs: []transport.ReceivedMessage{signed(feed, time.Now().Add(-2*time.Minute), "price/v0")},
+		},
+		{
+			name: "greet from the future",
+			msgs: []transport.ReceivedMessage{signed(feed, time.Now().Add(2*time.Minute), "price/v0")},
+		},
+		{
+			name: "greet without timestamp",
+			msgs: []transport.ReceivedMessage{signed(feed, time.Time{}, "price/v0")},
+		},
+		{
+			name: "replayed older greet",
+			msgs: []transport.ReceivedMessage{
+				signed(feed, time.Now(), "price/v1"),
+				signed(feed, time.Now().Add(-30*time.Second), "price/v0"),
+			},
+			topics: []string{"price/v1"},
+		},
+		{
+			name: "tampered greet",
+			msgs: func() []transport.ReceivedMessage {
+				msg := signed(feed, time.Now(), "price/v1")
+				msg.Message.(*messages.Greet).Capabilities = []string{"price/v0"}
+				return []transport.ReceivedMessage{msg}
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := New(Config{
+				Transport: local.New(nil, 0, nil),
+				Feeds:     []types.Address{feed.Address()},
+				Topics:    []string{"price/v0", "price/v1"},
+				Interval:  timeutil.NewTicker(0),
+				PeerTTL:   time.Minute,
+			})
+			require.NoError(t, err)
+			for _, msg := range tt.msgs {
+				n.handleGreet(msg)
+			}
+			peers := n.Peers()
+			if tt.topics == nil {
+				assert.Empty(t, peers)
+				return
+			}
+			require.Len(t, peers, 1)
+			assert.Equal(t, tt.topics, peers[feed.Address().String()].Topics)
+			assert.Equal(t, transport.AppInfo{Name: "ghost", Version: "1.0.0"}, peers[feed.Address().String()].AppInfo)
+		})
+	}
+}
@@ -0,0 +1,230 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+)
+
+// fileMagic is written at the beginning of every recording. The last byte
+// is the version of the format.
+var fileMagic = []byte{'O', 'S', 'T', 'R', 1}
+
+// maxRecordSize is the maximum size of a single record. It protects against
+// allocating huge amounts of memory when reading a corrupted file.
+const maxRecordSize = 16 * 1024 * 1024
+
+var ErrInvalidFormat = errors.New("invalid recording format")
+
+// Record is a single message stored in a recording.
+type Record struct {
+	// Time is the time when the message was received.
+	Time time.Time
+
+	// Topic is the topic on which the message was received.
+	Topic string
+
+	// Author is the author of the message.
+	Author []byte
+
+	// Data is the raw, binary encoded message, as it was sent over
+	// the network.
+	Data []byte
+
+	// Error is an error returned by the transport. Records with an error
+	// have no data.
+	Error string
+
+	// Meta contains information about the message.
+	Meta transport.Meta
+}
+
+// Writer writes records to an underlying writer.
+//
+// Each record is encoded as a length-prefixed sequence of fields. Integers
+// are encoded as varints, and byte slices and strings are prefixed with
+// their length.
+type Writer struct {
+	w          *bufio.Writer
+	buf        bytes.Buffer
+	headerDone bool
+}
+
+// NewWriter returns a new Writer that writes records to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+// Write writes a single record. Records are buffered, use Flush to write
+// them to the underlying writer.
+func (w *Writer) Write(r Record) error {
+	if !w.headerDone {
+		if _, err := w.w.Write(fileMagic); err != nil {
+			return err
+		}
+		w.headerDone = true
+	}
+	w.buf.Reset()
+	writeVarint(&w.buf, r.Time.UnixNano())
+	writeBytes(&w.buf, []byte(r.Topic))
+	writeBytes(&w.buf, r.Author)
+	writeBytes(&w.buf, r.Data)
+	writeBytes(&w.buf, []byte(r.Error))
+	for _, s := range metaFields(&r.Meta) {
+		writeBytes(&w.buf, []byte(*s))
+	}
+	var lenBuf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(lenBuf[:], uint64(w.buf.Len()))
+	if _, err := w.w.Write(lenBuf[:n]); err != nil {
+		return err
+	}
+	_, err := w.w.Write(w.buf.Bytes())
+	return err
+}
+
+// Flush writes buffered records to the underlying writer.
+func (w *Writer) Flush() error {
+	return w.w.Flush()
+}
+
+// Reader reads records written by the Writer.
+type Reader struct {
+	r          *bufio.Reader
+	headerDone bool
+}
+
+// NewReader returns a new Reader that reads records from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// Read reads the next record. It returns io.EOF when there are no more
+// records.
+func (r *Reader) Read() (Record, error) {
+	if !r.headerDone {
+		magic := make([]byte, len(fileMagic))
+		if _, err := io.ReadFull(r.r, magic); err != nil {
+			if errors.Is(err, io.EOF) {
+				return Record{}, io.EOF
+			}
+			return Record{}, ErrInvalidFormat
+		}
+		if !bytes.Equal(magic, fileMagic) {
+			return Record{}, ErrInvalidFormat
+		}
+		r.headerDone = true
+	}
+	size, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return Record{}, io.EOF
+		}
+		return Record{}, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+	if size > maxRecordSize {
+		return Record{}, fmt.Errorf("%w: record too large", ErrInvalidFormat)
+	}
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(r.r, buf); err != nil {
+		return Record{}, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+	return decodeRecord(buf)
+}
+
+func decodeRecord(buf []byte) (rec Record, err error) {
+	d := &decoder{buf: buf}
+	rec.Time = time.Unix(0, d.varint()).UTC()
+	rec.Topic = string(d.bytes())
+	rec.Author = d.bytes()
+	rec.Data = d.bytes()
+	rec.Error = string(d.bytes())
+	for _, s := range metaFields(&rec.Meta) {
+		*s = string(d.bytes())
+	}
+	if d.err != nil {
+		return Record{}, fmt.Errorf("%w: %v", ErrInvalidFormat, d.err)
+	}
+	return rec, nil
+}
+
+// metaFields returns pointers to the Meta fields in the order in which
+// they are encoded.
+func metaFields(m *transport.Meta) []*string {
+	return []*string{
+		&m.Transport,
+		&m.Topic,
+		&m.MessageID,
+		&m.PeerID,
+		&m.PeerAddr,
+		&m.ReceivedFromPeerID,
+		&m.ReceivedFromPeerAddr,
+		&m.UserAgent,
+	}
+}
+
+func writeVarint(b *bytes.Buffer, v int64) {
+	var buf [binary.MaxVarintLen64]byte
+	b.Write(buf[:binary.PutVarint(buf[:], v)])
+}
+
+func writeBytes(b *bytes.Buffer, v []byte) {
+	var buf [binary.MaxVarintLen64]byte
+	b.Write(buf[:binary.PutUvarint(buf[:], uint64(len(v)))])
+	b.Write(v)
+}
+
+type decoder struct {
+	buf []byte
+	err error
+}
+
+func (d *decoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.buf)
+	if n <= 0 {
+		d.err = errors.New("invalid varint")
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) bytes() []byte {
+	if d.err != nil {
+		return nil
+	}
+	l, n := binary.Uvarint(d.buf)
+	if n <= 0 || uint64(len(d.buf)-n) < l {
+		d.err = errors.New("invalid length")
+		return nil
+	}
+	v := d.buf[n : n+int(l)]
+	d.buf = d.buf[n+int(l):]
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}
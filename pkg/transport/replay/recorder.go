@@ -0,0 +1,163 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package replay
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+)
+
+const RecorderLoggerTag = "TRANSPORT_RECORDER"
+
+// Recorder subscribes to the given topics and writes all received messages
+// to a recording that can be played back later using the Replay transport.
+type Recorder struct {
+	mu     sync.Mutex
+	ctx    context.Context
+	waitCh chan error
+	wg     sync.WaitGroup
+
+	transport transport.Transport
+	topics    []string
+	writer    *Writer
+	log       log.Logger
+}
+
+// RecorderConfig is the configuration for the Recorder.
+type RecorderConfig struct {
+	// Transport is the transport from which messages are recorded.
+	Transport transport.Transport
+
+	// Topics is the list of topics to record.
+	Topics []string
+
+	// Writer is the writer to which the recording is written.
+	Writer io.Writer
+
+	// Logger is a current logger interface.
+	Logger log.Logger
+}
+
+// NewRecorder returns a new instance of the Recorder.
+func NewRecorder(cfg RecorderConfig) (*Recorder, error) {
+	if cfg.Transport == nil {
+		return nil, errors.New("transport must not be nil")
+	}
+	if cfg.Writer == nil {
+		return nil, errors.New("writer must not be nil")
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = null.New()
+	}
+	return &Recorder{
+		waitCh:    make(chan error),
+		transport: cfg.Transport,
+		topics:    cfg.Topics,
+		writer:    NewWriter(cfg.Writer),
+		log:       cfg.Logger.WithField("tag", RecorderLoggerTag),
+	}, nil
+}
+
+// Start implements the supervisor.Service interface.
+func (r *Recorder) Start(ctx context.Context) error {
+	if r.ctx != nil {
+		return errors.New("service can be started only once")
+	}
+	if ctx == nil {
+		return errors.New("context must not be nil")
+	}
+	r.log.Debug("Starting")
+	r.ctx = ctx
+	for _, topic := range r.topics {
+		r.wg.Add(1)
+		go r.recordRoutine(topic, r.transport.Messages(topic))
+	}
+	go r.contextCancelHandler()
+	return nil
+}
+
+// Wait implements the supervisor.Service interface.
+func (r *Recorder) Wait() <-chan error {
+	return r.waitCh
+}
+
+// Record writes a single message to the recording. It may be used to record
+// messages received outside the Recorder, e.g. by a custom subscriber.
+func (r *Recorder) Record(topic string, msg transport.ReceivedMessage) error {
+	rec := Record{
+		Time:   time.Now(),
+		Topic:  topic,
+		Author: msg.Author,
+		Meta:   msg.Meta,
+	}
+	switch {
+	case msg.Error != nil:
+		rec.Error = msg.Error.Error()
+	case msg.Data != nil:
+		// Prefer the raw data received from the network, so the recording
+		// contains exactly the same bytes as the original message.
+		if d, ok := msg.Data.(interface{ GetData() []byte }); ok {
+			rec.Data = d.GetData()
+		}
+	}
+	if rec.Data == nil && msg.Message != nil && msg.Error == nil {
+		data, err := msg.Message.MarshallBinary()
+		if err != nil {
+			return err
+		}
+		rec.Data = data
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.writer.Write(rec); err != nil {
+		return err
+	}
+	return r.writer.Flush()
+}
+
+func (r *Recorder) recordRoutine(topic string, ch <-chan transport.ReceivedMessage) {
+	defer r.wg.Done()
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := r.Record(topic, msg); err != nil {
+				r.log.
+					WithError(err).
+					WithField("topic", topic).
+					Error("Unable to record message")
+			}
+		}
+	}
+}
+
+func (r *Recorder) contextCancelHandler() {
+	defer func() { close(r.waitCh) }()
+	defer r.log.Debug("Stopped")
+	<-r.ctx.Done()
+	r.wg.Wait()
+}
@@ -0,0 +1,300 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package replay
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/chanutil"
+)
+
+const TransportName = "replay"
+const LoggerTag = "TRANSPORT_REPLAY"
+
+var ErrNotSubscribed = errors.New("topic is not subscribed")
+
+// Replay is an implementation of the transport.Service interface that plays
+// back messages from a recording created by the Recorder.
+//
+// Messages are emitted with the same delays between them as in the original
+// recording, divided by the Speed parameter. Messages broadcast using the
+// Broadcast method are delivered to local subscribers only.
+type Replay struct {
+	mu     sync.RWMutex
+	wg     sync.WaitGroup // in-flight sends
+	ctx    context.Context
+	waitCh chan error
+	doneCh chan struct{}
+
+	reader     *Reader
+	closer     io.Closer
+	speed      float64
+	startDelay time.Duration
+	author     []byte
+	subs       map[string]*subscription
+	log        log.Logger
+}
+
+type subscription struct {
+	// typ is the structure type to which the message must be unmarshalled.
+	typ reflect.Type
+
+	// msgCh is a channel used to broadcast unmarshalled messages.
+	msgCh chan transport.ReceivedMessage
+
+	// msgFanOut is a fan-out demultiplexer for the msgCh channel.
+	msgFanOut *chanutil.FanOut[transport.ReceivedMessage]
+}
+
+// Config is the configuration for the Replay transport.
+type Config struct {
+	// Reader is the reader from which the recording is read. It is closed
+	// when the service is stopped.
+	Reader io.ReadCloser
+
+	// Topics is a list of supported topics. The key is the name of the topic
+	// and the value is the type of the message given as a nil pointer,
+	// e.g.: (*Message)(nil). Records with other topics are skipped.
+	Topics map[string]transport.Message
+
+	// Speed is the playback speed. The value of 1 replays messages with
+	// the original timing, greater values accelerate the playback. If zero,
+	// messages are replayed without any delay.
+	Speed float64
+
+	// StartDelay is the time to wait before the playback starts. It gives
+	// other services time to subscribe to topics.
+	StartDelay time.Duration
+
+	// Author is the author used for messages sent using the Broadcast
+	// method.
+	Author []byte
+
+	// Logger is a current logger interface.
+	Logger log.Logger
+}
+
+// New returns a new instance of the Replay transport.
+func New(cfg Config) (*Replay, error) {
+	if cfg.Reader == nil {
+		return nil, errors.New("reader must not be nil")
+	}
+	if cfg.Speed < 0 {
+		return nil, errors.New("speed must not be negative")
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = null.New()
+	}
+	r := &Replay{
+		waitCh:     make(chan error),
+		doneCh:     make(chan struct{}),
+		reader:     NewReader(cfg.Reader),
+		closer:     cfg.Reader,
+		speed:      cfg.Speed,
+		startDelay: cfg.StartDelay,
+		author:     cfg.Author,
+		subs:       make(map[string]*subscription),
+		log:        cfg.Logger.WithField("tag", LoggerTag),
+	}
+	for topic, typ := range cfg.Topics {
+		msgCh := make(chan transport.ReceivedMessage)
+		r.subs[topic] = &subscription{
+			typ:       reflect.TypeOf(typ).Elem(),
+			msgCh:     msgCh,
+			msgFanOut: chanutil.NewFanOut(msgCh),
+		}
+	}
+	return r, nil
+}
+
+// Start implements the transport.Service interface.
+func (r *Replay) Start(ctx context.Context) error {
+	if r.ctx != nil {
+		return errors.New("service can be started only once")
+	}
+	if ctx == nil {
+		return errors.New("context must not be nil")
+	}
+	r.log.Debug("Starting")
+	r.ctx = ctx
+	go r.replayRoutine()
+	go r.contextCancelHandler()
+	return nil
+}
+
+// Wait implements the transport.Service interface.
+func (r *Replay) Wait() <-chan error {
+	return r.waitCh
+}
+
+// Done returns a channel that is closed when all messages from the recording
+// have been replayed.
+func (r *Replay) Done() <-chan struct{} {
+	return r.doneCh
+}
+
+// Broadcast implements the transport.Transport interface.
+func (r *Replay) Broadcast(topic string, message transport.Message) error {
+	if r.ctx == nil {
+		return errors.New("transport is not started")
+	}
+	sub, ok := r.acquire(topic)
+	if !ok {
+		return ErrNotSubscribed
+	}
+	defer r.wg.Done()
+	data, err := message.MarshallBinary()
+	if err != nil {
+		return err
+	}
+	r.send(sub, Record{
+		Time:   time.Now(),
+		Topic:  topic,
+		Author: r.author,
+		Data:   data,
+		Meta:   transport.Meta{Transport: TransportName, Topic: topic},
+	})
+	return nil
+}
+
+// Messages implements the transport.Transport interface.
+func (r *Replay) Messages(topic string) <-chan transport.ReceivedMessage {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if sub, ok := r.subs[topic]; ok {
+		return sub.msgFanOut.Chan()
+	}
+	return nil
+}
+
+func (r *Replay) replayRoutine() {
+	defer close(r.doneCh)
+	if !r.sleep(r.startDelay) {
+		return
+	}
+	var (
+		count int
+		prev  time.Time
+	)
+	for {
+		if r.ctx.Err() != nil {
+			return
+		}
+		rec, err := r.reader.Read()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				r.log.WithError(err).Error("Unable to read the recording")
+			}
+			break
+		}
+		if !prev.IsZero() && r.speed > 0 && rec.Time.After(prev) {
+			if !r.sleep(time.Duration(float64(rec.Time.Sub(prev)) / r.speed)) {
+				return
+			}
+		}
+		prev = rec.Time
+		if sub, ok := r.acquire(rec.Topic); ok {
+			r.send(sub, rec)
+			r.wg.Done()
+			count++
+		}
+	}
+	r.log.WithField("count", count).Info("Replay finished")
+}
+
+// acquire returns the subscription for the given topic. If the
+// subscription exists, it is registered as in use, so its channel is not
+// closed until the caller calls r.wg.Done.
+func (r *Replay) acquire(topic string) (*subscription, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sub, ok := r.subs[topic]
+	if ok {
+		r.wg.Add(1)
+	}
+	return sub, ok
+}
+
+// send unmarshalls a record and sends it to the subscribers. The
+// subscription must be acquired by the caller. If the context is canceled
+// before the message is received, the message is dropped.
+func (r *Replay) send(sub *subscription, rec Record) {
+	msg := transport.ReceivedMessage{
+		Author: rec.Author,
+		Data:   rec.Data,
+		Meta:   rec.Meta,
+	}
+	if msg.Meta.Transport == "" {
+		msg.Meta.Transport = TransportName
+	}
+	if rec.Error != "" {
+		msg.Error = errors.New(rec.Error)
+	} else {
+		m := reflect.New(sub.typ).Interface().(transport.Message)
+		if err := m.UnmarshallBinary(rec.Data); err != nil {
+			msg.Error = err
+		} else {
+			msg.Message = m
+		}
+	}
+	select {
+	case sub.msgCh <- msg:
+	case <-r.ctx.Done():
+	}
+}
+
+// sleep waits for the given duration. It returns false if the context was
+// canceled in the meantime.
+func (r *Replay) sleep(d time.Duration) bool {
+	if d <= 0 {
+		return r.ctx.Err() == nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-r.ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+// contextCancelHandler handles context cancellation.
+func (r *Replay) contextCancelHandler() {
+	defer func() { close(r.waitCh) }()
+	defer r.log.Debug("Stopped")
+	<-r.ctx.Done()
+	r.mu.Lock()
+	subs := r.subs
+	r.subs = nil
+	r.mu.Unlock()
+	r.wg.Wait()
+	for _, sub := range subs {
+		close(sub.msgCh)
+	}
+	<-r.doneCh
+	if err := r.closer.Close(); err != nil {
+		r.log.WithError(err).Error("Unable to close the recording")
+	}
+}
@@ -0,0 +1,221 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package replay
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/local"
+)
+
+type testMsg struct {
+	Val string
+}
+
+func (t *testMsg) MarshallBinary() ([]byte, error) {
+	return []byte(t.Val), nil
+}
+
+func (t *testMsg) UnmarshallBinary(bytes []byte) error {
+	t.Val = string(bytes)
+	return nil
+}
+
+func TestWriterReader(t *testing.T) {
+	recs := []Record{
+		{
+			Time:   time.Unix(100, 5).UTC(),
+			Topic:  "foo",
+			Author: []byte("author"),
+			Data:   []byte("data"),
+			Meta: transport.Meta{
+				Transport:            "libp2p",
+				Topic:                "foo",
+				MessageID:            "id",
+				PeerID:               "peer",
+				PeerAddr:             "/ip4/127.0.0.1",
+				ReceivedFromPeerID:   "peer2",
+				ReceivedFromPeerAddr: "/ip4/127.0.0.2",
+				UserAgent:            "spire/1.0.0",
+			},
+		},
+		{
+			Time:  time.Unix(101, 0).UTC(),
+			Topic: "bar",
+			Error: "error",
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	w := NewWriter(buf)
+	for _, rec := range recs {
+		require.NoError(t, w.Write(rec))
+	}
+	require.NoError(t, w.Flush())
+
+	r := NewReader(buf)
+	for _, rec := range recs {
+		got, err := r.Read()
+		require.NoError(t, err)
+		assert.Equal(t, rec, got)
+	}
+	_, err := r.Read()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestReader_InvalidFormat(t *testing.T) {
+	_, err := NewReader(bytes.NewBufferString("invalid")).Read()
+	assert.ErrorIs(t, err, ErrInvalidFormat)
+
+	// Truncated record:
+	buf := &bytes.Buffer{}
+	w := NewWriter(buf)
+	require.NoError(t, w.Write(Record{Topic: "foo", Data: []byte("data")}))
+	require.NoError(t, w.Flush())
+	_, err = NewReader(bytes.NewReader(buf.Bytes()[:buf.Len()-2])).Read()
+	assert.ErrorIs(t, err, ErrInvalidFormat)
+}
+
+func TestRecorderReplay(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	topics := map[string]transport.Message{"foo": (*testMsg)(nil)}
+
+	// Record messages from the local transport:
+	buf := &bytes.Buffer{}
+	l := local.New([]byte("author"), 0, topics)
+	rec, err := NewRecorder(RecorderConfig{Transport: l, Topics: []string{"foo"}, Writer: buf})
+	require.NoError(t, err)
+	require.NoError(t, l.Start(ctx))
+	require.NoError(t, rec.Start(ctx))
+	ch := l.Messages("foo")
+	for _, v := range []string{"a", "b", "c"} {
+		require.NoError(t, l.Broadcast("foo", &testMsg{Val: v}))
+		<-ch
+	}
+	ctxCancel()
+	<-rec.Wait()
+
+	// Replay recorded messages:
+	ctx, ctxCancel = context.WithCancel(context.Background())
+	defer ctxCancel()
+	r, err := New(Config{Reader: io.NopCloser(buf), Topics: topics})
+	require.NoError(t, err)
+	msgs := r.Messages("foo")
+	require.NoError(t, r.Start(ctx))
+	for _, v := range []string{"a", "b", "c"} {
+		msg := <-msgs
+		require.NoError(t, msg.Error)
+		assert.Equal(t, &testMsg{Val: v}, msg.Message)
+		assert.Equal(t, []byte("author"), msg.Author)
+		assert.Equal(t, local.TransportName, msg.Meta.Transport)
+	}
+	<-r.Done()
+}
+
+func TestReplay_Timing(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	buf := &bytes.Buffer{}
+	w := NewWriter(buf)
+	now := time.Now()
+	require.NoError(t, w.Write(Record{Time: now, Topic: "foo", Data: []byte("a")}))
+	require.NoError(t, w.Write(Record{Time: now.Add(time.Second), Topic: "foo", Data: []byte("b")}))
+	require.NoError(t, w.Flush())
+
+	// With the speed of 10, one second in the recording is 100ms:
+	r, err := New(Config{Reader: io.NopCloser(buf), Topics: map[string]transport.Message{"foo": (*testMsg)(nil)}, Speed: 10})
+	require.NoError(t, err)
+	msgs := r.Messages("foo")
+	require.NoError(t, r.Start(ctx))
+
+	<-msgs
+	start := time.Now()
+	msg := <-msgs
+	assert.Equal(t, &testMsg{Val: "b"}, msg.Message)
+	assert.InDelta(t, 100*time.Millisecond, time.Since(start), float64(80*time.Millisecond))
+	assert.Equal(t, TransportName, msg.Meta.Transport)
+}
+
+func TestReplay_ShutdownWithoutReader(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	buf := &bytes.Buffer{}
+	w := NewWriter(buf)
+	for _, v := range []string{"a", "b", "c"} {
+		require.NoError(t, w.Write(Record{Time: time.Now(), Topic: "foo", Data: []byte(v)}))
+	}
+	require.NoError(t, w.Flush())
+
+	// Messages are never read, the replay must not block the shutdown:
+	r, err := New(Config{Reader: io.NopCloser(buf), Topics: map[string]transport.Message{"foo": (*testMsg)(nil)}})
+	require.NoError(t, err)
+	_ = r.Messages("foo")
+	require.NoError(t, r.Start(ctx))
+	time.Sleep(50 * time.Millisecond)
+	ctxCancel()
+
+	select {
+	case <-r.Wait():
+	case <-time.After(time.Second):
+		t.Fatal("replay did not stop")
+	}
+	assert.ErrorIs(t, r.Broadcast("foo", &testMsg{Val: "d"}), ErrNotSubscribed)
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestReplay_CloseReader(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	buf := &bytes.Buffer{}
+	w := NewWriter(buf)
+	require.NoError(t, w.Write(Record{Time: time.Now(), Topic: "foo", Data: []byte("a")}))
+	require.NoError(t, w.Flush())
+
+	reader := &closeRecorder{Reader: buf}
+	r, err := New(Config{Reader: reader, Topics: map[string]transport.Message{"foo": (*testMsg)(nil)}})
+	require.NoError(t, err)
+	require.NoError(t, r.Start(ctx))
+	ctxCancel()
+
+	select {
+	case <-r.Wait():
+	case <-time.After(time.Second):
+		t.Fatal("replay did not stop")
+	}
+	assert.True(t, reader.closed)
+}
@@ -370,7 +370,7 @@ func (w *WebAPI) flushMessages(ctx context.Context, t time.Time) error {
 	if w.messagePack == nil || len(w.messagePack.Messages) == 0 {
 		return nil // Nothing to send.
 	}
-	if err := signMessage(w.messagePack, w.signer); err != nil {
+	if err := SignMessagePack(w.messagePack, w.signer); err != nil {
 		return err
 	}
 	bin, err := proto.Marshal(w.messagePack)
@@ -618,7 +618,7 @@ func (w *WebAPI) consumeHandler(res http.ResponseWriter, req *http.Request) {
 	// Verify the message signature and verify that the message signer
 	// is same as the request author. There is no scenario in which
 	// message signer and request author signer can be different.
-	messagePackSigner, err := verifyMessage(mp, w.recover)
+	messagePackSigner, err := VerifyMessagePack(mp, w.recover)
 	if err != nil {
 		w.log.
 			WithError(err).
@@ -766,8 +766,8 @@ func verifyURL(url string, recover crypto.Recoverer) (*types.Address, time.Time,
 	return addr, time.Unix(t, 0), nil
 }
 
-// signMessage signs the given message pack with the signer's private key.
-func signMessage(msg *pb.MessagePack, signer wallet.Key) error {
+// SignMessagePack signs the given message pack with the signer's private key.
+func SignMessagePack(msg *pb.MessagePack, signer wallet.Key) error {
 	sig, err := signer.SignMessage(messageSigningData(msg))
 	if err != nil {
 		return err
@@ -776,9 +776,13 @@ func signMessage(msg *pb.MessagePack, signer wallet.Key) error {
 	return nil
 }
 
-// verifyMessage verifies message pack signature and returns signer address.
-func verifyMessage(msg *pb.MessagePack, recover crypto.Recoverer) (*types.Address, error) {
-	return recover.RecoverMessage(messageSigningData(msg), types.MustSignatureFromBytes(msg.Signature))
+// VerifyMessagePack verifies message pack signature and returns signer address.
+func VerifyMessagePack(msg *pb.MessagePack, recover crypto.Recoverer) (*types.Address, error) {
+	sig, err := types.SignatureFromBytes(msg.Signature)
+	if err != nil {
+		return nil, err
+	}
+	return recover.RecoverMessage(messageSigningData(msg), sig)
 }
 
 // messageSigningData returns the data used to sign the given message pack.
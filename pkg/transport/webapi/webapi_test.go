@@ -385,7 +385,7 @@ func Test_WebAPI(t *testing.T) {
 	}
 }
 
-func Test_signMessage(t *testing.T) {
+func TestSignMessagePack(t *testing.T) {
 	var (
 		mp = &pb.MessagePack{
 			Messages: map[string]*pb.MessagePack_Messages{
@@ -416,11 +416,11 @@ func Test_signMessage(t *testing.T) {
 	recoverer.On("RecoverMessage", []byte(expSig), fakeSignature).Return(&address, nil)
 
 	// Sign message:
-	err := signMessage(mp, signer)
+	err := SignMessagePack(mp, signer)
 	require.NoError(t, err)
 
 	// Check signature:
-	retAddress, err := verifyMessage(mp, recoverer)
+	retAddress, err := VerifyMessagePack(mp, recoverer)
 	require.NoError(t, err)
 	assert.Equal(t, &address, retAddress)
 }
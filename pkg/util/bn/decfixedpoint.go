@@ -288,12 +288,31 @@ func (x *DecFixedPointNumber) Inv() *DecFixedPointNumber {
 	return &DecFixedPointNumber{x: bigIntDivRound(pow10(uint32(x.p)*2), x.x), p: x.p}
 }
 
+// Binary formats of DecFixedPointNumber, stored in the first byte of the
+// encoded number.
+//
+// The negative format was added together with rates, bars and vectors, as
+// they may contain negative values. It is a wire format change: older
+// versions reject negative numbers with the "invalid data format" error.
+// Before, the sign was silently dropped. Non-negative numbers are encoded
+// exactly as before, so ticks are not affected.
+const (
+	decFixedPointFormatPositive byte = 0 // non-negative number
+	decFixedPointFormatNegative byte = 1 // negative number, the absolute value is encoded
+)
+
 // MarshalBinary implements the encoding.BinaryMarshaler interface.
+//
+// The number is encoded as the format byte, the precision byte and the
+// big-endian absolute value.
 func (x *DecFixedPointNumber) MarshalBinary() (data []byte, err error) {
 	// Note, that changes in this function may break backward compatibility.
 
 	b := make([]byte, 2+(x.x.BitLen()+7)/8)
-	b[0] = 0 // version, reserved for future use
+	b[0] = decFixedPointFormatPositive
+	if x.x.Sign() < 0 {
+		b[0] = decFixedPointFormatNegative
+	}
 	b[1] = x.p
 	x.x.FillBytes(b[2:])
 	return b, nil
@@ -306,11 +325,14 @@ func (x *DecFixedPointNumber) UnmarshalBinary(data []byte) error {
 	if len(data) < 2 {
 		return errors.New("DecFixedPointNumber.UnmarshalBinary: invalid data length")
 	}
-	if data[0] != 0 {
+	if data[0] != decFixedPointFormatPositive && data[0] != decFixedPointFormatNegative {
 		return errors.New("DecFixedPointNumber.UnmarshalBinary: invalid data format")
 	}
 	x.p = data[1]
 	x.x = new(big.Int).SetBytes(data[2:])
+	if data[0] == decFixedPointFormatNegative {
+		x.x.Neg(x.x)
+	}
 	return nil
 }
 
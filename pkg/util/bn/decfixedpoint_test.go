@@ -565,3 +565,15 @@ func TestDecFixedPointNumber_UnmarshalBinary(t *testing.T) {
 	expectedNumber := &DecFixedPointNumber{x: big.NewInt(10625), p: 2}
 	assert.Equal(t, expectedNumber, number)
 }
+
+func TestDecFixedPointNumber_MarshalBinary_Negative(t *testing.T) {
+	number := &DecFixedPointNumber{x: big.NewInt(-10625), p: 2} // -106.25
+
+	data, err := number.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, append([]byte{1, 2}, big.NewInt(10625).Bytes()...), data)
+
+	decoded := &DecFixedPointNumber{}
+	assert.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, number, decoded)
+}
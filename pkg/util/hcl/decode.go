@@ -98,6 +98,14 @@ func DecodeExpression(ctx *hcl.EvalContext, expr hcl.Expression, val any) hcl.Di
 	if diags.HasErrors() {
 		return diags
 	}
+	ctyVal, err := unmarkValue(ctx, ctyVal)
+	if err != nil {
+		return hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Decode error",
+			Detail:   err.Error(),
+		}}
+	}
 	if err := mapper.Map(ctyVal, val); err != nil {
 		return hcl.Diagnostics{{
 			Severity: hcl.DiagError,
@@ -352,6 +360,17 @@ func decodeAttribute(ctx *hcl.EvalContext, attr *hcl.Attribute, val reflect.Valu
 		return diags
 	}
 
+	// Remove marks, redacting secrets if requested.
+	ctyVal, err := unmarkValue(ctx, ctyVal)
+	if err != nil {
+		return hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Decode error",
+			Detail:   err.Error(),
+			Subject:  &attr.Range,
+		}}
+	}
+
 	// Map the value.
 	if err := mapper.MapRefl(reflect.ValueOf(ctyVal), val); err != nil {
 		return hcl.Diagnostics{{
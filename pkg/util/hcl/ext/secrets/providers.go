@@ -0,0 +1,221 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const defaultHTTPTimeout = 10 * time.Second
+
+// envProvider returns secrets from environment variables.
+type envProvider struct{}
+
+// Secret implements the Provider interface.
+func (envProvider) Secret(ref string) (string, error) {
+	val, ok := os.LookupEnv(ref)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", ref)
+	}
+	return val, nil
+}
+
+// fileProvider returns secrets from files. The ref is the path to the file.
+type fileProvider struct{}
+
+// Secret implements the Provider interface.
+func (fileProvider) Secret(ref string) (string, error) {
+	b, err := os.ReadFile(ref)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(b), "\n"), nil
+}
+
+// Parameters of the scrypt key derivation function used to derive the
+// encryption key from the passphrase.
+const (
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	scryptSalt   = 32
+)
+
+// encryptedFile is the format of the encrypted secrets file.
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// EncryptedFileProvider returns secrets from a local file encrypted with
+// a passphrase. The file contains a JSON object with secret names as keys,
+// encrypted using AES-256-GCM with a key derived from the passphrase using
+// scrypt. Files can be created using the EncryptSecrets function.
+type EncryptedFileProvider struct {
+	secrets map[string]string
+}
+
+// NewEncryptedFileProvider reads and decrypts the secrets file at the given
+// path.
+func NewEncryptedFileProvider(path string, passphrase []byte) (*EncryptedFileProvider, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := DecryptSecrets(b, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return &EncryptedFileProvider{secrets: secrets}, nil
+}
+
+// Secret implements the Provider interface.
+func (p *EncryptedFileProvider) Secret(ref string) (string, error) {
+	val, ok := p.secrets[ref]
+	if !ok {
+		return "", fmt.Errorf("secret %s not found", ref)
+	}
+	return val, nil
+}
+
+// EncryptSecrets encrypts the given secrets using the given passphrase and
+// returns the content of the encrypted secrets file.
+func EncryptSecrets(secrets map[string]string, passphrase []byte) ([]byte, error) {
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, scryptSalt)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(encryptedFile{
+		Version:    1,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, plaintext, nil),
+	}, "", "  ")
+}
+
+// DecryptSecrets decrypts the content of the encrypted secrets file created
+// by the EncryptSecrets function.
+func DecryptSecrets(content []byte, passphrase []byte) (map[string]string, error) {
+	var file encryptedFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("invalid secrets file: %w", err)
+	}
+	if file.Version != 1 {
+		return nil, fmt.Errorf("unsupported secrets file version: %d", file.Version)
+	}
+	aead, err := newAEAD(passphrase, file.Salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid nonce size")
+	}
+	plaintext, err := aead.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("unable to decrypt secrets, invalid passphrase")
+	}
+	var secrets map[string]string
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, fmt.Errorf("invalid secrets file: %w", err)
+	}
+	return secrets, nil
+}
+
+func newAEAD(passphrase, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// HTTPProvider returns secrets from an HTTP endpoint. The secret is fetched
+// using a GET request to the endpoint URL with the ref appended to the path.
+// The response body, without the trailing newline, is used as the secret
+// value. Any status code other than 200 is treated as an error.
+type HTTPProvider struct {
+	url     string
+	headers http.Header
+	client  *http.Client
+}
+
+// NewHTTPProvider creates a new HTTPProvider. Headers are sent with every
+// request. If client is nil, a client with a default timeout is used.
+func NewHTTPProvider(url string, headers http.Header, client *http.Client) *HTTPProvider {
+	if client == nil {
+		client = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+	return &HTTPProvider{
+		url:     strings.TrimSuffix(url, "/"),
+		headers: headers,
+		client:  client,
+	}
+}
+
+// Secret implements the Provider interface.
+func (p *HTTPProvider) Secret(ref string) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, p.url+"/"+strings.TrimPrefix(ref, "/"), nil)
+	if err != nil {
+		return "", err
+	}
+	for k, v := range p.headers {
+		req.Header[k] = v
+	}
+	res, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code: %d", res.StatusCode)
+	}
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(b), "\n"), nil
+}
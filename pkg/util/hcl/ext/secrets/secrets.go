@@ -0,0 +1,259 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package secrets
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+
+	utilHCL "github.com/chronicleprotocol/oracle-suite/pkg/util/hcl"
+)
+
+const (
+	// secretsBlockName is the name of the custom block type that allows the
+	// definition of secret providers.
+	secretsBlockName = "secrets"
+
+	// providerBlockName is the name of the block that defines a single
+	// provider within the "secrets" block.
+	providerBlockName = "provider"
+
+	// secretFuncName is the name of the function used to reference secrets.
+	secretFuncName = "secret"
+)
+
+// Built-in providers that are always available.
+const (
+	envProviderName  = "env"
+	fileProviderName = "file"
+)
+
+// Provider types that can be defined in the "secrets" block.
+const (
+	encryptedFileProviderType = "encrypted_file"
+	httpProviderType          = "http"
+)
+
+// Provider resolves secret references to their values.
+type Provider interface {
+	// Secret returns the value of the secret identified by ref.
+	Secret(ref string) (string, error)
+}
+
+type secretsConfig struct {
+	Providers []providerConfig `hcl:"provider,block"`
+}
+
+type providerConfig struct {
+	Name string `hcl:"name,label"`
+	Type string `hcl:"type"`
+
+	// Encrypted file provider:
+	Path           string `hcl:"path,optional"`
+	Passphrase     string `hcl:"passphrase,optional"`
+	PassphraseFile string `hcl:"passphrase_file,optional"`
+
+	// HTTP provider:
+	URL     string            `hcl:"url,optional"`
+	Headers map[string]string `hcl:"headers,optional"`
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
+}
+
+// Secrets is a custom block type that allows the definition of secret
+// providers within the "secrets" block. Secrets can be referenced anywhere
+// in the configuration using the "secret" function, which takes the provider
+// name and the secret reference as arguments.
+//
+// The "env" and "file" providers are always available. The first returns
+// the value of an environment variable, the second returns the content of
+// a file without the trailing newline. Additional providers can be defined
+// using the "encrypted_file" and "http" types.
+//
+// Secrets are resolved when the configuration is loaded. Resolved values are
+// remembered, so they can be removed from rendered configurations using the
+// Redact and RedactJSON functions.
+//
+// Example:
+//
+//	secrets {
+//	  provider "local" {
+//	    type            = "encrypted_file"
+//	    path            = "./secrets.json"
+//	    passphrase_file = "./secrets.pass"
+//	  }
+//	  provider "vault" {
+//	    type    = "http"
+//	    url     = "http://127.0.0.1:8200/secrets"
+//	    headers = { "Authorization" = "Bearer ${env("VAULT_TOKEN", "")}" }
+//	  }
+//	}
+//
+//	block "example" {
+//	  api_key    = secret("local", "binance_api_key")
+//	  api_secret = secret("vault", "binance/api_secret")
+//	  passphrase = secret("env", "ETH_PASSPHRASE")
+//	}
+func Secrets(ctx *hcl.EvalContext, body hcl.Body) (hcl.Body, hcl.Diagnostics) {
+	if ctx.Functions == nil {
+		ctx.Functions = map[string]function.Function{}
+	}
+
+	// Decode the "secrets" block.
+	content, remain, diags := body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: secretsBlockName}},
+	})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	// Configure providers.
+	providers := map[string]Provider{
+		envProviderName:  envProvider{},
+		fileProviderName: fileProvider{},
+	}
+	for _, block := range content.Blocks.OfType(secretsBlockName) {
+		var cfg secretsConfig
+		if diags := utilHCL.DecodeBlock(ctx, block, &cfg); diags.HasErrors() {
+			return nil, diags
+		}
+		for _, p := range cfg.Providers {
+			if _, ok := providers[p.Name]; ok {
+				return nil, hcl.Diagnostics{{
+					Severity: hcl.DiagError,
+					Summary:  "Validation error",
+					Detail:   fmt.Sprintf("Secret provider %q is already defined", p.Name),
+					Subject:  p.Range.Ptr(),
+				}}
+			}
+			provider, diags := p.provider()
+			if diags.HasErrors() {
+				return nil, diags
+			}
+			providers[p.Name] = provider
+		}
+	}
+
+	ctx.Functions[secretFuncName] = secretFunc(providers)
+	return remain, diags
+}
+
+func (c *providerConfig) provider() (Provider, hcl.Diagnostics) {
+	switch c.Type {
+	case encryptedFileProviderType:
+		if c.Path == "" {
+			return nil, c.requiredAttr("path")
+		}
+		passphrase := c.Passphrase
+		if c.PassphraseFile != "" {
+			if c.Passphrase != "" {
+				return nil, hcl.Diagnostics{{
+					Severity: hcl.DiagError,
+					Summary:  "Validation error",
+					Detail:   "Only one of passphrase and passphrase_file can be set",
+					Subject:  c.Content.Attributes["passphrase_file"].Range.Ptr(),
+				}}
+			}
+			b, err := os.ReadFile(c.PassphraseFile)
+			if err != nil {
+				return nil, hcl.Diagnostics{{
+					Severity: hcl.DiagError,
+					Summary:  "Validation error",
+					Detail:   fmt.Sprintf("Failed to read passphrase file: %v", err),
+					Subject:  c.Content.Attributes["passphrase_file"].Range.Ptr(),
+				}}
+			}
+			passphrase = strings.TrimSuffix(string(b), "\n")
+		}
+		provider, err := NewEncryptedFileProvider(c.Path, []byte(passphrase))
+		if err != nil {
+			return nil, hcl.Diagnostics{{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   fmt.Sprintf("Failed to open encrypted secrets file: %v", err),
+				Subject:  c.Content.Attributes["path"].Range.Ptr(),
+			}}
+		}
+		return provider, nil
+	case httpProviderType:
+		if c.URL == "" {
+			return nil, c.requiredAttr("url")
+		}
+		headers := http.Header{}
+		for k, v := range c.Headers {
+			headers.Set(k, v)
+		}
+		return NewHTTPProvider(c.URL, headers, nil), nil
+	default:
+		return nil, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   fmt.Sprintf("Unknown secret provider type: %s", c.Type),
+			Subject:  c.Content.Attributes["type"].Range.Ptr(),
+		}}
+	}
+}
+
+func (c *providerConfig) requiredAttr(name string) hcl.Diagnostics {
+	return hcl.Diagnostics{{
+		Severity: hcl.DiagError,
+		Summary:  "Validation error",
+		Detail:   fmt.Sprintf("The %s attribute is required for the %s secret provider", name, c.Type),
+		Subject:  c.Range.Ptr(),
+	}}
+}
+
+// secretFunc returns the "secret" function that resolves secrets using the
+// given providers. Resolved secrets are cached, so each secret is fetched
+// only once per configuration load. Returned values are marked with
+// utilHCL.SecretMark, so they can be redacted during decoding.
+func secretFunc(providers map[string]Provider) function.Function {
+	cache := map[[2]string]string{}
+	return function.New(&function.Spec{
+		Description: `Returns the secret for a given provider and reference.`,
+		Params: []function.Parameter{
+			{Name: "provider", Type: cty.String},
+			{Name: "ref", Type: cty.String},
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			name := args[0].AsString()
+			ref := args[1].AsString()
+			key := [2]string{name, ref}
+			if val, ok := cache[key]; ok {
+				return cty.StringVal(val).Mark(utilHCL.SecretMark), nil
+			}
+			provider, ok := providers[name]
+			if !ok {
+				return cty.NilVal, fmt.Errorf("unknown secret provider: %s", name)
+			}
+			val, err := provider.Secret(ref)
+			if err != nil {
+				return cty.NilVal, fmt.Errorf("unable to resolve secret %q from %s provider: %w", ref, name, err)
+			}
+			cache[key] = val
+			return cty.StringVal(val).Mark(utilHCL.SecretMark), nil
+		},
+	})
+}
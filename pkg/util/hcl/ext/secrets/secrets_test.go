@@ -0,0 +1,208 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package secrets
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	utilHCL "github.com/chronicleprotocol/oracle-suite/pkg/util/hcl"
+)
+
+type testConfig struct {
+	Value string `hcl:"value"`
+}
+
+func loadTestConfig(t *testing.T, src string) (string, error) {
+	body, diags := utilHCL.ParseBytesList([][]byte{[]byte(src)})
+	require.False(t, diags.HasErrors(), diags.Error())
+	ctx := &hcl.EvalContext{}
+	body, diags = Secrets(ctx, body)
+	if diags.HasErrors() {
+		return "", diags
+	}
+	var cfg testConfig
+	if diags := utilHCL.Decode(ctx, body, &cfg); diags.HasErrors() {
+		return "", diags
+	}
+	return cfg.Value, nil
+}
+
+func TestSecrets(t *testing.T) {
+	dir := t.TempDir()
+
+	// Secrets file for the file provider.
+	secretPath := filepath.Join(dir, "secret")
+	require.NoError(t, os.WriteFile(secretPath, []byte("file-secret\n"), 0600))
+
+	// Encrypted secrets file.
+	encrypted, err := EncryptSecrets(map[string]string{"api_key": "encrypted-secret"}, []byte("pass"))
+	require.NoError(t, err)
+	encryptedPath := filepath.Join(dir, "secrets.json")
+	require.NoError(t, os.WriteFile(encryptedPath, encrypted, 0600))
+
+	// Stub of the HTTP secrets endpoint.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/secrets/binance/api_key" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("http-secret\n"))
+	}))
+	defer srv.Close()
+
+	t.Setenv("SECRETS_TEST_ENV", "env-secret")
+
+	providers := fmt.Sprintf(`
+secrets {
+  provider "local" {
+    type       = "encrypted_file"
+    path       = %q
+    passphrase = "pass"
+  }
+  provider "remote" {
+    type    = "http"
+    url     = "%s/secrets/"
+    headers = { "Authorization" = "Bearer token" }
+  }
+}
+`, encryptedPath, srv.URL)
+
+	tests := []struct {
+		name    string
+		src     string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "env",
+			src:  `value = secret("env", "SECRETS_TEST_ENV")`,
+			want: "env-secret",
+		},
+		{
+			name: "file",
+			src:  fmt.Sprintf(`value = secret("file", %q)`, secretPath),
+			want: "file-secret",
+		},
+		{
+			name: "encrypted-file",
+			src:  providers + `value = secret("local", "api_key")`,
+			want: "encrypted-secret",
+		},
+		{
+			name: "http",
+			src:  providers + `value = "key=${secret("remote", "binance/api_key")}"`,
+			want: "key=http-secret",
+		},
+		{
+			name:    "missing-env",
+			src:     `value = secret("env", "SECRETS_TEST_MISSING")`,
+			wantErr: "environment variable SECRETS_TEST_MISSING is not set",
+		},
+		{
+			name:    "missing-encrypted",
+			src:     providers + `value = secret("local", "missing")`,
+			wantErr: "secret missing not found",
+		},
+		{
+			name:    "missing-http",
+			src:     providers + `value = secret("remote", "missing")`,
+			wantErr: "unexpected status code: 404",
+		},
+		{
+			name:    "unknown-provider",
+			src:     `value = secret("vault", "foo")`,
+			wantErr: "unknown secret provider: vault",
+		},
+		{
+			name: "invalid-passphrase",
+			src: fmt.Sprintf(`
+secrets {
+  provider "local" {
+    type       = "encrypted_file"
+    path       = %q
+    passphrase = "wrong"
+  }
+}
+value = "foo"
+`, encryptedPath),
+			wantErr: "invalid passphrase",
+		},
+		{
+			name: "reserved-name",
+			src: `
+secrets {
+  provider "env" {
+    type = "http"
+    url  = "http://localhost"
+  }
+}
+value = "foo"
+`,
+			wantErr: `Secret provider "env" is already defined`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			val, err := loadTestConfig(t, tt.src)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, val)
+		})
+	}
+}
+
+func TestSecrets_Redacted(t *testing.T) {
+	t.Setenv("SECRETS_TEST_ENV", "env-secret")
+
+	body, diags := utilHCL.ParseBytesList([][]byte{[]byte(`
+value   = "key=${secret("env", "SECRETS_TEST_ENV")}"
+plain   = "env-secret"
+headers = { "Authorization" = secret("env", "SECRETS_TEST_ENV"), "Accept" = "text/plain" }
+`)})
+	require.False(t, diags.HasErrors(), diags.Error())
+	ctx := &hcl.EvalContext{}
+	body, diags = Secrets(ctx, body)
+	require.False(t, diags.HasErrors(), diags.Error())
+
+	var cfg struct {
+		Value   string            `hcl:"value"`
+		Plain   string            `hcl:"plain"`
+		Headers map[string]string `hcl:"headers"`
+	}
+	diags = utilHCL.Decode(utilHCL.RedactSecrets(ctx, func(string) string { return utilHCL.Redacted }), body, &cfg)
+	require.False(t, diags.HasErrors(), diags.Error())
+
+	assert.Equal(t, utilHCL.Redacted, cfg.Value)
+	assert.Equal(t, "env-secret", cfg.Plain)
+	assert.Equal(t, map[string]string{"Authorization": utilHCL.Redacted, "Accept": "text/plain"}, cfg.Headers)
+}
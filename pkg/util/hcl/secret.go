@@ -0,0 +1,104 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package hcl
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// SecretMark is a cty mark for values that contain secrets. Marks are
+// removed during decoding, so decoded Go values are never marked.
+const SecretMark = secretMark("secret")
+
+// Redacted is the placeholder that replaces secrets in redacted values.
+const Redacted = "[REDACTED]"
+
+type secretMark string
+
+// redactFuncName is the name under which the redact function is stored in
+// the evaluation context. It is not a valid identifier, so it cannot be
+// called from HCL.
+const redactFuncName = "$redact"
+
+// RedactSecrets returns a child of the given evaluation context. Strings
+// marked with SecretMark that are decoded using the returned context are
+// replaced by the result of the redact function.
+func RedactSecrets(ctx *hcl.EvalContext, redact func(string) string) *hcl.EvalContext {
+	child := ctx.NewChild()
+	child.Functions = map[string]function.Function{
+		redactFuncName: function.New(&function.Spec{
+			Params: []function.Parameter{{Name: "str", Type: cty.String}},
+			Type:   function.StaticReturnType(cty.String),
+			Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+				return cty.StringVal(redact(args[0].AsString())), nil
+			},
+		}),
+	}
+	return child
+}
+
+// unmarkValue removes all marks from the given value. If the context was
+// created by RedactSecrets, secret strings are redacted and other secret
+// primitive values are replaced by nulls.
+func unmarkValue(ctx *hcl.EvalContext, val cty.Value) (cty.Value, error) {
+	val, pvm := val.UnmarkDeepWithPaths()
+	redact, ok := redactFunc(ctx)
+	if !ok {
+		return val, nil
+	}
+	var secrets []cty.Path
+	for _, pm := range pvm {
+		if _, ok := pm.Marks[SecretMark]; ok {
+			secrets = append(secrets, pm.Path)
+		}
+	}
+	if len(secrets) == 0 {
+		return val, nil
+	}
+	return cty.Transform(val, func(path cty.Path, v cty.Value) (cty.Value, error) {
+		if !v.Type().IsPrimitiveType() || !v.IsKnown() || v.IsNull() || !isSecretPath(secrets, path) {
+			return v, nil
+		}
+		if v.Type() != cty.String {
+			return cty.NullVal(v.Type()), nil
+		}
+		return redact.Call([]cty.Value{v})
+	})
+}
+
+// redactFunc looks up the redact function in the given context and its
+// parents.
+func redactFunc(ctx *hcl.EvalContext) (function.Function, bool) {
+	for c := ctx; c != nil; c = c.Parent() {
+		if fn, ok := c.Functions[redactFuncName]; ok {
+			return fn, true
+		}
+	}
+	return function.Function{}, false
+}
+
+// isSecretPath returns true if the path is one of the secret paths or is
+// nested in one of them.
+func isSecretPath(secrets []cty.Path, path cty.Path) bool {
+	for _, s := range secrets {
+		if len(s) <= len(path) && s.Equals(path[:len(s)]) {
+			return true
+		}
+	}
+	return false
+}